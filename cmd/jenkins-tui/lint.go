@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/elogrono/jenkins-tui/internal/audit"
+	"github.com/elogrono/jenkins-tui/internal/config"
+	"github.com/elogrono/jenkins-tui/internal/jenkins"
+	"github.com/elogrono/jenkins-tui/internal/logger"
+)
+
+// runLint validates a declarative Jenkinsfile on the configured controller
+// and prints its errors as file:line:column: message. It returns the exit
+// code: 0 when valid, 1 on lint errors and 2 when linting failed.
+func runLint(cfg *config.Config, args []string) int {
+	flags := flag.NewFlagSet("lint", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: jenkins-tui lint [Jenkinsfile]")
+		fmt.Fprintln(flags.Output(), "Validates a declarative Jenkinsfile (default ./Jenkinsfile, - for stdin) on the configured Jenkins.")
+	}
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+	if flags.NArg() > 1 {
+		flags.Usage()
+		return 2
+	}
+	path := "Jenkinsfile"
+	if flags.NArg() == 1 {
+		path = flags.Arg(0)
+	}
+
+	if !cfg.IsConfigured() {
+		fmt.Fprintln(os.Stderr, "No Jenkins profile configured: run jenkins-tui to set one up")
+		return 2
+	}
+
+	var content []byte
+	var err error
+	if path == "-" {
+		path = "<stdin>"
+		content, err = io.ReadAll(os.Stdin)
+	} else {
+		content, err = os.ReadFile(path)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading Jenkinsfile: %v\n", err)
+		return 2
+	}
+
+	client, err := jenkins.NewClient(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+		return 2
+	}
+	// Lints are audited like in the TUI
+	if auditPath, err := audit.DefaultPath(); err == nil {
+		client.SetAuditLog(audit.New(auditPath))
+	} else {
+		logger.Warn("Audit log disabled", "error", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+	result, err := client.Lint(ctx, string(content))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error linting %s: %v\n", path, err)
+		return 2
+	}
+
+	if result.Valid {
+		fmt.Printf("%s: valid\n", path)
+		return 0
+	}
+	for _, e := range result.Errors {
+		if pos := e.Position(); pos != "" {
+			fmt.Printf("%s:%s: %s\n", path, pos, e.Message)
+		} else {
+			fmt.Printf("%s: %s\n", path, e.Message)
+		}
+	}
+	return 1
+}
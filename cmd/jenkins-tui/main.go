@@ -24,6 +24,11 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Subcommands run without the UI
+	if len(os.Args) > 1 && os.Args[1] == "lint" {
+		os.Exit(runLint(cfg, os.Args[2:]))
+	}
+
 	// Create and run the program
 	m := app.NewModel(cfg)
 	p := tea.NewProgram(m, tea.WithAltScreen())
@@ -0,0 +1,75 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/elogrono/jenkins-tui/internal/jenkins"
+	"github.com/elogrono/jenkins-tui/internal/ui/theme"
+)
+
+// confirmAction is a mutating action waiting for y/n confirmation
+type confirmAction struct {
+	prompt string
+	run    tea.Cmd
+}
+
+// ActionResultMsg reports the outcome of a mutating action
+type ActionResultMsg struct {
+	Message string
+	Error   error
+	Refresh bool
+}
+
+// canPerform reports whether writes are unlocked and the client believes the
+// user holds the permission on the job, or on the build for Run/* permissions.
+// A nil client (as used in view tests) allows everything.
+func canPerform(client *jenkins.Client, p jenkins.Permission, job string, build int) bool {
+	if client == nil {
+		return true
+	}
+	return client.WritesAllowed() && client.Can(p, job, build)
+}
+
+// blockedNotice returns the message shown when an action is not available
+func blockedNotice(client *jenkins.Client, p jenkins.Permission) string {
+	if client != nil && !client.WritesAllowed() {
+		return "Read-only profile: press Ctrl+X to unlock writes"
+	}
+	return fmt.Sprintf("Not permitted: your account lacks %s", p.String())
+}
+
+// renderConfirmPrompt renders the y/n confirmation bar
+func renderConfirmPrompt(prompt string, width int) string {
+	return lipgloss.NewStyle().
+		Background(theme.Surface).
+		Width(width).
+		Padding(0, 1).
+		Render(theme.WarningStyle.Render(theme.IconWarning+" "+prompt) + theme.MutedStyle.Render("  [y/N]"))
+}
+
+// renderNotice renders the result of the last action
+func renderNotice(message string, isError bool) string {
+	if message == "" {
+		return ""
+	}
+	if isError {
+		return theme.ErrorStyle.Render(" " + theme.IconFailure + " " + message)
+	}
+	return theme.SuccessStyle.Render(" " + theme.IconSuccess + " " + message)
+}
+
+// runAction executes a client call and wraps the result in an ActionResultMsg
+func runAction(success string, fn func(ctx context.Context) error) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if err := fn(ctx); err != nil {
+			return ActionResultMsg{Error: err}
+		}
+		return ActionResultMsg{Message: success, Refresh: true}
+	}
+}
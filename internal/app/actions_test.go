@@ -0,0 +1,481 @@
+package app
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/elogrono/jenkins-tui/internal/config"
+	"github.com/elogrono/jenkins-tui/internal/jenkins"
+	"github.com/elogrono/jenkins-tui/internal/jenkins/models"
+)
+
+func TestBuildsModelAbortRequiresConfirmation(t *testing.T) {
+	m := &BuildsModel{
+		width:     100,
+		height:    40,
+		mode:      ModeBuildList,
+		jobDetail: &models.JobDetail{Name: "deploy"},
+		builds: []models.BuildRef{
+			{Number: 12, Building: true},
+			{Number: 11, Result: "SUCCESS"},
+		},
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	if m.confirm == nil {
+		t.Fatal("expected abort to ask for confirmation")
+	}
+	if m.confirm.prompt != "Abort deploy #12?" {
+		t.Errorf("unexpected prompt %q", m.confirm.prompt)
+	}
+
+	// Any key other than y cancels
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	if m.confirm != nil {
+		t.Error("expected confirmation to be cleared")
+	}
+	if m.notice != "Cancelled" {
+		t.Errorf("expected cancelled notice, got %q", m.notice)
+	}
+
+	// Finished builds cannot be aborted
+	m.selectedBuild = 1
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	if m.confirm != nil {
+		t.Error("expected no confirmation for a finished build")
+	}
+}
+
+func TestJobConfigEdit(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	var mu sync.Mutex
+	current := "<project>\n  <description>v1</description>\n  <disabled>false</disabled>\n</project>\n"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.URL.Path != "/job/app/config.xml" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Method == http.MethodPost {
+			body, _ := io.ReadAll(r.Body)
+			current = string(body)
+		}
+		w.Write([]byte(current))
+	}))
+	defer server.Close()
+
+	cfg := config.DefaultConfig()
+	cfg.Profile.BaseURL = server.URL
+	cfg.Profile.Name = "staging"
+	client, err := jenkins.NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	m := NewBuildsModel(client, 120, 40)
+	m.loading = false
+	m.mode = ModeBuildList
+	m.jobDetail = &models.JobDetail{Name: "app"}
+
+	m.Update(m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})())
+	if m.mode != ModeJobConfig || !strings.Contains(m.View(), "<description>v1</description>") {
+		t.Fatalf("expected config.xml shown, got mode %d", m.mode)
+	}
+
+	// What the editor would leave behind
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "config.xml"), []byte(strings.Replace(current, "v1", "v2", 1)), 0o600)
+	m.Update(JobConfigEditedMsg{JobName: "app", Dir: dir})
+	if view := m.View(); !strings.Contains(view, "+  <description>v2</description>") || !strings.Contains(view, "Unsaved changes") {
+		t.Fatal("expected the diff of the edit")
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.confirm == nil || m.confirm.prompt != "Save config.xml of app (+1 -1 lines)?" {
+		t.Fatalf("expected a save confirmation, got %+v", m.confirm)
+	}
+	saved := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})()
+	if msg := saved.(JobConfigSavedMsg); msg.Error != nil {
+		t.Fatalf("save failed: %v", msg.Error)
+	}
+	m.Update(m.Update(saved)())
+	if !strings.Contains(current, "v2") || m.configEdited != "" || !strings.Contains(m.View(), "<description>v2</description>") {
+		t.Errorf("expected the edit saved and reloaded, got %q", current)
+	}
+
+	backups, _ := filepath.Glob(filepath.Join(os.Getenv("HOME"), ".config", "jenkins-tui", configBackupDirName, "staging", "app", "config-*.xml"))
+	if len(backups) != 1 {
+		t.Fatalf("expected one backup, got %v", backups)
+	}
+	if backup, _ := os.ReadFile(backups[0]); !strings.Contains(string(backup), "v1") {
+		t.Errorf("expected the previous config in the backup, got %q", backup)
+	}
+
+	// Saving over a configuration changed by someone else is refused
+	dir = t.TempDir()
+	os.WriteFile(filepath.Join(dir, "config.xml"), []byte(strings.Replace(current, "v2", "v3", 1)), 0o600)
+	m.Update(JobConfigEditedMsg{JobName: "app", Dir: dir})
+	mu.Lock()
+	current = strings.Replace(current, "false", "true", 1)
+	mu.Unlock()
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m.Update(m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})())
+	if !m.noticeErr || !strings.Contains(m.notice, "changed on Jenkins") || strings.Contains(current, "v3") {
+		t.Errorf("expected the save refused, got %q", m.notice)
+	}
+}
+
+func TestBackupJobConfig(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2024, 5, 1, 12, 30, 0, 123e6, time.UTC)
+
+	first, err := backupJobConfig(dir, "prod", "team/app", "<a/>", now)
+	if err != nil {
+		t.Fatalf("backupJobConfig failed: %v", err)
+	}
+	if want := filepath.Join(dir, "prod", "team%2Fapp", "config-20240501-123000.123.xml"); first != want {
+		t.Errorf("expected %s, got %s", want, first)
+	}
+	second, err := backupJobConfig(dir, "prod", "team/app", "<b/>", now)
+	if err != nil || filepath.Base(second) != "config-20240501-123000.123-1.xml" {
+		t.Fatalf("expected a numbered backup, got %s, %v", second, err)
+	}
+	if data, _ := os.ReadFile(first); string(data) != "<a/>" {
+		t.Errorf("expected the first backup kept, got %q", data)
+	}
+}
+
+func TestJobLifecycleActions(t *testing.T) {
+	m := NewViewsModel(nil, 120, 40)
+	m.loading = false
+	m.mode = ViewsModeJobs
+	m.views = []models.View{{Name: "all"}}
+	m.jobs = []models.Job{{Name: "app", Color: "disabled"}}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("E")})
+	if m.confirm == nil || m.confirm.prompt != "Enable app?" {
+		t.Fatalf("expected an enable confirmation, got %+v", m.confirm)
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("C")})
+	if !m.InputActive() || !strings.Contains(m.View(), "Copy app as:") {
+		t.Fatal("expected the copy prompt")
+	}
+	m.copyPrompt.input.SetValue("app/copy")
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.confirm != nil || !m.noticeErr || !strings.Contains(m.notice, `"/" is not allowed`) {
+		t.Fatalf("expected the name refused, got %q", m.notice)
+	}
+	m.copyPrompt.input.SetValue("app-next")
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.InputActive() || m.confirm == nil || m.confirm.prompt != "Copy app to app-next?" {
+		t.Fatalf("expected a copy confirmation, got %+v", m.confirm)
+	}
+}
+
+func TestDeleteJobRefreshesList(t *testing.T) {
+	var mu sync.Mutex
+	deleted := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.URL.Path {
+		case "/job/old/doDelete":
+			deleted = true
+		case "/api/json":
+			if deleted {
+				w.Write([]byte(`{"jobs":[{"name":"app","color":"blue"}]}`))
+			} else {
+				w.Write([]byte(`{"jobs":[{"name":"app","color":"blue"},{"name":"old","color":"blue"}]}`))
+			}
+		}
+	}))
+	defer server.Close()
+
+	cfg := config.DefaultConfig()
+	cfg.Profile.BaseURL = server.URL
+	client, err := jenkins.NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	m := NewBuildsModel(client, 120, 40)
+	m.loading = false
+	m.Update(m.fetchJobs()())
+	m.selectedJob = 1
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("X")})
+	if m.confirm == nil || !strings.HasPrefix(m.confirm.prompt, "Delete old and all its builds?") {
+		t.Fatalf("expected a delete confirmation, got %+v", m.confirm)
+	}
+	result := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})()
+	m.Update(m.Update(result)())
+	if !deleted || len(m.jobs) != 1 || m.selectedJob != 0 || m.notice != "old deleted" {
+		t.Errorf("expected the job deleted and the list refreshed, got %v %q", m.jobs, m.notice)
+	}
+}
+
+func TestBuildHousekeepingActions(t *testing.T) {
+	m := NewBuildsModel(nil, 120, 40)
+	m.loading = false
+	m.mode = ModeBuildDetail
+	m.jobDetail = &models.JobDetail{Name: "app"}
+	m.builds = []models.BuildRef{{Number: 7}, {Number: 6}}
+	m.buildDetail = &models.Build{Number: 7, DisplayName: "#7", Description: "first\nsecond", KeepLog: true}
+
+	if view := m.View(); !strings.Contains(view, "Kept forever") || !strings.Contains(view, "second") {
+		t.Error("expected the retention and description in build detail")
+	}
+
+	// Kept builds must be released before they can be deleted
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("X")})
+	if m.confirm != nil || !strings.Contains(m.notice, "press K to release it") {
+		t.Fatalf("expected the delete refused, got %q", m.notice)
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("K")})
+	if m.confirm == nil || m.confirm.prompt != "Release app #7 so log rotation may delete it?" {
+		t.Fatalf("expected a release confirmation, got %+v", m.confirm)
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("N")})
+	if !m.InputActive() || m.renameInput.Value() != "" {
+		t.Fatalf("expected an empty rename prompt, got %q", m.renameInput.Value())
+	}
+	m.renameInput.SetValue("release 1.2")
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.InputActive() || m.confirm == nil || m.confirm.prompt != `Rename app #7 to "release 1.2"?` {
+		t.Fatalf("expected a rename confirmation, got %+v", m.confirm)
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+
+	// An editor that only appended a newline changed nothing
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, descriptionFileName), []byte("first\nsecond\n"), 0o600)
+	m.Update(BuildDescriptionEditedMsg{JobName: "app", Build: 7, Dir: dir, Original: "first\nsecond"})
+	if m.confirm != nil || m.notice != "No changes to the description" {
+		t.Fatalf("expected no change, got %q", m.notice)
+	}
+	dir = t.TempDir()
+	os.WriteFile(filepath.Join(dir, descriptionFileName), []byte("\n"), 0o600)
+	m.Update(BuildDescriptionEditedMsg{JobName: "app", Build: 7, Dir: dir, Original: "first\nsecond"})
+	if m.confirm == nil || m.confirm.prompt != "Clear the description of app #7?" {
+		t.Fatalf("expected a clear confirmation, got %+v", m.confirm)
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+
+	m.buildDetail.KeepLog = false
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("X")})
+	if m.confirm == nil || !strings.HasPrefix(m.confirm.prompt, "Delete app #7 with its log and artifacts?") {
+		t.Fatalf("expected a delete confirmation, got %+v", m.confirm)
+	}
+	m.confirm = nil
+	if cmd := m.Update(BuildDeletedMsg{JobName: "app", Build: 7}); cmd == nil {
+		t.Error("expected the build list reloaded")
+	}
+	if m.mode != ModeBuildList || m.buildDetail != nil || m.notice != "Deleted app #7" {
+		t.Errorf("expected to leave the deleted build, got mode %d %q", m.mode, m.notice)
+	}
+}
+
+func TestSelectionMarks(t *testing.T) {
+	var s selection
+	keys := []string{"a", "b", "c", "d", "e"}
+
+	s.toggle("b")
+	if !s.has("b") || s.count() != 1 {
+		t.Fatal("expected b marked")
+	}
+	s.toggle("b")
+	if s.count() != 0 {
+		t.Fatal("expected b unmarked")
+	}
+
+	// V on one row and V on another marks the rows in between
+	s.markRange(keys, 3)
+	s.markRange(keys, 1)
+	if s.count() != 3 || !s.has("b") || !s.has("c") || !s.has("d") {
+		t.Errorf("expected b..d marked, got %v", s.marked)
+	}
+
+	s.retain([]string{"a", "c"})
+	if s.count() != 1 || !s.has("c") {
+		t.Errorf("expected only c kept, got %v", s.marked)
+	}
+	s.toggleAll(keys)
+	if s.count() != len(keys) {
+		t.Errorf("expected all marked, got %v", s.marked)
+	}
+	s.toggleAll(keys)
+	if s.count() != 0 {
+		t.Errorf("expected all unmarked, got %v", s.marked)
+	}
+	if got := countLabel(5, "jobs", s); got != "5 jobs" {
+		t.Errorf("unexpected label %q", got)
+	}
+}
+
+func TestBatchTriggerKeepsFailedMarked(t *testing.T) {
+	var mu sync.Mutex
+	triggered := map[string]bool{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.URL.Path {
+		case "/job/broken/build":
+			w.WriteHeader(http.StatusInternalServerError)
+		case "/job/app/build", "/job/web/build":
+			triggered[strings.Split(r.URL.Path, "/")[2]] = true
+			w.WriteHeader(http.StatusCreated)
+		case "/view/all/api/json":
+			w.Write([]byte(`{"jobs":[{"name":"app","color":"blue"},{"name":"broken","color":"blue"},{"name":"web","color":"blue"}]}`))
+		}
+	}))
+	defer server.Close()
+
+	cfg := config.DefaultConfig()
+	cfg.Profile.BaseURL = server.URL
+	client, err := jenkins.NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	m := NewViewsModel(client, 120, 40)
+	m.loading = false
+	m.mode = ViewsModeJobs
+	m.views = []models.View{{Name: "all"}}
+	m.jobs = []models.Job{{Name: "app", Color: "blue"}, {Name: "broken", Color: "blue"}, {Name: "web", Color: "blue"}}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("*")})
+	if !strings.Contains(m.View(), "3 jobs, 3 marked") {
+		t.Fatal("expected all jobs marked")
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("b")})
+	if m.confirm == nil || m.confirm.prompt != "Trigger a new build of 3 jobs?" {
+		t.Fatalf("expected a batch confirmation, got %+v", m.confirm)
+	}
+
+	// Run the batch to the end: the workers finish before progress is read
+	start := m.Update(m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})())
+	cmds := start().(tea.BatchMsg)
+	cmds[0]()
+	msg := cmds[1]()
+	for {
+		if _, done := msg.(BatchDoneMsg); done {
+			break
+		}
+		msg = m.Update(msg)()
+	}
+	m.Update(m.Update(msg)())
+
+	if !triggered["app"] || !triggered["web"] {
+		t.Errorf("expected app and web triggered, got %v", triggered)
+	}
+	if m.notice != "Trigger: 2 succeeded, 1 failed (still marked)" || !m.noticeErr {
+		t.Errorf("unexpected summary %q", m.notice)
+	}
+	if m.jobMarks.count() != 1 || !m.jobMarks.has("broken") {
+		t.Errorf("expected only the failed job marked, got %v", m.jobMarks.marked)
+	}
+	if !strings.Contains(m.View(), "broken") {
+		t.Error("expected the failure listed in the batch panel")
+	}
+
+	// Esc dismisses the finished batch, then clears the marks
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.batch != nil || m.jobMarks.count() != 1 {
+		t.Fatal("expected the batch dismissed first")
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.jobMarks.count() != 0 || m.mode != ViewsModeJobs {
+		t.Error("expected the marks cleared without leaving the view")
+	}
+}
+
+func TestBatchLeavesOutJobsHiddenByFilter(t *testing.T) {
+	m := NewViewsModel(nil, 120, 40)
+	m.loading = false
+	m.mode = ViewsModeJobs
+	m.views = []models.View{{Name: "all"}}
+	m.jobs = []models.Job{{Name: "api", Color: "blue"}, {Name: "app", Color: "blue"}, {Name: "web", Color: "blue"}}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("*")})
+	m.filter = "ap"
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("b")})
+	if m.confirm == nil || m.confirm.prompt != "Trigger a new build of 2 jobs? (1 hidden by the filter skipped)" {
+		t.Fatalf("expected the hidden job left out, got %+v", m.confirm)
+	}
+	m.confirm = nil
+
+	// Only hidden marks: nothing runs, not even the selected job
+	m.filter = "zzz"
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("b")})
+	if m.confirm != nil || m.notice != "The marked jobs are hidden by the filter" {
+		t.Errorf("expected the action refused, got %+v, %q", m.confirm, m.notice)
+	}
+}
+
+func TestBatchSkipsDeniedJobs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	cfg := config.DefaultConfig()
+	cfg.Profile.BaseURL = server.URL
+	client, err := jenkins.NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	// Jenkins refuses Job/Build on one job only
+	if err := client.TriggerBuild(context.Background(), "locked"); err == nil {
+		t.Fatal("expected the trigger to be refused")
+	}
+	if canPerform(client, jenkins.PermBuild, "locked", 0) || !canPerform(client, jenkins.PermBuild, "app", 0) {
+		t.Fatal("expected the denial to apply to the refused job only")
+	}
+
+	jobs := []models.Job{{Name: "app"}, {Name: "locked"}, {Name: "web"}}
+	confirm, _ := batchJobAction(client, "b", jobs)
+	if confirm == nil || confirm.prompt != "Trigger a new build of 2 jobs? (1 not permitted skipped)" {
+		t.Fatalf("expected the denied job skipped, got %+v", confirm)
+	}
+	if confirm, notice := batchJobAction(client, "b", jobs[1:2]); confirm != nil || !strings.Contains(notice, "Job/Build") {
+		t.Errorf("expected the denied job blocked, got %q", notice)
+	}
+}
+
+func TestBatchProgressReachesHiddenTab(t *testing.T) {
+	m := NewModel(config.DefaultConfig())
+	m.state = StateReady
+	m.width, m.height = 120, 40
+	m.initTabModels()
+
+	views := m.viewsModel
+	items := []batchItem{{key: "app", label: "app", run: func(ctx context.Context) error { return nil }}}
+	cmds := views.runBatch(BatchStartMsg{Action: "Trigger", Items: items}, &views.jobMarks)().(tea.BatchMsg)
+	cmds[0]()
+
+	// Progress keeps flowing to the Views tab while Builds is shown
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("3")})
+	msg := cmds[1]()
+	for msg != nil {
+		_, cmd := m.Update(msg)
+		if cmd == nil {
+			break
+		}
+		msg = cmd()
+	}
+	if m.activeTab != TabBuilds || views.batchRunning() || views.notice != "Trigger: all 1 succeeded" {
+		t.Errorf("expected the batch finished in the background, got %q", views.notice)
+	}
+}
@@ -0,0 +1,153 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/elogrono/jenkins-tui/internal/audit"
+	"github.com/elogrono/jenkins-tui/internal/ui/theme"
+)
+
+// activityLimit is the number of audit entries shown in the overlay
+const activityLimit = 200
+
+// ActivityDataMsg carries recent audit entries
+type ActivityDataMsg struct {
+	Entries []audit.Entry
+	Error   error
+}
+
+// toggleActivity opens or closes the Activity overlay
+func (m *Model) toggleActivity() tea.Cmd {
+	if m.showActivity {
+		m.showActivity = false
+		return nil
+	}
+	m.showActivity = true
+	m.activityOffset = 0
+	return m.loadActivity()
+}
+
+// loadActivity reads recent entries from the client's audit log
+func (m *Model) loadActivity() tea.Cmd {
+	if m.client == nil || m.client.AuditLog() == nil {
+		return nil
+	}
+	log := m.client.AuditLog()
+	return func() tea.Msg {
+		entries, err := log.Recent(activityLimit)
+		return ActivityDataMsg{Entries: entries, Error: err}
+	}
+}
+
+// updateActivity handles keys while the Activity overlay is open
+func (m *Model) updateActivity(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc", "ctrl+a", "q":
+		m.showActivity = false
+	case "j", "down":
+		if m.activityOffset < len(m.activity)-1 {
+			m.activityOffset++
+		}
+	case "k", "up":
+		if m.activityOffset > 0 {
+			m.activityOffset--
+		}
+	case "g":
+		m.activityOffset = 0
+	case "r":
+		return m.loadActivity()
+	}
+	return nil
+}
+
+// viewActivity renders the list of recent mutating actions
+func (m *Model) viewActivity() string {
+	width := minInt(maxInt(m.width-8, 40), 120)
+	visible := maxInt(m.height-12, 5)
+
+	lines := []string{
+		theme.TitleStyle.Render(theme.IconLog + " Activity"),
+	}
+	if m.client != nil && m.client.AuditLog() != nil {
+		lines = append(lines, theme.MutedStyle.Render(m.client.AuditLog().Path()))
+	}
+	lines = append(lines, "")
+
+	switch {
+	case m.activityErr != nil:
+		lines = append(lines, theme.ErrorStyle.Render("Error reading audit log: "+m.activityErr.Error()))
+	case len(m.activity) == 0:
+		lines = append(lines, theme.MutedStyle.Render("No actions recorded yet"))
+	default:
+		end := minInt(m.activityOffset+visible, len(m.activity))
+		for _, e := range m.activity[m.activityOffset:end] {
+			lines = append(lines, truncate(renderActivityEntry(e), width-6))
+		}
+		lines = append(lines, "", theme.MutedStyle.Render(
+			fmt.Sprintf("%d-%d of %d", m.activityOffset+1, end, len(m.activity))))
+	}
+	lines = append(lines, "", theme.MutedStyle.Render("j/k: Scroll | r: Reload | Esc: Close"))
+
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Primary).
+		Padding(1, 2).
+		Width(width).
+		Render(strings.Join(lines, "\n"))
+
+	return lipgloss.Place(
+		m.width,
+		m.height,
+		lipgloss.Center,
+		lipgloss.Center,
+		box,
+	)
+}
+
+// renderActivityEntry formats one audit entry as a single line
+func renderActivityEntry(e audit.Entry) string {
+	icon := theme.SuccessStyle.Render(theme.IconSuccess)
+	if !e.Succeeded() {
+		icon = theme.ErrorStyle.Render(theme.IconFailure)
+	}
+
+	target := e.Job
+	if e.Build > 0 {
+		target += fmt.Sprintf(" #%d", e.Build)
+	}
+
+	status := fmt.Sprintf("%d", e.Status)
+	if e.Status == 0 {
+		status = "---"
+	}
+
+	line := fmt.Sprintf("%s %s %s %-10s %-14s %s",
+		icon,
+		theme.MutedStyle.Render(e.Time.Local().Format("01-02 15:04:05")),
+		status,
+		truncate(e.User, 10),
+		truncate(e.Action, 14),
+		target,
+	)
+
+	if len(e.Parameters) > 0 {
+		names := make([]string, 0, len(e.Parameters))
+		for name := range e.Parameters {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		params := make([]string, len(names))
+		for i, name := range names {
+			params[i] = name + "=" + e.Parameters[name]
+		}
+		line += theme.MutedStyle.Render(" " + strings.Join(params, " "))
+	}
+	if e.Error != "" {
+		line += theme.ErrorStyle.Render(" " + e.Error)
+	}
+	return line
+}
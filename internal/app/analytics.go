@@ -0,0 +1,126 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/elogrono/jenkins-tui/internal/jenkins/models"
+	"github.com/elogrono/jenkins-tui/internal/ui/components"
+	"github.com/elogrono/jenkins-tui/internal/ui/theme"
+)
+
+const (
+	analyticsBuilds = 30 // Builds considered by the analytics panel
+	analyticsWindow = 5  // Size of the rolling success-rate window
+)
+
+// viewAnalytics renders duration, success-rate and stage trends for the job
+func (m *BuildsModel) viewAnalytics() string {
+	jobName := ""
+	if m.jobDetail != nil {
+		jobName = m.jobDetail.Name
+	}
+	breadcrumb := components.NewBreadcrumb("Builds", jobName, "Analytics").Render()
+
+	stats := models.ComputeBuildStats(m.builds, analyticsBuilds, analyticsWindow)
+	chartWidth := maxInt(m.width-40, 10)
+
+	rows := []string{theme.SectionTitleStyle.Render(theme.IconClock + " Duration")}
+	if len(stats.Builds) == 0 {
+		rows = append(rows, theme.MutedStyle.Render("  No completed builds yet"))
+	} else {
+		chart := components.NewBarChart(6).SetWidth(chartWidth)
+		for i, b := range stats.Builds {
+			chart.AddBar(stats.Durations[i].Seconds(), theme.BuildResultColor(b.Result))
+		}
+		first, last := stats.Builds[0].Number, stats.Builds[len(stats.Builds)-1].Number
+		rows = append(rows,
+			theme.MutedStyle.Render(fmt.Sprintf("Last %d builds (#%d-#%d), peak %s",
+				len(stats.Builds), first, last, formatDuration(stats.MaxDuration))),
+			"",
+			chart.Render(),
+			"",
+			fmt.Sprintf("%s %s   %s %s   %s %s   %s %s",
+				theme.MutedStyle.Render("mean"), formatDuration(stats.MeanDuration),
+				theme.MutedStyle.Render("p95"), formatDuration(stats.P95Duration),
+				theme.MutedStyle.Render("min"), formatDuration(stats.MinDuration),
+				theme.MutedStyle.Render("max"), formatDuration(stats.MaxDuration)),
+			"",
+			theme.SectionTitleStyle.Render(theme.IconSuccess+" Success rate"),
+			fmt.Sprintf("%s  %s",
+				components.NewSparkline(stats.RollingSuccess).
+					SetWidth(chartWidth).
+					SetRange(0, 1).
+					SetColor(successRateColor(stats.SuccessRate)).
+					Render(),
+				lipgloss.NewStyle().Foreground(successRateColor(stats.SuccessRate)).Bold(true).
+					Render(fmt.Sprintf("%.0f%%", stats.SuccessRate*100))+
+					theme.MutedStyle.Render(fmt.Sprintf(" (%d/%d, rolling over %d)", stats.Successes, len(stats.Builds), analyticsWindow))),
+		)
+	}
+
+	rows = append(rows, "", theme.SectionTitleStyle.Render(theme.IconBuild+" Stage trends"))
+	if len(stats.StageTrends) == 0 {
+		rows = append(rows, theme.MutedStyle.Render("  No stage data for these builds"))
+	} else {
+		nameWidth := 24
+		sparkWidth := maxInt(chartWidth-nameWidth, 10)
+		for _, trend := range stats.StageTrends {
+			rows = append(rows, renderStageTrend(trend, nameWidth, sparkWidth))
+		}
+	}
+
+	panel := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Border).
+		Width(m.width-6).
+		Padding(1, 2).
+		Render(strings.Join(rows, "\n"))
+
+	content := lipgloss.JoinVertical(lipgloss.Left, breadcrumb, panel, m.renderShortcuts())
+
+	return lipgloss.NewStyle().
+		Width(m.width).
+		Height(m.height-4).
+		Padding(0, 1).
+		Render(content)
+}
+
+// renderStageTrend renders one stage as name, sparkline, mean and last duration
+func renderStageTrend(trend models.StageTrend, nameWidth, sparkWidth int) string {
+	values := make([]float64, len(trend.Durations))
+	for i, d := range trend.Durations {
+		values[i] = d.Seconds()
+	}
+
+	color := theme.Secondary
+	if trend.Mean > 0 && trend.Last > trend.Mean+trend.Mean/4 {
+		color = theme.BuildUnstable
+	}
+
+	name := lipgloss.NewStyle().Width(nameWidth).Render(truncate(trend.Name, nameWidth-1))
+	return fmt.Sprintf("%s %s  %s %s  %s %s",
+		name,
+		components.NewSparkline(values).SetWidth(sparkWidth).SetColor(color).Render(),
+		theme.MutedStyle.Render("mean"), padDuration(trend.Mean),
+		theme.MutedStyle.Render("last"), padDuration(trend.Last))
+}
+
+// padDuration formats d in a fixed-width column
+func padDuration(d time.Duration) string {
+	return fmt.Sprintf("%-8s", formatDuration(d))
+}
+
+// successRateColor picks a color for a 0..1 success rate
+func successRateColor(rate float64) lipgloss.Color {
+	switch {
+	case rate >= 0.9:
+		return theme.BuildSuccess
+	case rate >= 0.6:
+		return theme.BuildUnstable
+	default:
+		return theme.BuildFailure
+	}
+}
@@ -227,3 +227,34 @@ func TestStatusToColor(t *testing.T) {
 		}
 	}
 }
+
+func TestSetupModelAdvancedFields(t *testing.T) {
+	setup := NewSetupModel()
+	setup.showAdvanced = true
+
+	// Token is followed by the network section
+	setup.focusedField = FieldToken
+	setup.nextField()
+	if setup.focusedField != FieldProxy {
+		t.Errorf("expected FieldProxy, got %v", setup.focusedField)
+	}
+
+	// Client key is followed by submit
+	setup.focusedField = FieldClientKey
+	setup.nextField()
+	if setup.focusedField != FieldSubmit {
+		t.Errorf("expected FieldSubmit, got %v", setup.focusedField)
+	}
+
+	// Invalid proxy is reported before testing the connection
+	setup.urlInput.SetValue("https://jenkins.example.com")
+	setup.usernameInput.SetValue("admin")
+	setup.tokenInput.SetValue("token")
+	setup.proxyInput.SetValue("proxy.example.com")
+	if cmd := setup.testAndSave(); cmd != nil {
+		t.Error("expected no command for invalid network settings")
+	}
+	if setup.err == nil {
+		t.Error("expected validation error for proxy without scheme")
+	}
+}
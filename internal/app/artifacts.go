@@ -0,0 +1,422 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/elogrono/jenkins-tui/internal/clipboard"
+	"github.com/elogrono/jenkins-tui/internal/jenkins"
+	"github.com/elogrono/jenkins-tui/internal/jenkins/models"
+	"github.com/elogrono/jenkins-tui/internal/ui/components"
+	"github.com/elogrono/jenkins-tui/internal/ui/theme"
+)
+
+// artifactPreviewBytes is how much of an artifact is fetched for previewing
+const artifactPreviewBytes = 1 << 20
+
+// ArtifactPreviewMsg carries the beginning of a text artifact
+type ArtifactPreviewMsg struct {
+	Path    string
+	Preview *jenkins.ArtifactPreview
+	Error   error
+}
+
+// ArtifactProgressMsg reports how much of the current download was written
+type ArtifactProgressMsg struct {
+	Written int64
+	Total   int64 // -1 when the server did not send a length
+}
+
+// ArtifactDownloadedMsg is sent when a download finished, failed or was cancelled
+type ArtifactDownloadedMsg struct {
+	File  string
+	Error error
+}
+
+// artifactDownload is the download in progress
+type artifactDownload struct {
+	name     string
+	written  int64
+	total    int64
+	progress chan tea.Msg
+	cancel   context.CancelFunc
+}
+
+// openArtifacts switches to the artifact list of the current build
+func (m *BuildsModel) openArtifacts() {
+	if m.buildDetail == nil {
+		return
+	}
+	m.mode = ModeArtifacts
+	m.selectedArtifact = minInt(m.selectedArtifact, maxInt(len(m.buildDetail.Artifacts)-1, 0))
+}
+
+// selectedArtifactPath returns the relative path of the selected artifact
+func (m *BuildsModel) selectedArtifactPath() string {
+	if m.mode == ModeArtifactPreview {
+		return m.artifactPreview
+	}
+	if m.buildDetail == nil || m.selectedArtifact >= len(m.buildDetail.Artifacts) {
+		return ""
+	}
+	return m.buildDetail.Artifacts[m.selectedArtifact].RelativePath
+}
+
+// artifactListHeight returns how many artifacts fit on screen
+func (m *BuildsModel) artifactListHeight() int {
+	return maxInt(m.height-18, 3)
+}
+
+// moveArtifactSelection moves the selected artifact by delta rows
+func (m *BuildsModel) moveArtifactSelection(delta int) {
+	if m.buildDetail == nil || len(m.buildDetail.Artifacts) == 0 {
+		return
+	}
+	m.selectedArtifact = maxInt(0, minInt(m.selectedArtifact+delta, len(m.buildDetail.Artifacts)-1))
+	if m.selectedArtifact < m.artifactsScroll {
+		m.artifactsScroll = m.selectedArtifact
+	}
+	if m.selectedArtifact >= m.artifactsScroll+m.artifactListHeight() {
+		m.artifactsScroll = m.selectedArtifact - m.artifactListHeight() + 1
+	}
+}
+
+// openArtifactPreview fetches the selected artifact and shows it in the viewport
+func (m *BuildsModel) openArtifactPreview() tea.Cmd {
+	path := m.selectedArtifactPath()
+	if path == "" || m.jobDetail == nil {
+		return nil
+	}
+	m.mode = ModeArtifactPreview
+	m.artifactPreview = path
+	m.artifactTruncated = false
+	m.viewport.SetContent("")
+	m.viewport.GotoTop()
+	return m.fetchArtifactPreview(m.jobDetail.Name, m.buildDetail.Number, path)
+}
+
+// applyArtifactPreview shows a fetched artifact, pretty-printing JSON
+func (m *BuildsModel) applyArtifactPreview(msg ArtifactPreviewMsg) {
+	m.loading = false
+	if m.mode != ModeArtifactPreview || msg.Path != m.artifactPreview {
+		return
+	}
+	if msg.Error != nil {
+		m.mode = ModeArtifacts
+		if errors.Is(msg.Error, jenkins.ErrBinaryArtifact) {
+			m.notice = filepath.Base(msg.Path) + " is not a text file; press d to download it"
+			m.noticeErr = false
+			return
+		}
+		m.lastError = msg.Error
+		return
+	}
+	m.artifactTruncated = msg.Preview.Truncated
+	m.viewport.SetContent(formatArtifact(msg.Path, msg.Preview.Content, m.artifactTruncated))
+	m.viewport.GotoTop()
+}
+
+// formatArtifact numbers the lines of an artifact. Complete JSON documents
+// are indented first.
+func formatArtifact(path, content string, truncated bool) string {
+	if strings.EqualFold(filepath.Ext(path), ".json") && !truncated {
+		var indented bytes.Buffer
+		if err := json.Indent(&indented, []byte(content), "", "  "); err == nil {
+			content = indented.String()
+		}
+	}
+	content = strings.ReplaceAll(strings.TrimRight(content, "\n"), "\t", "    ")
+
+	lines := strings.Split(content, "\n")
+	numWidth := len(fmt.Sprintf("%d", len(lines)))
+	for i, line := range lines {
+		lines[i] = theme.MutedStyle.Render(fmt.Sprintf("%*d│ ", numWidth, i+1)) + strings.TrimRight(line, "\r")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// requestArtifactDownload asks for the directory to save an artifact into.
+// Pass jenkins.ArchiveName to download all artifacts as a zip file.
+func (m *BuildsModel) requestArtifactDownload(relativePath string) tea.Cmd {
+	if relativePath == "" || m.jobDetail == nil || m.buildDetail == nil {
+		return nil
+	}
+	if m.download != nil {
+		m.notice = "A download is already in progress, c cancels it"
+		m.noticeErr = true
+		return nil
+	}
+	if m.downloadDir == "" {
+		m.downloadDir = defaultDownloadDir()
+	}
+	m.downloadTarget = relativePath
+	m.downloadInput.SetValue(m.downloadDir)
+	m.downloadInput.CursorEnd()
+	m.downloadPrompting = true
+	m.downloadInput.Focus()
+	return textinput.Blink
+}
+
+// updateDownloadPrompt handles keys while the download directory is edited
+func (m *BuildsModel) updateDownloadPrompt(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc":
+		m.downloadPrompting = false
+		m.downloadInput.Blur()
+		return nil
+	case "enter":
+		m.downloadPrompting = false
+		m.downloadInput.Blur()
+		dir := expandHome(strings.TrimSpace(m.downloadInput.Value()))
+		if dir == "" {
+			return nil
+		}
+		m.downloadDir = dir
+		return m.startArtifactDownload(m.jobDetail.Name, m.buildDetail.Number, m.downloadTarget, dir)
+	}
+	var cmd tea.Cmd
+	m.downloadInput, cmd = m.downloadInput.Update(msg)
+	return cmd
+}
+
+// startArtifactDownload downloads in the background, reporting progress
+// through a channel that is drained one message at a time
+func (m *BuildsModel) startArtifactDownload(jobName string, buildNum int, relativePath, dir string) tea.Cmd {
+	name := filepath.Base(relativePath)
+	if relativePath == jenkins.ArchiveName {
+		name = "all artifacts (zip)"
+	}
+	// Large files outlive the usual request timeout, and the client skips the
+	// profile's HTTP timeout for downloads; this bounds them instead
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	progress := make(chan tea.Msg, 1)
+	m.download = &artifactDownload{name: name, total: -1, progress: progress, cancel: cancel}
+
+	download := func() tea.Msg {
+		defer close(progress)
+		defer cancel()
+		file, err := m.client.DownloadArtifact(ctx, jobName, buildNum, relativePath, dir, func(written, total int64) {
+			// Drop updates the UI has not caught up with
+			select {
+			case progress <- ArtifactProgressMsg{Written: written, Total: total}:
+			default:
+			}
+		})
+		return ArtifactDownloadedMsg{File: file, Error: err}
+	}
+	return tea.Batch(download, waitForDownload(progress))
+}
+
+// waitForDownload returns the next progress message of a download
+func waitForDownload(progress chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		msg, ok := <-progress
+		if !ok {
+			return nil
+		}
+		return msg
+	}
+}
+
+// applyArtifactProgress records download progress and waits for more
+func (m *BuildsModel) applyArtifactProgress(msg ArtifactProgressMsg) tea.Cmd {
+	if m.download == nil {
+		return nil
+	}
+	m.download.written = msg.Written
+	m.download.total = msg.Total
+	return waitForDownload(m.download.progress)
+}
+
+// cancelArtifactDownload stops the running download
+func (m *BuildsModel) cancelArtifactDownload() {
+	if m.download != nil {
+		m.download.cancel()
+	}
+}
+
+// applyArtifactDownloaded reports the result of a download
+func (m *BuildsModel) applyArtifactDownloaded(msg ArtifactDownloadedMsg) {
+	name := ""
+	if m.download != nil {
+		name = m.download.name
+	}
+	m.download = nil
+	if errors.Is(msg.Error, context.Canceled) {
+		m.notice = "Download of " + name + " cancelled"
+		m.noticeErr = false
+		return
+	}
+	if msg.Error != nil {
+		m.notice = "Download failed: " + msg.Error.Error()
+		m.noticeErr = true
+		return
+	}
+	m.notice = "Saved " + msg.File
+	m.noticeErr = false
+}
+
+// copyArtifactURL puts the URL of the selected artifact on the clipboard
+func (m *BuildsModel) copyArtifactURL() {
+	path := m.selectedArtifactPath()
+	if path == "" || m.jobDetail == nil || m.buildDetail == nil {
+		return
+	}
+	url := m.client.ArtifactURL(m.jobDetail.Name, m.buildDetail.Number, path)
+	if err := clipboard.Copy(url); err != nil {
+		m.notice = "Could not copy " + url
+		m.noticeErr = true
+		return
+	}
+	m.notice = "Copied " + url
+	m.noticeErr = false
+}
+
+// defaultDownloadDir returns ~/Downloads when it exists, else the working directory
+func defaultDownloadDir() string {
+	if home, err := os.UserHomeDir(); err == nil {
+		dir := filepath.Join(home, "Downloads")
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			return dir
+		}
+	}
+	if wd, err := os.Getwd(); err == nil {
+		return wd
+	}
+	return "."
+}
+
+// viewArtifacts renders the artifacts of the current build
+func (m *BuildsModel) viewArtifacts() string {
+	jobName, buildLabel := "", ""
+	var artifacts []models.Artifact
+	if m.jobDetail != nil {
+		jobName = m.jobDetail.Name
+	}
+	if m.buildDetail != nil {
+		buildLabel = fmt.Sprintf("#%d", m.buildDetail.Number)
+		artifacts = m.buildDetail.Artifacts
+	}
+	breadcrumb := components.NewBreadcrumb("Builds", jobName, buildLabel, "Artifacts").Render()
+
+	width := m.width - 12
+	rows := []string{theme.SectionTitleStyle.Render(theme.IconArtifact + " Artifacts")}
+
+	if len(artifacts) == 0 {
+		rows = append(rows, theme.MutedStyle.Render("  This build archived no artifacts"))
+	} else {
+		rows = append(rows, theme.MutedStyle.Render(fmt.Sprintf("%d files", len(artifacts))), "")
+		end := minInt(m.artifactsScroll+m.artifactListHeight(), len(artifacts))
+		for i := m.artifactsScroll; i < end; i++ {
+			path := truncate(artifacts[i].RelativePath, maxInt(width-4, 10))
+			if i == m.selectedArtifact {
+				rows = append(rows, theme.PrimaryStyle.Render("> ")+theme.PrimaryStyle.Bold(true).Render(path))
+			} else {
+				rows = append(rows, "  "+path)
+			}
+		}
+		if len(artifacts) > m.artifactListHeight() {
+			rows = append(rows, theme.MutedStyle.Render(fmt.Sprintf("  %d-%d of %d", m.artifactsScroll+1, end, len(artifacts))))
+		}
+	}
+
+	if status := m.renderDownloadStatus(width); status != "" {
+		rows = append(rows, "", status)
+	}
+
+	panel := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Border).
+		Width(m.width-6).
+		Padding(1, 2).
+		Render(strings.Join(rows, "\n"))
+
+	content := lipgloss.JoinVertical(lipgloss.Left, breadcrumb, panel, m.renderShortcuts())
+
+	return lipgloss.NewStyle().
+		Width(m.width).
+		Height(m.height-4).
+		Padding(0, 1).
+		Render(content)
+}
+
+// renderDownloadStatus renders the directory prompt or the running download
+func (m *BuildsModel) renderDownloadStatus(width int) string {
+	if m.downloadPrompting {
+		target := filepath.Base(m.downloadTarget)
+		if m.downloadTarget == jenkins.ArchiveName {
+			target = "all artifacts (zip)"
+		}
+		return theme.MutedStyle.Render("Save "+target+" to:") + "\n" +
+			theme.SearchBarStyle.Width(maxInt(width-2, 20)).Render(m.downloadInput.View())
+	}
+	if m.download == nil {
+		return ""
+	}
+	label := fmt.Sprintf("Downloading %s  %s", m.download.name, components.FormatBytes(m.download.written))
+	hint := theme.MutedStyle.Render("  (c to cancel)")
+	if m.download.total <= 0 {
+		return label + hint
+	}
+	percent := int(m.download.written * 100 / m.download.total)
+	return label + " / " + components.FormatBytes(m.download.total) + hint + "\n" +
+		components.NewProgressBar(percent, minInt(maxInt(width-8, 10), 60)).Render()
+}
+
+// viewArtifactPreview renders a text artifact in the viewport
+func (m *BuildsModel) viewArtifactPreview() string {
+	jobName, buildLabel := "", ""
+	if m.jobDetail != nil {
+		jobName = m.jobDetail.Name
+	}
+	if m.buildDetail != nil {
+		buildLabel = fmt.Sprintf("#%d", m.buildDetail.Number)
+	}
+	breadcrumb := components.NewBreadcrumb("Builds", jobName, buildLabel, "Artifacts", filepath.Base(m.artifactPreview)).Render()
+
+	statusItems := []string{theme.MutedStyle.Render(m.artifactPreview)}
+	if m.loading {
+		statusItems = append(statusItems, m.spinner.View()+" Loading...")
+	}
+	if m.artifactTruncated {
+		statusItems = append(statusItems, theme.WarningStyle.Render(fmt.Sprintf("Showing the first %s; press d to download the rest", components.FormatBytes(artifactPreviewBytes))))
+	}
+	statusBar := strings.Join(statusItems, " │ ")
+
+	m.viewport.Width = m.width - 2
+	m.viewport.Height = m.height - 10
+
+	sections := []string{breadcrumb, statusBar, m.viewport.View()}
+	if status := m.renderDownloadStatus(m.width - 4); status != "" {
+		sections = append(sections, status)
+	}
+	sections = append(sections,
+		theme.MutedStyle.Render(fmt.Sprintf(" %d%% ", int(m.viewport.ScrollPercent()*100))),
+		m.renderShortcuts())
+
+	return lipgloss.NewStyle().
+		Width(m.width).
+		Height(m.height-4).
+		Padding(0, 1).
+		Render(lipgloss.JoinVertical(lipgloss.Left, sections...))
+}
+
+func (m *BuildsModel) fetchArtifactPreview(jobName string, buildNum int, path string) tea.Cmd {
+	m.loading = true
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		preview, err := m.client.GetArtifact(ctx, jobName, buildNum, path, artifactPreviewBytes)
+		return ArtifactPreviewMsg{Path: path, Preview: preview, Error: err}
+	}
+}
@@ -0,0 +1,746 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/elogrono/jenkins-tui/internal/jenkins"
+	"github.com/elogrono/jenkins-tui/internal/jenkins/models"
+	"github.com/elogrono/jenkins-tui/internal/ui/components"
+	"github.com/elogrono/jenkins-tui/internal/ui/theme"
+)
+
+// batchWorkers bounds how many items of a batch run at once; the client's
+// rate limiter still paces the requests they send
+const batchWorkers = 4
+
+// batchFailureRows bounds the failed items listed in the batch panel
+const batchFailureRows = 5
+
+// selection holds the marked rows of a list, keyed by job name or build number
+type selection struct {
+	marked map[string]bool
+	anchor string // Row a V range started on, "" when none
+}
+
+// count returns how many rows are marked
+func (s *selection) count() int {
+	return len(s.marked)
+}
+
+// has reports whether the row is marked
+func (s *selection) has(key string) bool {
+	return s.marked[key]
+}
+
+// toggle marks or unmarks a row
+func (s *selection) toggle(key string) {
+	if s.marked == nil {
+		s.marked = make(map[string]bool)
+	}
+	if s.marked[key] {
+		delete(s.marked, key)
+	} else {
+		s.marked[key] = true
+	}
+}
+
+// markRange starts a range on the current row, or marks every row between
+// the start of the range and the current row
+func (s *selection) markRange(keys []string, current int) {
+	if current >= len(keys) {
+		return
+	}
+	if s.marked == nil {
+		s.marked = make(map[string]bool)
+	}
+	start := -1
+	for i, key := range keys {
+		if key == s.anchor {
+			start = i
+		}
+	}
+	if start < 0 {
+		s.anchor = keys[current]
+		s.marked[s.anchor] = true
+		return
+	}
+	for i := minInt(start, current); i <= maxInt(start, current); i++ {
+		s.marked[keys[i]] = true
+	}
+	s.anchor = ""
+}
+
+// toggleAll marks every row, or clears the marks when all are marked
+func (s *selection) toggleAll(keys []string) {
+	all := len(keys) > 0
+	for _, key := range keys {
+		if !s.marked[key] {
+			all = false
+		}
+	}
+	s.clear()
+	if all {
+		return
+	}
+	s.marked = make(map[string]bool, len(keys))
+	for _, key := range keys {
+		s.marked[key] = true
+	}
+}
+
+// retain drops marks of rows that are gone, e.g. deleted jobs
+func (s *selection) retain(keys []string) {
+	present := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		present[key] = true
+	}
+	for key := range s.marked {
+		if !present[key] {
+			delete(s.marked, key)
+		}
+	}
+}
+
+// clear removes every mark
+func (s *selection) clear() {
+	s.marked = nil
+	s.anchor = ""
+}
+
+// countLabel renders the size of a list and how many of its rows are marked
+func countLabel(n int, noun string, marks selection) string {
+	if marks.count() == 0 {
+		return fmt.Sprintf("%d %s", n, noun)
+	}
+	return fmt.Sprintf("%d %s, %d marked", n, noun, marks.count())
+}
+
+// jobKeys returns the selection keys of jobs
+func jobKeys(jobs []models.Job) []string {
+	keys := make([]string, len(jobs))
+	for i, job := range jobs {
+		keys[i] = job.Name
+	}
+	return keys
+}
+
+// buildKeys returns the selection keys of builds
+func buildKeys(builds []models.BuildRef) []string {
+	keys := make([]string, len(builds))
+	for i, build := range builds {
+		keys[i] = strconv.Itoa(build.Number)
+	}
+	return keys
+}
+
+// markedJobs returns the marked jobs in list order
+func markedJobs(jobs []models.Job, s selection) []models.Job {
+	var marked []models.Job
+	for _, job := range jobs {
+		if s.has(job.Name) {
+			marked = append(marked, job)
+		}
+	}
+	return marked
+}
+
+// shownMarkedJobs returns the marked jobs among those the filter shows, and
+// how many marked jobs it hides
+func shownMarkedJobs(all, shown []models.Job, s selection) ([]models.Job, int) {
+	marked := markedJobs(shown, s)
+	return marked, len(markedJobs(all, s)) - len(marked)
+}
+
+// permittedJobs returns the jobs the user is believed to hold the permission
+// on, and how many were left out because Jenkins refused it before
+func permittedJobs(client *jenkins.Client, p jenkins.Permission, jobs []models.Job) ([]models.Job, int) {
+	var permitted []models.Job
+	for _, job := range jobs {
+		if canPerform(client, p, job.Name, 0) {
+			permitted = append(permitted, job)
+		}
+	}
+	return permitted, len(jobs) - len(permitted)
+}
+
+// canPerformAny reports whether the permission is believed to be held on at
+// least one of the jobs
+func canPerformAny(client *jenkins.Client, p jenkins.Permission, jobs []models.Job) bool {
+	permitted, _ := permittedJobs(client, p, jobs)
+	return len(permitted) > 0
+}
+
+// renderMark renders the marker column of a list row
+func renderMark(marked, selected bool) string {
+	if !marked {
+		return " "
+	}
+	if selected {
+		return theme.IconMarked
+	}
+	return theme.AccentStyle.Render(theme.IconMarked)
+}
+
+// batchItem is one job or build a batch action applies to
+type batchItem struct {
+	key   string // Selection key, unmarked once the item succeeded
+	label string
+	run   func(ctx context.Context) error
+}
+
+// batchState is the progress of one item of a batch
+type batchState int
+
+const (
+	batchPending batchState = iota
+	batchRunning
+	batchSucceeded
+	batchFailed
+)
+
+// BatchStartMsg asks to run a confirmed batch action
+type BatchStartMsg struct {
+	Action string
+	Items  []batchItem
+}
+
+// BatchItemMsg reports that an item of the running batch started or finished
+type BatchItemMsg struct {
+	Index int
+	Done  bool
+	Error error
+	run   *batchRun // Batch the item belongs to, for routing
+}
+
+// BatchDoneMsg is sent once every item of the batch finished
+type BatchDoneMsg struct {
+	run *batchRun
+}
+
+// batchRun is a batch action in progress, or finished and not yet dismissed
+type batchRun struct {
+	action   string
+	items    []batchItem
+	states   []batchState
+	errors   []error
+	finished bool
+	marks    *selection // Selection the items were marked in
+	progress chan tea.Msg
+}
+
+// startBatch returns the command running a confirmed batch action
+func startBatch(action string, items []batchItem) tea.Cmd {
+	return func() tea.Msg {
+		return BatchStartMsg{Action: action, Items: items}
+	}
+}
+
+// newBatchRun prepares a batch; start runs it
+func newBatchRun(action string, items []batchItem) *batchRun {
+	return &batchRun{
+		action: action,
+		items:  items,
+		states: make([]batchState, len(items)),
+		errors: make([]error, len(items)),
+		// Two messages per item: the channel never blocks the workers
+		progress: make(chan tea.Msg, 2*len(items)),
+	}
+}
+
+// start runs the items on a few workers, reporting through the progress
+// channel that is drained one message at a time
+func (b *batchRun) start() tea.Cmd {
+	run := func() tea.Msg {
+		defer close(b.progress)
+		queue := make(chan int)
+		var wg sync.WaitGroup
+		for w := 0; w < minInt(batchWorkers, len(b.items)); w++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := range queue {
+					b.progress <- BatchItemMsg{Index: i, run: b}
+					ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+					err := b.items[i].run(ctx)
+					cancel()
+					b.progress <- BatchItemMsg{Index: i, Done: true, Error: err, run: b}
+				}
+			}()
+		}
+		for i := range b.items {
+			queue <- i
+		}
+		close(queue)
+		wg.Wait()
+		return nil
+	}
+	return tea.Batch(run, b.wait())
+}
+
+// wait returns the next progress message, or BatchDoneMsg once all items finished
+func (b *batchRun) wait() tea.Cmd {
+	return func() tea.Msg {
+		msg, ok := <-b.progress
+		if !ok {
+			return BatchDoneMsg{run: b}
+		}
+		return msg
+	}
+}
+
+// apply records the progress of an item and waits for more
+func (b *batchRun) apply(msg BatchItemMsg) tea.Cmd {
+	if msg.Index >= len(b.items) {
+		return b.wait()
+	}
+	switch {
+	case !msg.Done:
+		b.states[msg.Index] = batchRunning
+	case msg.Error != nil:
+		b.states[msg.Index] = batchFailed
+		b.errors[msg.Index] = msg.Error
+	default:
+		b.states[msg.Index] = batchSucceeded
+	}
+	return b.wait()
+}
+
+// counts returns how many items finished and how many of them failed
+func (b *batchRun) counts() (done, failed int) {
+	for _, state := range b.states {
+		switch state {
+		case batchSucceeded:
+			done++
+		case batchFailed:
+			done++
+			failed++
+		}
+	}
+	return done, failed
+}
+
+// summary describes the outcome of a finished batch
+func (b *batchRun) summary() string {
+	done, failed := b.counts()
+	if failed == 0 {
+		return fmt.Sprintf("%s: all %d succeeded", b.action, done)
+	}
+	return fmt.Sprintf("%s: %d succeeded, %d failed (still marked)", b.action, done-failed, failed)
+}
+
+// outcome returns the summary notice of a finished batch and whether any
+// item failed
+func (b *batchRun) outcome() (string, bool) {
+	_, failed := b.counts()
+	return b.summary(), failed > 0
+}
+
+// unmarkSucceeded unmarks the items that succeeded, keeping failed ones
+// marked to retry them
+func (b *batchRun) unmarkSucceeded() {
+	if b.marks == nil {
+		return
+	}
+	for i, item := range b.items {
+		if b.states[i] == batchSucceeded && b.marks.has(item.key) {
+			b.marks.toggle(item.key)
+		}
+	}
+}
+
+// render renders the progress of the batch and the items that failed
+func (b *batchRun) render(width int) string {
+	done, failed := b.counts()
+	percent := 100
+	if len(b.items) > 0 {
+		percent = done * 100 / len(b.items)
+	}
+	color := theme.Warning
+	switch {
+	case b.finished && failed > 0:
+		color = theme.Error
+	case b.finished:
+		color = theme.Success
+	}
+
+	status := fmt.Sprintf("%s  %d/%d", b.action, done, len(b.items))
+	if failed > 0 {
+		status += theme.ErrorStyle.Render(fmt.Sprintf("  %d failed", failed))
+	}
+	rows := []string{components.NewProgressBar(percent, 20).SetColor(color).Render() + "  " + status}
+
+	var running []string
+	for i, state := range b.states {
+		if state == batchRunning {
+			running = append(running, b.items[i].label)
+		}
+	}
+	if len(running) > 0 {
+		rows = append(rows, theme.RunningStyle.Render(theme.IconRunning)+" "+truncate(strings.Join(running, ", "), maxInt(width-6, 20)))
+	}
+
+	shown := 0
+	for i, state := range b.states {
+		if state != batchFailed {
+			continue
+		}
+		if shown == batchFailureRows {
+			rows = append(rows, theme.MutedStyle.Render(fmt.Sprintf("  ... and %d more failed", failed-shown)))
+			break
+		}
+		line := fmt.Sprintf("%s: %s", b.items[i].label, b.errors[i].Error())
+		rows = append(rows, theme.ErrorStyle.Render(theme.IconFailure)+" "+truncate(line, maxInt(width-6, 20)))
+		shown++
+	}
+	if b.finished {
+		rows = append(rows, theme.MutedStyle.Render("Esc to dismiss"))
+	}
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(color).
+		Width(maxInt(width-2, 20)).
+		Padding(0, 1).
+		Render(strings.Join(rows, "\n"))
+}
+
+// batcher holds the batch action of a list model. The progress of a batch
+// is delivered to the model running it even when its tab is not shown.
+type batcher struct {
+	batch *batchRun
+}
+
+// batchRunning reports whether a batch is still running
+func (b *batcher) batchRunning() bool {
+	return b.batch != nil && !b.batch.finished
+}
+
+// runBatch starts a confirmed batch action on rows marked in marks
+func (b *batcher) runBatch(msg BatchStartMsg, marks *selection) tea.Cmd {
+	if b.batchRunning() {
+		return nil
+	}
+	b.batch = newBatchRun(msg.Action, msg.Items)
+	b.batch.marks = marks
+	return b.batch.start()
+}
+
+// ownsBatch reports whether progress of run is for this model's batch
+func (b *batcher) ownsBatch(run *batchRun) bool {
+	return b.batch != nil && b.batch == run
+}
+
+// applyBatch records the progress of an item of the batch
+func (b *batcher) applyBatch(msg BatchItemMsg) tea.Cmd {
+	if !b.ownsBatch(msg.run) {
+		return nil
+	}
+	return b.batch.apply(msg)
+}
+
+// finishBatch marks the batch finished and unmarks the items that
+// succeeded; it reports false for a batch that is not this model's
+func (b *batcher) finishBatch(msg BatchDoneMsg) bool {
+	if !b.ownsBatch(msg.run) {
+		return false
+	}
+	b.batch.finished = true
+	b.batch.unmarkSucceeded()
+	return true
+}
+
+// dismissBatchOrMarks hides a finished batch, or clears the marks of the
+// list shown; it reports whether Esc was used up
+func (b *batcher) dismissBatchOrMarks(marks *selection) bool {
+	switch {
+	case b.batch != nil && b.batch.finished:
+		b.batch = nil
+	case marks != nil && marks.count() > 0:
+		marks.clear()
+	default:
+		return false
+	}
+	return true
+}
+
+// batchJobAction returns the confirmation running an action on the marked
+// jobs ("b" trigger, "x" abort, "E" enable/disable), or the notice
+// explaining why it is not available. A single job gets a plain action.
+func batchJobAction(client *jenkins.Client, key string, jobs []models.Job) (*confirmAction, string) {
+	if len(jobs) == 0 {
+		return nil, ""
+	}
+	switch key {
+	case "b":
+		jobs, denied := permittedJobs(client, jenkins.PermBuild, jobs)
+		if len(jobs) == 0 {
+			return nil, blockedNotice(client, jenkins.PermBuild)
+		}
+		if len(jobs) == 1 && denied == 0 {
+			name := jobs[0].Name
+			return &confirmAction{
+				prompt: fmt.Sprintf("Trigger a new build of %s?", name),
+				run: runAction("Build triggered for "+name, func(ctx context.Context) error {
+					return client.TriggerBuild(ctx, name)
+				}),
+			}, ""
+		}
+		var items []batchItem
+		for _, job := range jobs {
+			name := job.Name
+			items = append(items, batchItem{key: name, label: name, run: func(ctx context.Context) error {
+				return client.TriggerBuild(ctx, name)
+			}})
+		}
+		return &confirmAction{
+			prompt: fmt.Sprintf("Trigger a new build of %d jobs?%s", len(items), skippedNote(denied, "not permitted")),
+			run:    startBatch("Trigger", items),
+		}, ""
+
+	case "x":
+		jobs, denied := permittedJobs(client, jenkins.PermCancel, jobs)
+		if len(jobs) == 0 {
+			return nil, blockedNotice(client, jenkins.PermCancel)
+		}
+		var items []batchItem
+		for _, job := range jobs {
+			if !job.IsRunning() || job.LastBuild == nil {
+				continue
+			}
+			name, number := job.Name, job.LastBuild.Number
+			items = append(items, batchItem{key: name, label: fmt.Sprintf("%s #%d", name, number), run: func(ctx context.Context) error {
+				return client.AbortBuild(ctx, name, number)
+			}})
+		}
+		if len(items) == 0 && len(jobs) == 1 {
+			return nil, jobs[0].Name + " is not running"
+		}
+		if len(items) == 0 {
+			return nil, "None of the marked jobs is running"
+		}
+		if len(jobs) == 1 && denied == 0 {
+			name, number := jobs[0].Name, jobs[0].LastBuild.Number
+			return &confirmAction{
+				prompt: fmt.Sprintf("Abort %s #%d?", name, number),
+				run: runAction(fmt.Sprintf("Abort requested for %s #%d", name, number), func(ctx context.Context) error {
+					return client.AbortBuild(ctx, name, number)
+				}),
+			}, ""
+		}
+		return &confirmAction{
+			prompt: fmt.Sprintf("Abort the running builds of %d jobs?%s%s", len(items), skippedNote(len(jobs)-len(items), "not running"), skippedNote(denied, "not permitted")),
+			run:    startBatch("Abort", items),
+		}, ""
+
+	case "E":
+		if len(jobs) == 1 {
+			return toggleJobAction(client, jobs[0])
+		}
+		jobs, denied := permittedJobs(client, jenkins.PermConfigure, jobs)
+		if len(jobs) == 0 {
+			return nil, blockedNotice(client, jenkins.PermConfigure)
+		}
+		// Disable unless every marked job is disabled already
+		enable := true
+		for _, job := range jobs {
+			if !job.IsDisabled() {
+				enable = false
+			}
+		}
+		var items []batchItem
+		for _, job := range jobs {
+			if !enable && job.IsDisabled() {
+				continue
+			}
+			name := job.Name
+			items = append(items, batchItem{key: name, label: name, run: func(ctx context.Context) error {
+				if enable {
+					return client.EnableJob(ctx, name)
+				}
+				return client.DisableJob(ctx, name)
+			}})
+		}
+		if enable {
+			return &confirmAction{
+				prompt: fmt.Sprintf("Enable %d jobs?%s", len(items), skippedNote(denied, "not permitted")),
+				run:    startBatch("Enable", items),
+			}, ""
+		}
+		return &confirmAction{
+			prompt: fmt.Sprintf("Disable %d jobs?%s%s", len(items), skippedNote(len(jobs)-len(items), "already disabled"), skippedNote(denied, "not permitted")),
+			run:    startBatch("Disable", items),
+		}, ""
+	}
+	return nil, ""
+}
+
+// batchMarkedJobs returns the confirmation running an action on the marked
+// jobs the filter shows, like batchJobAction. Marked jobs hidden by the
+// filter are left out, and the prompt says how many.
+func batchMarkedJobs(client *jenkins.Client, key string, all, shown []models.Job, s selection) (*confirmAction, string) {
+	jobs, hidden := shownMarkedJobs(all, shown, s)
+	if len(jobs) == 0 && hidden > 0 {
+		return nil, "The marked jobs are hidden by the filter"
+	}
+	confirm, notice := batchJobAction(client, key, jobs)
+	if confirm != nil {
+		confirm.prompt += skippedNote(hidden, "hidden by the filter")
+	}
+	return confirm, notice
+}
+
+// batchAbortBuilds returns the confirmation aborting the marked running builds
+// of a job, or the notice explaining why it is not available
+func batchAbortBuilds(client *jenkins.Client, jobName string, builds []models.BuildRef, s selection) (*confirmAction, string) {
+	if !canPerform(client, jenkins.PermCancel, jobName, 0) {
+		return nil, blockedNotice(client, jenkins.PermCancel)
+	}
+	var items []batchItem
+	skipped := 0
+	for _, build := range builds {
+		key := strconv.Itoa(build.Number)
+		if !s.has(key) {
+			continue
+		}
+		if !build.Building {
+			skipped++
+			continue
+		}
+		number := build.Number
+		items = append(items, batchItem{key: key, label: fmt.Sprintf("#%d", number), run: func(ctx context.Context) error {
+			return client.AbortBuild(ctx, jobName, number)
+		}})
+	}
+	if len(items) == 0 {
+		return nil, "None of the marked builds is running"
+	}
+	return &confirmAction{
+		prompt: fmt.Sprintf("Abort %d running builds of %s?%s", len(items), jobName, skippedNote(skipped, "finished")),
+		run:    startBatch("Abort", items),
+	}, ""
+}
+
+// skippedNote tells how many marked items an action leaves out
+func skippedNote(skipped int, reason string) string {
+	if skipped == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" (%d %s skipped)", skipped, reason)
+}
+
+// toggleMark marks or unmarks the selected row and moves to the next one
+func (m *BuildsModel) toggleMark() {
+	switch m.mode {
+	case ModeJobList:
+		filtered := m.getFilteredJobs()
+		if m.selectedJob < len(filtered) {
+			m.jobMarks.toggle(filtered[m.selectedJob].Name)
+			m.navigateDown()
+		}
+	case ModeBuildList:
+		if m.selectedBuild < len(m.builds) {
+			m.buildMarks.toggle(strconv.Itoa(m.builds[m.selectedBuild].Number))
+			m.navigateDown()
+		}
+	}
+}
+
+// markRange starts or ends a range of marked rows on the selected row
+func (m *BuildsModel) markRange() {
+	switch m.mode {
+	case ModeJobList:
+		m.jobMarks.markRange(jobKeys(m.getFilteredJobs()), m.selectedJob)
+	case ModeBuildList:
+		m.buildMarks.markRange(buildKeys(m.builds), m.selectedBuild)
+	}
+}
+
+// requestBatch asks for confirmation before running an action on the marked rows
+func (m *BuildsModel) requestBatch(key string) {
+	if m.batchRunning() {
+		m.notice, m.noticeErr = "A batch action is still running", true
+		return
+	}
+	var notice string
+	switch m.mode {
+	case ModeJobList:
+		m.confirm, notice = batchMarkedJobs(m.client, key, m.jobs, m.getFilteredJobs(), m.jobMarks)
+	case ModeBuildList:
+		if m.jobDetail == nil {
+			return
+		}
+		m.confirm, notice = batchAbortBuilds(m.client, m.jobDetail.Name, m.builds, m.buildMarks)
+	}
+	if m.confirm == nil {
+		m.notice, m.noticeErr = notice, true
+	}
+}
+
+// listMarks returns the marks of the list shown, or nil
+func (m *BuildsModel) listMarks() *selection {
+	switch m.mode {
+	case ModeJobList:
+		return &m.jobMarks
+	case ModeBuildList:
+		return &m.buildMarks
+	}
+	return nil
+}
+
+// reloadAfterBatch reports the outcome of the batch and reloads the list
+func (m *BuildsModel) reloadAfterBatch() tea.Cmd {
+	m.notice, m.noticeErr = m.batch.outcome()
+	if m.mode == ModeJobList {
+		return m.fetchJobs()
+	}
+	if m.jobDetail != nil {
+		return m.fetchJobDetail(m.jobDetail.Name)
+	}
+	return nil
+}
+
+// toggleMark marks or unmarks the selected job and moves to the next one
+func (m *ViewsModel) toggleMark() {
+	filtered := m.getFilteredJobs()
+	if m.mode == ViewsModeJobs && m.selectedJob < len(filtered) {
+		m.jobMarks.toggle(filtered[m.selectedJob].Name)
+		m.navigateDown()
+	}
+}
+
+// requestJobAction asks for confirmation before running an action on the
+// marked jobs, or on the selected job when none is marked
+func (m *ViewsModel) requestJobAction(key string) {
+	if m.mode != ViewsModeJobs {
+		return
+	}
+	if m.batchRunning() {
+		m.notice, m.noticeErr = "A batch action is still running", true
+		return
+	}
+	var notice string
+	if m.jobMarks.count() > 0 {
+		m.confirm, notice = batchMarkedJobs(m.client, key, m.jobs, m.getFilteredJobs(), m.jobMarks)
+	} else if job := m.selectedListJob(); job != nil {
+		m.confirm, notice = batchJobAction(m.client, key, []models.Job{*job})
+	}
+	if m.confirm == nil && notice != "" {
+		m.notice, m.noticeErr = notice, true
+	}
+}
+
+// reloadAfterBatch reports the outcome of the batch and reloads the jobs
+func (m *ViewsModel) reloadAfterBatch() tea.Cmd {
+	m.notice, m.noticeErr = m.batch.outcome()
+	if m.mode == ViewsModeJobs && m.selectedView < len(m.views) {
+		return m.fetchViewJobs(m.views[m.selectedView].Name)
+	}
+	return nil
+}
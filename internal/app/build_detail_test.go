@@ -1,9 +1,16 @@
 package app
 
 import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/elogrono/jenkins-tui/internal/config"
+	"github.com/elogrono/jenkins-tui/internal/jenkins"
 	"github.com/elogrono/jenkins-tui/internal/jenkins/models"
 )
 
@@ -80,3 +87,571 @@ func TestBuildDetailView(t *testing.T) {
 		}
 	}
 }
+
+func TestStageStepsDrillDown(t *testing.T) {
+	m := &BuildsModel{
+		width:       120,
+		height:      40,
+		mode:        ModeStageSteps,
+		jobDetail:   &models.JobDetail{Name: "test-job"},
+		buildDetail: &models.Build{Number: 7},
+		pipelineRun: &models.PipelineRun{
+			Stages: []models.Stage{{ID: "12", Name: "Test", Status: "FAILED"}},
+		},
+	}
+
+	m.Update(StageStepsMsg{
+		StageID: "12",
+		Steps: []models.WFAPIFlowNode{
+			{ID: "13", Name: "Git", Status: "SUCCESS", ParameterDescription: "https://git.example.com/app.git"},
+			{ID: "14", Name: "Shell Script", Status: "FAILED", ParameterDescription: "make test", DurationMillis: 3000},
+			{ID: "15", Name: "Archive", Status: "NOT_EXECUTED"},
+		},
+	})
+
+	if m.selectedStep != 1 {
+		t.Errorf("expected failing step to be preselected, got %d", m.selectedStep)
+	}
+
+	view := m.viewStageSteps()
+	for _, s := range []string{"Test", "Shell Script", "make test", "Archive", "Step log"} {
+		if !strings.Contains(view, s) {
+			t.Errorf("expected steps view to contain %q", s)
+		}
+	}
+
+	// Steps for a stage that is no longer selected are ignored
+	m.Update(StageStepsMsg{StageID: "99", Steps: []models.WFAPIFlowNode{{ID: "1"}}})
+	if len(m.stageSteps) != 3 {
+		t.Errorf("expected stale steps to be ignored, got %d steps", len(m.stageSteps))
+	}
+
+	// Esc from a step log returns to the step list
+	m.openStageLog("14", true)
+	if title := m.viewLog(); !strings.Contains(title, "Step: Shell Script") {
+		t.Error("expected step log title")
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.mode != ModeStageSteps {
+		t.Errorf("expected to return to step list, got mode %d", m.mode)
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.mode != ModeBuildDetail {
+		t.Errorf("expected to return to build detail, got mode %d", m.mode)
+	}
+}
+
+func TestTimelineView(t *testing.T) {
+	m := &BuildsModel{
+		width:       120,
+		height:      40,
+		mode:        ModeBuildDetail,
+		jobDetail:   &models.JobDetail{Name: "test-job"},
+		buildDetail: &models.Build{Number: 9},
+		pipelineRun: &models.PipelineRun{
+			StartTimeMillis: 1000,
+			Stages: []models.Stage{
+				{ID: "1", Name: "Build", Status: "SUCCESS", StartTimeMillis: 1000, DurationMillis: 20000},
+				{ID: "2", Name: "Approval", Status: "SUCCESS", StartTimeMillis: 21000, DurationMillis: 60000, PauseDurationMillis: 45000},
+			},
+		},
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")})
+	if m.mode != ModeTimeline {
+		t.Fatalf("expected timeline mode, got %d", m.mode)
+	}
+
+	view := m.View()
+	for _, s := range []string{"Timeline", "Build", "Approval", "paused 45s", "Span 1m 20s"} {
+		if !strings.Contains(view, s) {
+			t.Errorf("expected timeline view to contain %q", s)
+		}
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.mode != ModeBuildDetail {
+		t.Errorf("expected esc to return to build detail, got %d", m.mode)
+	}
+}
+
+func TestAnalyticsView(t *testing.T) {
+	m := &BuildsModel{
+		width:     120,
+		height:    40,
+		mode:      ModeBuildList,
+		jobDetail: &models.JobDetail{Name: "test-job"},
+		builds: []models.BuildRef{
+			{Number: 3, Result: "SUCCESS", Duration: 90000, Stages: []models.Stage{{Name: "Compile", DurationMillis: 60000}}},
+			{Number: 2, Result: "FAILURE", Duration: 30000, Stages: []models.Stage{{Name: "Compile", DurationMillis: 30000}}},
+			{Number: 1, Result: "SUCCESS", Duration: 60000},
+		},
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	if m.mode != ModeJobAnalytics {
+		t.Fatalf("expected analytics mode, got %d", m.mode)
+	}
+
+	view := m.View()
+	for _, s := range []string{"Analytics", "Duration", "mean", "1m", "p95", "1m 30s", "67%", "(2/3", "Stage trends", "Compile", "45s"} {
+		if !strings.Contains(view, s) {
+			t.Errorf("expected analytics view to contain %q", s)
+		}
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.mode != ModeBuildList {
+		t.Errorf("expected esc to return to the build list, got %d", m.mode)
+	}
+}
+
+func TestCompareBuilds(t *testing.T) {
+	m := &BuildsModel{
+		width:     140,
+		height:    60,
+		mode:      ModeBuildList,
+		jobDetail: &models.JobDetail{Name: "test-job"},
+		builds: []models.BuildRef{
+			{Number: 12, Result: "FAILURE"},
+			{Number: 11, Result: "FAILURE"},
+			{Number: 10, Result: "SUCCESS"},
+		},
+	}
+
+	// C compares the selected build with the last green one
+	if cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("C")}); cmd == nil || m.mode != ModeCompare {
+		t.Fatalf("expected compare mode with a fetch, got mode %d", m.mode)
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+
+	// c marks a base, c on another build compares
+	m.selectedBuild = 1
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")})
+	if m.compareMark != 11 || m.mode != ModeBuildList {
+		t.Fatalf("expected #11 marked, got %d", m.compareMark)
+	}
+	m.selectedBuild = 0
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")})
+	if m.mode != ModeCompare || m.compareMark != 0 {
+		t.Fatalf("expected compare mode, got %d", m.mode)
+	}
+
+	base := &models.Build{Number: 11, Result: "FAILURE", Actions: []models.Action{{Parameters: []models.Parameter{{Name: "ENV", Value: "staging"}}}}}
+	target := &models.Build{Number: 12, Result: "SUCCESS", Actions: []models.Action{{Parameters: []models.Parameter{{Name: "ENV", Value: "prod"}}}},
+		ChangeSets: []models.ChangeSet{{Items: []models.ChangeItem{{CommitID: "abcdef123456", Msg: "Fix flaky test", Author: models.Author{FullName: "Dev"}}}}},
+		Artifacts:  []models.Artifact{{FileName: "app.jar", RelativePath: "build/app.jar"}}}
+	comparison := models.CompareBuilds(base, target,
+		&models.PipelineRun{Stages: []models.Stage{{Name: "Test", Status: "FAILED", DurationMillis: 5000}}},
+		&models.PipelineRun{Stages: []models.Stage{{Name: "Test", Status: "SUCCESS", DurationMillis: 4000}}},
+		nil)
+	m.Update(CompareDataMsg{Comparison: &comparison})
+
+	view := m.View()
+	for _, s := range []string{"#11 ⇄ #12", "ENV", "staging", "prod", "abcdef12", "Fix flaky test", "Test", "FAILED", "build/app.jar", "Log diff"} {
+		if !strings.Contains(view, s) {
+			t.Errorf("expected compare view to contain %q", s)
+		}
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	if !m.compareShowLog {
+		t.Fatal("expected d to switch to the log diff")
+	}
+	m.Update(CompareLogMsg{Diff: []string{"--- #11", "+++ #12", "@@ -1,1 +1,1 @@", "-tests failed", "+tests passed"}})
+	view = m.View()
+	for _, s := range []string{"Console log diff", "-tests failed", "+tests passed"} {
+		if !strings.Contains(view, s) {
+			t.Errorf("expected log diff view to contain %q", s)
+		}
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.mode != ModeBuildList || m.comparison != nil {
+		t.Errorf("expected esc to return to the build list, got mode %d", m.mode)
+	}
+}
+
+func TestNormalizeLog(t *testing.T) {
+	a := normalizeLog("[2024-05-01T10:00:01.123Z] Started\x1b[0m  \nTook 12.5 s at 10:00:14\nid 1b4e28ba-2fa1-11d2-883f-0016d3cca427\n")
+	b := normalizeLog("[2024-05-02T08:30:59.999Z] Started\nTook 3 s at 08:31:02\nid 6fa459ea-ee8a-3ca4-894e-db77e160355e\n")
+	if len(a) != 3 || strings.Join(a, "\n") != strings.Join(b, "\n") {
+		t.Errorf("expected logs to normalize equally:\n%q\n%q", a, b)
+	}
+}
+
+func TestTestsView(t *testing.T) {
+	m := &BuildsModel{
+		width:       140,
+		height:      50,
+		mode:        ModeBuildDetail,
+		jobDetail:   &models.JobDetail{Name: "test-job"},
+		buildDetail: &models.Build{Number: 8},
+	}
+
+	if cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("T")}); cmd == nil || m.mode != ModeTests {
+		t.Fatalf("expected tests mode with a fetch, got mode %d", m.mode)
+	}
+
+	m.Update(TestReportMsg{Build: 8, Report: &models.TestReport{
+		FailCount: 2, PassCount: 1, SkipCount: 1,
+		Suites: []models.TestSuite{
+			{Name: "app.ApiTest", Cases: []models.TestCase{
+				{ClassName: "app.ApiTest", Name: "testGet", Status: "PASSED"},
+				{ClassName: "app.ApiTest", Name: "testPost", Status: "REGRESSION", Age: 1, ErrorDetails: "expected 201 but was 500", ErrorStackTrace: "at app.ApiTest.testPost(ApiTest.java:42)"},
+			}},
+			{Name: "app.DbTest", Cases: []models.TestCase{
+				{ClassName: "app.DbTest", Name: "testMigrate", Status: "FAILED", Age: 3, FailedSince: 6},
+				{ClassName: "app.DbTest", Name: "testSkip", Status: "SKIPPED", Skipped: true},
+			}},
+		},
+	}})
+
+	view := m.View()
+	for _, s := range []string{"1 passed", "2 failed", "(1 new)", "1 skipped", "Failures", "app.ApiTest.testPost", "NEW", "3 builds", "Suites", "app.DbTest"} {
+		if !strings.Contains(view, s) {
+			t.Errorf("expected tests view to contain %q", s)
+		}
+	}
+
+	// The new failure is listed first and opens its details
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.mode != ModeTestDetail || m.testCase == nil || m.testCase.Name != "testPost" {
+		t.Fatalf("expected details of testPost, got mode %d", m.mode)
+	}
+	view = m.View()
+	for _, s := range []string{"new failure", "expected 201 but was 500", "Stack trace", "ApiTest.java:42"} {
+		if !strings.Contains(view, s) {
+			t.Errorf("expected test detail to contain %q", s)
+		}
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+
+	// Status filter: new failures only
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("f")})
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("f")})
+	if m.testStatus != testFilterNew {
+		t.Fatalf("expected new failures filter, got %v", m.testStatus)
+	}
+	for _, row := range m.testRows() {
+		if row.test != nil && row.test.Name != "testPost" {
+			t.Errorf("unexpected row %q with new failures filter", row.test.Name)
+		}
+	}
+
+	// Text filter matches names and error details
+	m.testStatus = testFilterAll
+	m.testFilter = "migrate"
+	rows := m.testRows()
+	if len(rows) != 3 || rows[0].test.Name != "testMigrate" || rows[1].test != nil {
+		t.Errorf("expected failure, suite and case rows for testMigrate, got %d rows", len(rows))
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.mode != ModeBuildDetail {
+		t.Errorf("expected esc to return to build detail, got %d", m.mode)
+	}
+}
+
+func TestFlakyTestsView(t *testing.T) {
+	m := &BuildsModel{
+		width:     140,
+		height:    50,
+		mode:      ModeBuildList,
+		jobDetail: &models.JobDetail{Name: "test-job", URL: "http://jenkins/job/test-job/"},
+		builds:    []models.BuildRef{{Number: 3, Result: "FAILURE"}, {Number: 2, Result: "SUCCESS"}, {Number: 1, Result: "FAILURE"}},
+	}
+
+	if cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("F")}); cmd == nil || m.mode != ModeFlakyTests {
+		t.Fatalf("expected flaky tests mode with a fetch, got mode %d", m.mode)
+	}
+
+	report := func(status, details string) *models.TestReport {
+		return &models.TestReport{Suites: []models.TestSuite{{Name: "app.ApiTest", Cases: []models.TestCase{
+			{ClassName: "app.ApiTest", Name: "testTimeout", Status: status, ErrorDetails: details},
+		}}}}
+	}
+	m.Update(FlakyTestsMsg{Job: "test-job", History: []models.BuildTestReport{
+		{Build: 3, Report: report("FAILED", "timed out after 30s")},
+		{Build: 2, Report: report("PASSED", "")},
+		{Build: 1, Report: report("FAILED", "connection reset")},
+	}})
+
+	view := m.View()
+	for _, s := range []string{"Flaky tests", "100%", "2/3", "app.ApiTest.testTimeout", "#3", "#1"} {
+		if !strings.Contains(view, s) {
+			t.Errorf("expected flaky view to contain %q", s)
+		}
+	}
+
+	// Pick the older failure and open the test as it failed there
+	m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.mode != ModeTestDetail || m.testCaseBuild != 1 {
+		t.Fatalf("expected test detail of build #1, got mode %d build %d", m.mode, m.testCaseBuild)
+	}
+	if view := m.View(); !strings.Contains(view, "connection reset") {
+		t.Error("expected the error of build #1")
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.mode != ModeFlakyTests {
+		t.Errorf("expected esc to return to flaky tests, got %d", m.mode)
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.mode != ModeBuildList {
+		t.Errorf("expected esc to return to the build list, got %d", m.mode)
+	}
+}
+
+func TestArtifactsView(t *testing.T) {
+	m := NewBuildsModel(nil, 140, 50)
+	m.loading = false
+	m.mode = ModeBuildDetail
+	m.jobDetail = &models.JobDetail{Name: "test-job"}
+	m.buildDetail = &models.Build{Number: 7, Artifacts: []models.Artifact{
+		{FileName: "app.bin", RelativePath: "dist/app.bin"},
+		{FileName: "summary.json", RelativePath: "reports/summary.json"},
+	}}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("A")})
+	if m.mode != ModeArtifacts {
+		t.Fatalf("expected artifacts mode, got %d", m.mode)
+	}
+	if view := m.View(); !strings.Contains(view, "dist/app.bin") || !strings.Contains(view, "reports/summary.json") {
+		t.Error("expected both artifacts to be listed")
+	}
+
+	// Binary artifacts fall back to the list with a hint
+	if cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter}); cmd == nil || m.mode != ModeArtifactPreview {
+		t.Fatalf("expected a preview fetch, got mode %d", m.mode)
+	}
+	m.Update(ArtifactPreviewMsg{Path: "dist/app.bin", Error: jenkins.ErrBinaryArtifact})
+	if m.mode != ModeArtifacts || !strings.Contains(m.notice, "not a text file") {
+		t.Errorf("expected the list with a binary notice, got mode %d notice %q", m.mode, m.notice)
+	}
+
+	// JSON artifacts are indented
+	m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m.Update(ArtifactPreviewMsg{Path: "reports/summary.json", Preview: &jenkins.ArtifactPreview{Content: `{"passed":12}`}})
+	if view := m.View(); !strings.Contains(view, `"passed": 12`) {
+		t.Error("expected the indented JSON preview")
+	}
+
+	// Downloads ask for a directory; keys go to the input meanwhile
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	if !m.InputActive() || m.downloadTarget != "reports/summary.json" {
+		t.Fatalf("expected a directory prompt for the previewed artifact, got %q", m.downloadTarget)
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+	if !strings.HasSuffix(m.downloadInput.Value(), "q") {
+		t.Errorf("expected typed keys in the input, got %q", m.downloadInput.Value())
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.InputActive() || m.mode != ModeArtifactPreview {
+		t.Errorf("expected esc to cancel only the prompt, got mode %d", m.mode)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m.download = &artifactDownload{name: "all artifacts (zip)", total: -1, progress: make(chan tea.Msg)}
+	m.Update(ArtifactProgressMsg{Written: 512, Total: 2048})
+	if view := m.View(); !strings.Contains(view, "Downloading all artifacts (zip)") || !strings.Contains(view, "25%") {
+		t.Error("expected download progress")
+	}
+	m.Update(ArtifactDownloadedMsg{File: "/tmp/test-job-7-artifacts.zip"})
+	if m.download != nil || m.notice != "Saved /tmp/test-job-7-artifacts.zip" {
+		t.Errorf("expected the saved file to be reported, got %q", m.notice)
+	}
+}
+
+func TestArtifactDownloadInBackground(t *testing.T) {
+	m := NewModel(config.DefaultConfig())
+	m.state = StateReady
+	m.width, m.height = 120, 40
+	m.initTabModels()
+	b := m.buildsModel
+	b.mode = ModeArtifacts
+
+	cancelled := false
+	b.download = &artifactDownload{name: "app.bin", total: -1, progress: make(chan tea.Msg, 1), cancel: func() { cancelled = true }}
+	b.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")})
+	if !cancelled {
+		t.Fatal("expected c to cancel the download")
+	}
+
+	// The outcome reaches the Builds tab while the dashboard is shown
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("1")})
+	m.Update(ArtifactDownloadedMsg{Error: fmt.Errorf("error downloading: %w", context.Canceled)})
+	if b.download != nil || b.notice != "Download of app.bin cancelled" {
+		t.Errorf("expected the cancelled download cleared, got %q", b.notice)
+	}
+}
+
+func TestInputForm(t *testing.T) {
+	m := &BuildsModel{
+		width:       140,
+		height:      50,
+		mode:        ModeBuildDetail,
+		jobDetail:   &models.JobDetail{Name: "deploy"},
+		buildDetail: &models.Build{Number: 12, Building: true},
+		pendingInputs: []models.PendingInput{{ID: "Approve", Message: "Deploy to prod?", ProceedText: "Ship it", Inputs: []models.InputParam{
+			{Type: "BooleanParameterDefinition", Name: "DRY_RUN"},
+			{Type: "ChoiceParameterDefinition", Name: "REGION", Definition: map[string]interface{}{"choices": []interface{}{"eu", "us"}}},
+			{Type: "StringParameterDefinition", Name: "TAG"},
+		}}},
+	}
+
+	if view := m.View(); !strings.Contains(view, "Waiting for input: Deploy to prod?") {
+		t.Error("expected the input banner in build detail")
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("i")})
+	if m.mode != ModeInput || !m.InputActive() {
+		t.Fatalf("expected the input form, got mode %d", m.mode)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeySpace})                      // DRY_RUN on
+	m.Update(tea.KeyMsg{Type: tea.KeyDown})                       // REGION
+	m.Update(tea.KeyMsg{Type: tea.KeyRight})                      // us
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})                      // TAG
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v2")}) // typed, not shortcuts
+	if values := m.inputForm.values(); values["DRY_RUN"] != "true" || values["REGION"] != "us" || values["TAG"] != "v2" {
+		t.Errorf("unexpected values %v", values)
+	}
+	if view := m.View(); !strings.Contains(view, "Ship it") || !strings.Contains(view, "Abort") {
+		t.Error("expected proceed and abort buttons")
+	}
+
+	// Submitting asks for confirmation; declining keeps the form
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.confirm == nil || !strings.Contains(m.confirm.prompt, "Ship it deploy #12") {
+		t.Fatalf("expected a proceed confirmation, got %+v", m.confirm)
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.confirm == nil || !strings.HasPrefix(m.confirm.prompt, "Abort deploy #12") {
+		t.Fatalf("expected an abort confirmation, got %+v", m.confirm)
+	}
+	m.confirm = nil
+
+	// A successful answer returns to the build, which is reloaded
+	if cmd := m.Update(ActionResultMsg{Message: "Input approved"}); cmd == nil || m.mode != ModeBuildDetail || m.pendingInputs != nil {
+		t.Errorf("expected build detail to reload, got mode %d", m.mode)
+	}
+}
+
+func TestDashboardWaitingForInput(t *testing.T) {
+	m := &DashboardModel{
+		width:  160,
+		height: 40,
+		nodes: []models.Node{{DisplayName: "built-in", OneOffExecutors: []models.Executor{
+			{CurrentExecutable: models.ExecutableRef{URL: "http://jenkins/job/deploy/12/", Number: 12, FullDisplayName: "deploy #12"}},
+		}}},
+	}
+	m.runningBuilds = m.extractRunningBuilds()
+	if len(m.runningBuilds) != 1 {
+		t.Fatalf("expected the flyweight build to be running, got %d", len(m.runningBuilds))
+	}
+
+	m.Update(DashboardInputsMsg{Waiting: map[string]string{"http://jenkins/job/deploy/12/": "Deploy to prod?"}})
+	if panel := m.renderRunningBuildsPanel(150, 10); !strings.Contains(panel, "deploy #12 waiting for input: Deploy to prod?") {
+		t.Errorf("expected the waiting banner, got:\n%s", panel)
+	}
+}
+
+func TestReplayEdit(t *testing.T) {
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "code --wait")
+	if cmd := editorCommand([]string{"Jenkinsfile"}); strings.Join(cmd.Args, " ") != "code --wait Jenkinsfile" {
+		t.Errorf("unexpected editor command %v", cmd.Args)
+	}
+
+	original := models.ReplayScripts{Main: "echo 'a'", Loaded: []models.LoadedScript{{Name: "Script1", Script: "def x = 1"}}}
+	dir := filepath.Join(t.TempDir(), "replay")
+	os.Mkdir(dir, 0o700)
+	files, err := writeReplayScripts(dir, original)
+	if err != nil || len(files) != 2 || filepath.Base(files[1]) != "Script1.groovy" {
+		t.Fatalf("unexpected files %v, %v", files, err)
+	}
+	os.WriteFile(files[0], []byte("echo 'b'"), 0o600)
+
+	m := &BuildsModel{width: 120, height: 40, mode: ModeBuildDetail}
+	m.applyReplayEdited(ReplayEditedMsg{JobName: "app", Build: 7, Dir: dir, Original: original})
+	if m.confirm == nil || m.confirm.prompt != "Replay app #7 with 1 edited script(s)?" {
+		t.Fatalf("expected a replay confirmation, got %+v", m.confirm)
+	}
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Error("expected the temporary scripts to be removed")
+	}
+
+	// The replayed build is opened once the job lists it
+	m.applyReplayStarted(ReplayStartedMsg{JobName: "app", Build: 7, NewBuild: 9})
+	m.jobDetail = &models.JobDetail{Name: "app"}
+	m.builds = []models.BuildRef{{Number: 8}, {Number: 7}}
+	if cmd := m.followReplay(); cmd == nil || m.replayPolls != 1 || m.mode != ModeBuildDetail {
+		t.Fatal("expected to poll while the replay is queued")
+	}
+	m.mode = ModeBuildList
+	m.builds = append([]models.BuildRef{{Number: 9}}, m.builds...)
+	m.selectedBuild = 2
+	if cmd := m.followReplay(); cmd == nil || m.mode != ModeBuildDetail || m.selectedBuild != 0 || m.replayBuild != 0 {
+		t.Errorf("expected build #9 opened, got mode %d selected %d", m.mode, m.selectedBuild)
+	}
+}
+
+func TestReplayPollStopsOnAnotherJob(t *testing.T) {
+	m := NewBuildsModel(nil, 120, 40)
+	m.mode = ModeBuildDetail
+	m.jobDetail = &models.JobDetail{Name: "app"}
+	m.applyReplayStarted(ReplayStartedMsg{JobName: "app", Build: 7, NewBuild: 9})
+	m.builds = []models.BuildRef{{Number: 8}, {Number: 7}}
+	if cmd := m.followReplay(); cmd == nil {
+		t.Fatal("expected to poll while the replay is queued")
+	}
+	if cmd := m.Update(ReplayPollMsg{}); cmd == nil {
+		t.Fatal("expected the job reloaded while it stays open")
+	}
+
+	// Opening another job before the next tick stops following the replay
+	m.OpenJob("other")
+	if cmd := m.Update(ReplayPollMsg{}); cmd != nil || m.replayBuild != 0 {
+		t.Error("expected the poll dropped once another job is open")
+	}
+	if m.jobDetail.Name != "other" || m.mode != ModeBuildList {
+		t.Errorf("expected the other job kept, got %s in mode %d", m.jobDetail.Name, m.mode)
+	}
+}
+
+func TestLintView(t *testing.T) {
+	m := NewBuildsModel(nil, 120, 40)
+	m.loading = false
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("L")})
+	if m.mode != ModeLint || !m.InputActive() || m.lintInput.Value() != "Jenkinsfile" {
+		t.Fatalf("expected the lint prompt, got mode %d", m.mode)
+	}
+	m.lintInput.SetValue("ci/Jenkinsfile")
+	if cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter}); cmd == nil || m.lintPath != "ci/Jenkinsfile" || m.InputActive() {
+		t.Fatal("expected enter to start linting")
+	}
+
+	m.applyLintResult(LintResultMsg{
+		Path:   "ci/Jenkinsfile",
+		Source: "pipeline {\n    stages {\n        stage('build') {\n",
+		Result: &models.LintResult{Errors: []models.LintError{{Line: 3, Column: 9, Message: "Unknown stage section"}}},
+	})
+	view := m.View()
+	for _, want := range []string{"1 error(s)", "3:9", "Unknown stage section", "stage('build')", "^"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q in the lint view", want)
+		}
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.mode != ModeJobList {
+		t.Errorf("expected esc to return to the job list, got mode %d", m.mode)
+	}
+}
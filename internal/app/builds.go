@@ -3,6 +3,8 @@ package app
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -26,6 +28,18 @@ const (
 	ModeJobList BuildsMode = iota
 	ModeBuildList
 	ModeBuildDetail
+	ModeStageSteps
+	ModeTimeline
+	ModeJobAnalytics
+	ModeCompare
+	ModeTests
+	ModeTestDetail
+	ModeFlakyTests
+	ModeArtifacts
+	ModeArtifactPreview
+	ModeInput
+	ModeLint
+	ModeJobConfig
 	ModeStageLogView
 	ModeLogView
 )
@@ -48,7 +62,82 @@ type BuildsModel struct {
 	selectedStage int
 	buildDetail   *models.Build
 	pipelineRun   *models.PipelineRun
+	pendingInputs []models.PendingInput // Input steps the build is paused on
+	inputForm     *inputForm
 	logContent    string
+	logLines      []logLine // logContent parsed for display, search and grep
+
+	// Step drill-down of the selected stage
+	stageSteps   []models.WFAPIFlowNode
+	selectedStep int
+	stepsScroll  int
+	logStepID    string // Step whose log is shown; empty for the whole stage
+	logFromSteps bool   // Stage/step log was opened from the step list
+
+	// Timeline
+	timelineScroll int
+
+	// Build comparison
+	compareMark    int // Build number marked as the comparison base, 0 if none
+	comparison     *models.BuildComparison
+	compareSkipped int
+	compareLog     []string // Unified diff of the normalized console logs
+	compareShowLog bool
+	compareScroll  int
+
+	// Test results
+	testReport       *models.TestReport
+	testExpanded     map[string]bool // Suites expanded in the tree, by name
+	testFilter       string
+	testStatus       testStatusFilter
+	selectedTest     int
+	testsScroll      int
+	testCase         *models.TestCase // Test shown in ModeTestDetail
+	testCaseBuild    int
+	testDetailFrom   BuildsMode
+	testDetailScroll int
+
+	// Flaky tests across the job's recent builds
+	flakyHistory  []models.BuildTestReport
+	flakyTests    []models.FlakyTest
+	selectedFlaky int
+	flakyScroll   int
+	flakyBuild    int // Selected failed build of the selected test
+
+	// Artifacts of the current build
+	selectedArtifact  int
+	artifactsScroll   int
+	artifactPreview   string // Relative path shown in ModeArtifactPreview
+	artifactTruncated bool
+	downloadInput     textinput.Model
+	downloadPrompting bool
+	downloadTarget    string // Relative path, or jenkins.ArchiveName for all
+	downloadDir       string // Last directory downloaded into
+	download          *artifactDownload
+
+	// Replayed build to open once the job lists it, while the screen the
+	// replay was started from stays open
+	replayJob   string
+	replayBuild int
+	replayPolls int
+	replayMode  BuildsMode
+
+	// Linter of a local Jenkinsfile
+	lintInput     textinput.Model
+	lintPrompting bool
+	lintPath      string
+	lintSource    []string // Lines of the linted file
+	lintResult    *models.LintResult
+	lintErr       error
+	lintRunning   bool
+	lintScroll    int
+	lintFrom      BuildsMode // Mode to return to
+
+	// config.xml of a job, viewed and edited in ModeJobConfig
+	configJob    string
+	configXML    string // As last fetched from Jenkins
+	configEdited string // Pending edit; empty when there is none
+	configFrom   BuildsMode
 
 	// UI components
 	viewport    viewport.Model
@@ -67,11 +156,35 @@ type BuildsModel struct {
 	logSearchInput textinput.Model
 	logSearching   bool
 	logFilter      string
+	logRegex       *regexp.Regexp // Compiled logFilter, nil without a search
+	logLiteral     bool           // logFilter is not a valid expression
+	logMatches     []logMatch
+	logMatchIdx    int
+	grep           logGrep
+	grepInput      textinput.Model
+	grepPrompting  bool
 
 	// Scroll
 	jobsScroll   int
 	buildsScroll int
 
+	// Pending confirmation and last action result
+	confirm   *confirmAction
+	notice    string
+	noticeErr bool
+
+	// Name prompt of a job copy
+	jobCopier
+
+	// Display name of the build being renamed
+	renameInput textinput.Model
+	renaming    bool
+
+	// Marked rows of the job and build lists, and the batch action run on them
+	jobMarks   selection
+	buildMarks selection
+	batcher
+
 	// State
 	loading    bool
 	lastError  error
@@ -95,6 +208,21 @@ func NewBuildsModel(client *jenkins.Client, width, height int) *BuildsModel {
 	logSearch.Width = 30
 	logSearch.Prompt = theme.IconSearch + " "
 
+	downloadDir := textinput.New()
+	downloadDir.Placeholder = "Download directory"
+	downloadDir.Width = 60
+	downloadDir.Prompt = theme.IconArtifact + " "
+
+	rename := textinput.New()
+	rename.Placeholder = "Display name"
+	rename.Width = 60
+	rename.Prompt = theme.IconBuild + " "
+
+	lintPath := textinput.New()
+	lintPath.Placeholder = "Path to Jenkinsfile"
+	lintPath.Width = 60
+	lintPath.Prompt = theme.IconFile + " "
+
 	p := paginator.New()
 	p.Type = paginator.Dots
 	p.PerPage = 20
@@ -109,6 +237,10 @@ func NewBuildsModel(client *jenkins.Client, width, height int) *BuildsModel {
 		spinner:        s,
 		searchInput:    search,
 		logSearchInput: logSearch,
+		grepInput:      newGrepInput(),
+		downloadInput:  downloadDir,
+		lintInput:      lintPath,
+		renameInput:    rename,
 		paginator:      p,
 		viewport:       vp,
 		pageSize:       20,
@@ -124,6 +256,12 @@ func (m *BuildsModel) SetSize(width, height int) {
 	m.viewport.Height = height - 12
 }
 
+// InputActive reports whether a text input has the keyboard, so global
+// shortcuts must not steal its keys
+func (m *BuildsModel) InputActive() bool {
+	return m.searching || m.logSearching || m.grepPrompting || m.downloadPrompting || m.lintPrompting || m.copyPrompt != nil || m.renaming || m.mode == ModeInput
+}
+
 // LoadData fetches builds data
 func (m *BuildsModel) LoadData() tea.Cmd {
 	m.loading = true
@@ -145,6 +283,12 @@ func (m *BuildsModel) Update(msg tea.Msg) tea.Cmd {
 			m.jobs = msg.Jobs
 			// Sort by last build timestamp
 			models.SortJobsByLastBuild(m.jobs)
+			// Keep the selection in range when jobs were deleted
+			if filtered := m.getFilteredJobs(); m.selectedJob >= len(filtered) {
+				m.selectedJob = maxInt(len(filtered)-1, 0)
+				m.jobsScroll = minInt(m.jobsScroll, m.selectedJob)
+			}
+			m.jobMarks.retain(jobKeys(m.jobs))
 		}
 		if msg.JobDetail != nil {
 			m.jobDetail = msg.JobDetail
@@ -152,17 +296,23 @@ func (m *BuildsModel) Update(msg tea.Msg) tea.Cmd {
 			// Sort builds by number (most recent first)
 			models.SortBuildsByNumber(m.builds)
 			m.totalBuilds = len(m.builds)
+			// Keep the selection in range when builds were deleted
+			if m.selectedBuild >= len(m.builds) {
+				m.selectedBuild = maxInt(len(m.builds)-1, 0)
+				m.buildsScroll = minInt(m.buildsScroll, m.selectedBuild)
+			}
+			m.buildMarks.retain(buildKeys(m.builds))
 			m.paginator.SetTotalPages((m.totalBuilds + m.pageSize - 1) / m.pageSize)
 		}
 		if msg.BuildDetail != nil {
 			m.buildDetail = msg.BuildDetail
+			m.pendingInputs = msg.PendingInputs
 		}
 		if msg.PipelineRun != nil {
 			m.pipelineRun = msg.PipelineRun
 		}
 		if msg.LogContent != "" {
-			m.logContent = msg.LogContent
-			m.viewport.SetContent(m.formatLogContent(m.logContent))
+			m.setLogContent(msg.LogContent)
 			if m.followLog {
 				m.viewport.GotoBottom()
 			}
@@ -170,6 +320,138 @@ func (m *BuildsModel) Update(msg tea.Msg) tea.Cmd {
 		if msg.Error != nil {
 			m.lastError = msg.Error
 		}
+		if msg.JobDetail != nil {
+			return m.followReplay()
+		}
+		return nil
+
+	case StageStepsMsg:
+		m.applyStageSteps(msg)
+		return nil
+
+	case TestReportMsg:
+		m.applyTestReport(msg)
+		return nil
+
+	case FlakyTestsMsg:
+		m.applyFlakyTests(msg)
+		return nil
+
+	case ArtifactPreviewMsg:
+		m.applyArtifactPreview(msg)
+		return nil
+
+	case ArtifactProgressMsg:
+		return m.applyArtifactProgress(msg)
+
+	case ArtifactDownloadedMsg:
+		m.applyArtifactDownloaded(msg)
+		return nil
+
+	case ReplayScriptsMsg:
+		return m.editReplayScripts(msg)
+
+	case ReplayEditedMsg:
+		m.applyReplayEdited(msg)
+		return nil
+
+	case ReplayStartedMsg:
+		return m.applyReplayStarted(msg)
+
+	case LintResultMsg:
+		m.applyLintResult(msg)
+		return nil
+
+	case JobConfigMsg:
+		m.applyJobConfig(msg)
+		return nil
+
+	case JobConfigEditedMsg:
+		m.applyJobConfigEdited(msg)
+		return nil
+
+	case JobConfigSavedMsg:
+		return m.applyJobConfigSaved(msg)
+
+	case BuildDescriptionEditedMsg:
+		m.applyBuildDescriptionEdited(msg)
+		return nil
+
+	case BuildDeletedMsg:
+		return m.applyBuildDeleted(msg)
+
+	case BatchStartMsg:
+		marks := &m.jobMarks
+		if m.mode == ModeBuildList {
+			marks = &m.buildMarks
+		}
+		return m.runBatch(msg, marks)
+
+	case BatchItemMsg:
+		return m.applyBatch(msg)
+
+	case BatchDoneMsg:
+		if !m.finishBatch(msg) {
+			return nil
+		}
+		return m.reloadAfterBatch()
+
+	case LintEditedMsg:
+		if msg.Error != nil {
+			m.notice, m.noticeErr = "Editor: "+msg.Error.Error(), true
+			return nil
+		}
+		return m.runLint()
+
+	case ReplayPollMsg:
+		if m.replayBuild == 0 {
+			return nil
+		}
+		// Stop following once the user opened another job or screen
+		if m.jobDetail == nil || m.jobDetail.Name != m.replayJob || m.mode != m.replayMode {
+			m.replayBuild = 0
+			return nil
+		}
+		return m.fetchJobDetail(m.replayJob)
+
+	case CompareDataMsg:
+		m.loading = false
+		if msg.Error != nil {
+			m.lastError = msg.Error
+			return nil
+		}
+		m.comparison = msg.Comparison
+		m.compareSkipped = msg.Skipped
+		return nil
+
+	case CompareLogMsg:
+		m.loading = false
+		if msg.Error != nil {
+			m.lastError = msg.Error
+			m.compareShowLog = false
+			return nil
+		}
+		m.compareLog = msg.Diff
+		return nil
+
+	case ActionResultMsg:
+		m.notice = msg.Message
+		m.noticeErr = msg.Error != nil
+		if msg.Error != nil {
+			m.notice = msg.Error.Error()
+		}
+		if msg.Error == nil && m.mode == ModeInput {
+			return m.closeInputForm()
+		}
+		if msg.Refresh && m.mode == ModeJobList {
+			return m.fetchJobs()
+		}
+		if msg.Refresh && m.mode == ModeBuildDetail && m.jobDetail != nil && m.buildDetail != nil {
+			return tea.Batch(m.fetchJobDetail(m.jobDetail.Name), m.fetchBuildDetail(m.jobDetail.Name, m.buildDetail.Number))
+		}
+		if msg.Refresh && m.jobDetail != nil {
+			return m.fetchJobDetail(m.jobDetail.Name)
+		}
 		return nil
 
 	case spinner.TickMsg:
@@ -180,19 +462,65 @@ func (m *BuildsModel) Update(msg tea.Msg) tea.Cmd {
 		}
 
 	case tea.KeyMsg:
+		// Handle pending confirmation
+		if m.confirm != nil {
+			action := m.confirm
+			m.confirm = nil
+			if msg.String() == "y" || msg.String() == "Y" {
+				return action.run
+			}
+			m.notice = "Cancelled"
+			m.noticeErr = false
+			return nil
+		}
+
+		if m.downloadPrompting {
+			return m.updateDownloadPrompt(msg)
+		}
+		if m.lintPrompting {
+			return m.updateLintPrompt(msg)
+		}
+		if m.copyPrompt != nil {
+			cmd, confirm, notice := m.updateCopyPrompt(m.client, msg)
+			m.confirm = confirm
+			if notice != "" {
+				m.notice, m.noticeErr = notice, true
+			}
+			return cmd
+		}
+		if m.renaming {
+			return m.updateRenamePrompt(msg)
+		}
+		if m.grepPrompting {
+			return m.updateGrepPrompt(msg)
+		}
+		if m.mode == ModeInput && m.inputForm != nil {
+			return m.updateInputForm(msg)
+		}
+
 		// Handle search mode
 		if m.searching {
 			switch msg.String() {
 			case "esc":
 				m.searching = false
 				m.searchInput.Blur()
-				m.filter = ""
+				if m.mode == ModeTests {
+					m.testFilter = ""
+				} else {
+					m.filter = ""
+				}
 				m.searchInput.SetValue("")
 				return nil
 			case "enter":
 				m.searching = false
 				m.searchInput.Blur()
-				m.filter = m.searchInput.Value()
+				if m.mode == ModeTests {
+					m.testFilter = m.searchInput.Value()
+					m.selectedTest = 0
+					m.testsScroll = 0
+				} else {
+					m.filter = m.searchInput.Value()
+				}
 				return nil
 			default:
 				var cmd tea.Cmd
@@ -214,7 +542,7 @@ func (m *BuildsModel) Update(msg tea.Msg) tea.Cmd {
 				m.logSearching = false
 				m.logSearchInput.Blur()
 				m.logFilter = m.logSearchInput.Value()
-				m.highlightLogSearch()
+				m.applyLogSearch()
 				return nil
 			default:
 				var cmd tea.Cmd
@@ -230,26 +558,74 @@ func (m *BuildsModel) Update(msg tea.Msg) tea.Cmd {
 				m.logSearchInput.Focus()
 				return textinput.Blink
 			}
+			if m.mode == ModeTests {
+				m.searchInput.SetValue(m.testFilter)
+			}
 			m.searching = true
 			m.searchInput.Focus()
 			return textinput.Blink
 
 		case "esc":
+			if m.dismissBatchOrMarks(m.listMarks()) {
+				return nil
+			}
+			// Esc leaves grep mode before the log
+			if (m.mode == ModeLogView || m.mode == ModeStageLogView) && m.grep.active {
+				m.toggleGrep()
+				return nil
+			}
 			switch m.mode {
 			case ModeLogView, ModeStageLogView:
+				if m.mode == ModeStageLogView && m.logFromSteps {
+					m.mode = ModeStageSteps
+				} else {
+					m.mode = ModeBuildDetail
+				}
+				m.logContent, m.logLines = "", nil
+				m.clearLogSearch()
+				m.logStepID = ""
+				m.logFromSteps = false
+			case ModeStageSteps:
 				m.mode = ModeBuildDetail
-				m.logContent = ""
-				m.logFilter = ""
-			case ModeBuildDetail:
+				m.stageSteps = nil
+			case ModeTimeline:
+				m.mode = ModeBuildDetail
+			case ModeJobAnalytics:
 				m.mode = ModeBuildList
-				m.buildDetail = nil
-				m.pipelineRun = nil
+			case ModeCompare:
+				m.mode = ModeBuildList
+				m.comparison = nil
+				m.compareLog = nil
+			case ModeTests:
+				m.mode = ModeBuildDetail
+				m.testReport = nil
+				m.searchInput.SetValue(m.filter)
+			case ModeTestDetail:
+				m.mode = m.testDetailFrom
+				m.testCase = nil
+			case ModeLint:
+				m.closeLint()
+			case ModeJobConfig:
+				m.discardJobConfigEdit()
+			case ModeFlakyTests:
+				m.mode = ModeBuildList
+				m.flakyHistory = nil
+				m.flakyTests = nil
+			case ModeArtifacts:
+				m.mode = ModeBuildDetail
+			case ModeArtifactPreview:
+				m.mode = ModeArtifacts
+				m.viewport.SetContent("")
+			case ModeBuildDetail:
+				m.closeBuildDetail()
 			case ModeBuildList:
 				m.mode = ModeJobList
 				m.jobDetail = nil
 				m.builds = nil
 				m.selectedBuild = 0
 				m.buildsScroll = 0
+				m.compareMark = 0
+				m.buildMarks.clear()
 			}
 			return nil
 
@@ -268,13 +644,36 @@ func (m *BuildsModel) Update(msg tea.Msg) tea.Cmd {
 				}
 			case ModeBuildDetail:
 				if m.pipelineRun != nil && len(m.pipelineRun.Stages) > 0 {
-					m.mode = ModeStageLogView
-					stage := m.pipelineRun.Stages[m.selectedStage]
-					return m.fetchStageLog(m.jobDetail.Name, m.buildDetail.Number, stage.ID)
+					return m.openStageSteps()
+				}
+			case ModeTests:
+				m.activateTest()
+			case ModeFlakyTests:
+				m.openFlakyFailure()
+			case ModeArtifacts:
+				return m.openArtifactPreview()
+			case ModeJobConfig:
+				m.requestSaveJobConfig()
+			case ModeStageSteps:
+				if step := m.selectedStageStep(); step != nil {
+					stage := m.selectedPipelineStage()
+					m.openStageLog(step.ID, true)
+					return m.fetchStepLog(m.jobDetail.Name, m.buildDetail.Number, stage.ID, step.ID)
 				}
 			}
 
 		case "l":
+			if m.mode == ModeFlakyTests {
+				m.moveFlakyBuild(1)
+				return nil
+			}
+			if m.mode == ModeStageSteps {
+				if stage := m.selectedPipelineStage(); stage != nil {
+					m.openStageLog("", true)
+					return m.fetchStageLog(m.jobDetail.Name, m.buildDetail.Number, stage.ID)
+				}
+				return nil
+			}
 			if (m.mode == ModeBuildList || m.mode == ModeBuildDetail) && m.jobDetail != nil {
 				buildNum := 0
 				if m.mode == ModeBuildList && len(m.builds) > 0 {
@@ -288,6 +687,228 @@ func (m *BuildsModel) Update(msg tea.Msg) tea.Cmd {
 				}
 			}
 
+		case "t":
+			if m.mode == ModeBuildDetail && m.pipelineRun != nil {
+				m.mode = ModeTimeline
+				m.timelineScroll = 0
+			}
+			return nil
+
+		case "T":
+			if m.mode == ModeBuildDetail {
+				return m.openTests()
+			}
+			return nil
+
+		case "F":
+			if m.mode == ModeBuildList {
+				return m.openFlakyTests()
+			}
+			if m.mode == ModeLogView || m.mode == ModeStageLogView {
+				m.toggleGrep()
+			}
+			return nil
+
+		case "i":
+			if m.mode == ModeBuildDetail {
+				return m.openInputForm()
+			}
+			return nil
+
+		case "R":
+			if m.mode == ModeBuildDetail {
+				return m.startReplay()
+			}
+			return nil
+
+		case "L":
+			if m.mode == ModeJobList || m.mode == ModeBuildList || m.mode == ModeLint {
+				return m.openLint()
+			}
+			return nil
+
+		case "e":
+			switch m.mode {
+			case ModeJobList, ModeBuildList:
+				return m.openJobConfig()
+			case ModeJobConfig:
+				return m.editJobConfig()
+			case ModeLint:
+				return m.editLintFile()
+			}
+			return nil
+
+		case "A":
+			if m.mode == ModeBuildDetail {
+				m.openArtifacts()
+			}
+			return nil
+
+		case "D":
+			if m.mode == ModeArtifacts && m.buildDetail != nil && len(m.buildDetail.Artifacts) > 0 {
+				return m.requestArtifactDownload(jenkins.ArchiveName)
+			}
+			if m.mode == ModeBuildDetail {
+				return m.editBuildDescription()
+			}
+			return nil
+
+		case "K":
+			if m.mode == ModeBuildDetail {
+				m.requestToggleKeep()
+			}
+			return nil
+
+		case "N":
+			if m.mode == ModeBuildDetail {
+				return m.openRenamePrompt()
+			}
+			if m.mode == ModeLogView || m.mode == ModeStageLogView {
+				m.moveLogMatch(-1)
+			}
+			return nil
+
+		case "n":
+			if m.mode == ModeLogView || m.mode == ModeStageLogView {
+				m.moveLogMatch(1)
+			}
+			return nil
+
+		case "y":
+			if m.mode == ModeArtifacts || m.mode == ModeArtifactPreview {
+				m.copyArtifactURL()
+			}
+			return nil
+
+		case "left", "h":
+			if m.mode == ModeFlakyTests {
+				m.moveFlakyBuild(-1)
+			}
+			return nil
+
+		case "right":
+			if m.mode == ModeFlakyTests {
+				m.moveFlakyBuild(1)
+			}
+			return nil
+
+		case "f":
+			if m.mode == ModeTests {
+				m.cycleTestStatus()
+			}
+			if m.mode == ModeLogView || m.mode == ModeStageLogView {
+				return m.openGrepPrompt()
+			}
+			return nil
+
+		case "v":
+			if m.mode == ModeLogView || m.mode == ModeStageLogView {
+				m.invertGrep()
+			}
+			return nil
+
+		case "+", "=":
+			if m.mode == ModeLogView || m.mode == ModeStageLogView {
+				m.changeGrepContext(1)
+			}
+			return nil
+
+		case "-":
+			if m.mode == ModeLogView || m.mode == ModeStageLogView {
+				m.changeGrepContext(-1)
+			}
+			return nil
+
+		case "a":
+			if m.mode == ModeBuildList && m.jobDetail != nil {
+				m.mode = ModeJobAnalytics
+			}
+			return nil
+
+		case "c":
+			if m.mode == ModeBuildList {
+				return m.toggleCompareMark()
+			}
+			if m.mode == ModeArtifacts || m.mode == ModeArtifactPreview {
+				m.cancelArtifactDownload()
+			}
+			return nil
+
+		case "C":
+			switch m.mode {
+			case ModeBuildList:
+				return m.compareWithLastSuccess()
+			case ModeJobList:
+				cmd, notice := m.openCopyPrompt(m.client, m.selectedListJob())
+				if notice != "" {
+					m.notice, m.noticeErr = notice, true
+				}
+				return cmd
+			}
+			return nil
+
+		case "E":
+			if m.mode == ModeJobList && m.jobMarks.count() > 0 {
+				m.requestBatch("E")
+				return nil
+			}
+			if job := m.selectedListJob(); job != nil {
+				m.confirm, m.notice = toggleJobAction(m.client, *job)
+				m.noticeErr = m.confirm == nil
+			}
+			return nil
+
+		case "X":
+			if m.mode == ModeBuildDetail {
+				m.requestDeleteBuild()
+				return nil
+			}
+			if job := m.selectedListJob(); job != nil {
+				m.confirm, m.notice = deleteJobAction(m.client, job.Name)
+				m.noticeErr = m.confirm == nil
+			}
+			return nil
+
+		case "d":
+			if m.mode == ModeCompare {
+				return m.toggleCompareLog()
+			}
+			if m.mode == ModeArtifacts || m.mode == ModeArtifactPreview {
+				return m.requestArtifactDownload(m.selectedArtifactPath())
+			}
+			return nil
+
+		case "b":
+			if m.mode == ModeJobList && m.jobMarks.count() > 0 {
+				m.requestBatch("b")
+				return nil
+			}
+			return m.requestTriggerBuild()
+
+		case "x":
+			if (m.mode == ModeJobList && m.jobMarks.count() > 0) || (m.mode == ModeBuildList && m.buildMarks.count() > 0) {
+				m.requestBatch("x")
+				return nil
+			}
+			return m.requestAbortBuild()
+
+		case " ":
+			m.toggleMark()
+			return nil
+
+		case "V":
+			m.markRange()
+			return nil
+
+		case "*":
+			switch m.mode {
+			case ModeJobList:
+				m.jobMarks.toggleAll(jobKeys(m.getFilteredJobs()))
+			case ModeBuildList:
+				m.buildMarks.toggleAll(buildKeys(m.builds))
+			}
+			return nil
+
 		case "s":
 			if m.mode == ModeLogView || m.mode == ModeStageLogView {
 				m.followLog = !m.followLog
@@ -300,14 +921,37 @@ func (m *BuildsModel) Update(msg tea.Msg) tea.Cmd {
 			switch m.mode {
 			case ModeJobList:
 				return m.LoadData()
-			case ModeBuildList:
+			case ModeBuildList, ModeJobAnalytics:
 				if m.jobDetail != nil {
 					return m.fetchJobDetail(m.jobDetail.Name)
 				}
+			case ModeStageSteps:
+				if stage := m.selectedPipelineStage(); stage != nil && m.jobDetail != nil && m.buildDetail != nil {
+					return m.fetchStageSteps(m.jobDetail.Name, m.buildDetail.Number, stage.ID)
+				}
+			case ModeTests:
+				if m.jobDetail != nil && m.buildDetail != nil {
+					return m.fetchTestReport(m.jobDetail.Name, m.buildDetail.Number)
+				}
+			case ModeFlakyTests:
+				if m.jobDetail != nil {
+					return m.fetchFlakyTests(m.jobDetail.Name)
+				}
+			case ModeArtifactPreview:
+				if m.jobDetail != nil && m.buildDetail != nil {
+					return m.fetchArtifactPreview(m.jobDetail.Name, m.buildDetail.Number, m.artifactPreview)
+				}
+			case ModeLint:
+				return m.runLint()
+			case ModeJobConfig:
+				return m.fetchJobConfig(m.configJob)
 			case ModeLogView, ModeStageLogView:
 				if m.jobDetail != nil && m.buildDetail != nil {
 					if m.mode == ModeLogView {
 						return m.fetchBuildLog(m.jobDetail.Name, m.buildDetail.Number)
+					} else if m.logStepID != "" {
+						stage := m.pipelineRun.Stages[m.selectedStage]
+						return m.fetchStepLog(m.jobDetail.Name, m.buildDetail.Number, stage.ID, m.logStepID)
 					} else {
 						stage := m.pipelineRun.Stages[m.selectedStage]
 						return m.fetchStageLog(m.jobDetail.Name, m.buildDetail.Number, stage.ID)
@@ -328,10 +972,28 @@ func (m *BuildsModel) Update(msg tea.Msg) tea.Cmd {
 				if len(m.builds) > 0 && m.selectedBuild < len(m.builds) {
 					url = m.builds[m.selectedBuild].URL
 				}
-			case ModeBuildDetail:
+			case ModeBuildDetail, ModeStageSteps, ModeTimeline:
 				if m.buildDetail != nil {
 					url = m.buildDetail.URL
 				}
+			case ModeTests:
+				if m.buildDetail != nil {
+					url = m.buildDetail.URL + "testReport/"
+				}
+			case ModeTestDetail:
+				if m.jobDetail != nil {
+					url = fmt.Sprintf("%s%d/testReport/", m.jobDetail.URL, m.testCaseBuild)
+				}
+			case ModeFlakyTests:
+				if m.jobDetail != nil && m.selectedFlaky < len(m.flakyTests) {
+					if failed := m.flakyTests[m.selectedFlaky].FailedBuilds(); m.flakyBuild < len(failed) {
+						url = fmt.Sprintf("%s%d/testReport/", m.jobDetail.URL, failed[m.flakyBuild])
+					}
+				}
+			case ModeArtifacts, ModeArtifactPreview:
+				if path := m.selectedArtifactPath(); path != "" && m.jobDetail != nil && m.buildDetail != nil {
+					url = m.client.ArtifactURL(m.jobDetail.Name, m.buildDetail.Number, path)
+				}
 			case ModeLogView, ModeStageLogView:
 				if m.buildDetail != nil {
 					url = m.buildDetail.URL + "console"
@@ -350,10 +1012,38 @@ func (m *BuildsModel) Update(msg tea.Msg) tea.Cmd {
 
 		case "g":
 			// Go to top
+			switch m.mode {
+			case ModeCompare:
+				m.compareScroll = 0
+				return nil
+			case ModeTests:
+				m.selectedTest = 0
+				m.testsScroll = 0
+				return nil
+			case ModeFlakyTests:
+				m.moveFlakySelection(-len(m.flakyTests))
+				return nil
+			case ModeTestDetail:
+				m.testDetailScroll = 0
+				return nil
+			case ModeLint:
+				m.lintScroll = 0
+				return nil
+			case ModeArtifacts:
+				m.moveArtifactSelection(-m.selectedArtifact)
+				return nil
+			case ModeArtifactPreview, ModeJobConfig:
+				m.viewport.GotoTop()
+				return nil
+			}
 			m.selectedJob = 0
 			m.selectedBuild = 0
 			m.jobsScroll = 0
 			m.buildsScroll = 0
+			if m.mode == ModeStageSteps {
+				m.selectedStep = 0
+				m.stepsScroll = 0
+			}
 			if m.mode == ModeLogView || m.mode == ModeStageLogView {
 				m.viewport.GotoTop()
 			}
@@ -370,19 +1060,38 @@ func (m *BuildsModel) Update(msg tea.Msg) tea.Cmd {
 				if len(m.builds) > 0 {
 					m.selectedBuild = len(m.builds) - 1
 				}
-			case ModeLogView, ModeStageLogView:
+			case ModeStageSteps:
+				if len(m.stageSteps) > 0 {
+					m.selectedStep = len(m.stageSteps) - 1
+					m.ensureStepVisible()
+				}
+			case ModeCompare:
+				m.scrollCompare(len(m.compareLines(m.width - 12)))
+			case ModeTests:
+				m.moveTestSelection(len(m.testRows()))
+			case ModeFlakyTests:
+				m.moveFlakySelection(len(m.flakyTests))
+			case ModeTestDetail:
+				m.scrollTestDetail(len(m.testDetailLines(m.width - 12)))
+			case ModeLint:
+				m.scrollLint(len(m.lintLines(m.width - 12)))
+			case ModeArtifacts:
+				if m.buildDetail != nil {
+					m.moveArtifactSelection(len(m.buildDetail.Artifacts))
+				}
+			case ModeLogView, ModeStageLogView, ModeArtifactPreview, ModeJobConfig:
 				m.viewport.GotoBottom()
 			}
 
 		case "pgdown", "ctrl+f":
-			if m.mode == ModeLogView || m.mode == ModeStageLogView {
+			if m.mode == ModeLogView || m.mode == ModeStageLogView || m.mode == ModeArtifactPreview || m.mode == ModeJobConfig {
 				m.viewport.ViewDown()
 			} else {
 				m.pageDown()
 			}
 
 		case "pgup", "ctrl+b":
-			if m.mode == ModeLogView || m.mode == ModeStageLogView {
+			if m.mode == ModeLogView || m.mode == ModeStageLogView || m.mode == ModeArtifactPreview || m.mode == ModeJobConfig {
 				m.viewport.ViewUp()
 			} else {
 				m.pageUp()
@@ -406,6 +1115,30 @@ func (m *BuildsModel) View() string {
 		return m.viewBuildList()
 	case ModeBuildDetail:
 		return m.viewBuildDetail()
+	case ModeStageSteps:
+		return m.viewStageSteps()
+	case ModeTimeline:
+		return m.viewTimeline()
+	case ModeJobAnalytics:
+		return m.viewAnalytics()
+	case ModeCompare:
+		return m.viewCompare()
+	case ModeTests:
+		return m.viewTests()
+	case ModeTestDetail:
+		return m.viewTestDetail()
+	case ModeFlakyTests:
+		return m.viewFlakyTests()
+	case ModeArtifacts:
+		return m.viewArtifacts()
+	case ModeArtifactPreview:
+		return m.viewArtifactPreview()
+	case ModeInput:
+		return m.viewInputForm()
+	case ModeLint:
+		return m.viewLint()
+	case ModeJobConfig:
+		return m.viewJobConfig()
 	case ModeLogView, ModeStageLogView:
 		return m.viewLog()
 	}
@@ -433,12 +1166,14 @@ func (m *BuildsModel) viewJobList() string {
 	header := lipgloss.JoinHorizontal(lipgloss.Left,
 		breadcrumb,
 		strings.Repeat(" ", maxInt(0, m.width-lipgloss.Width(breadcrumb)-20)),
-		theme.MutedStyle.Render(fmt.Sprintf("%d jobs", len(m.jobs))),
+		theme.MutedStyle.Render(countLabel(len(m.jobs), "jobs", m.jobMarks)),
 	)
 
-	// Search bar
+	// Search bar, or the name of a job copy
 	var searchBar string
-	if m.searching || m.filter != "" {
+	if m.copyPrompt != nil {
+		searchBar = m.copyPrompt.view(m.width)
+	} else if m.searching || m.filter != "" {
 		searchBar = theme.SearchBarStyle.Width(m.width - 4).Render(m.searchInput.View())
 	}
 
@@ -448,11 +1183,20 @@ func (m *BuildsModel) viewJobList() string {
 		"", "Job Name", "Last Build", "Result", "Health", "Updated")
 	columnHeader := headerStyle.Width(m.width - 6).Render(colHeaders)
 
+	// Progress of a batch action
+	batchPanel := ""
+	if m.batch != nil {
+		batchPanel = m.batch.render(m.width - 4)
+	}
+
 	// Jobs list
 	filtered := m.getFilteredJobs()
 	listHeight := contentHeight - 6
 	if searchBar != "" {
-		listHeight -= 3
+		listHeight -= lipgloss.Height(searchBar)
+	}
+	if batchPanel != "" {
+		listHeight -= lipgloss.Height(batchPanel)
 	}
 
 	var rows []string
@@ -462,7 +1206,7 @@ func (m *BuildsModel) viewJobList() string {
 	for i := visibleStart; i < visibleEnd; i++ {
 		job := filtered[i]
 		isSelected := i == m.selectedJob
-		row := m.renderJobRow(job, isSelected, m.width-6)
+		row := m.renderJobRow(job, isSelected, m.jobMarks.has(job.Name), m.width-6)
 		rows = append(rows, row)
 	}
 
@@ -482,7 +1226,11 @@ func (m *BuildsModel) viewJobList() string {
 	if searchBar != "" {
 		sections = append(sections, searchBar)
 	}
-	sections = append(sections, columnHeader, list, scrollInfo, shortcuts)
+	sections = append(sections, columnHeader, list, scrollInfo)
+	if batchPanel != "" {
+		sections = append(sections, batchPanel)
+	}
+	sections = append(sections, shortcuts)
 
 	content := lipgloss.JoinVertical(lipgloss.Left, sections...)
 
@@ -506,7 +1254,7 @@ func (m *BuildsModel) viewBuildList() string {
 	header := lipgloss.JoinHorizontal(lipgloss.Left,
 		breadcrumb,
 		strings.Repeat(" ", maxInt(0, m.width-lipgloss.Width(breadcrumb)-25)),
-		theme.MutedStyle.Render(fmt.Sprintf("%d builds", len(m.builds))),
+		theme.MutedStyle.Render(countLabel(len(m.builds), "builds", m.buildMarks)),
 	)
 
 	// Extract stage names from first build that has stages
@@ -518,8 +1266,17 @@ func (m *BuildsModel) viewBuildList() string {
 	// Build column headers (potentially multi-line for stages)
 	columnHeader := m.renderBuildTableHeader(cols, stageNames)
 
+	// Progress of a batch action
+	batchPanel := ""
+	if m.batch != nil {
+		batchPanel = m.batch.render(m.width - 4)
+	}
+
 	// Builds list
 	listHeight := contentHeight - 6
+	if batchPanel != "" {
+		listHeight -= lipgloss.Height(batchPanel)
+	}
 
 	var rows []string
 	visibleStart := m.buildsScroll
@@ -528,7 +1285,7 @@ func (m *BuildsModel) viewBuildList() string {
 	for i := visibleStart; i < visibleEnd; i++ {
 		build := m.builds[i]
 		isSelected := i == m.selectedBuild
-		row := m.renderBuildTableRow(build, isSelected, cols, stageNames)
+		row := m.renderBuildTableRow(build, isSelected, m.buildMarks.has(strconv.Itoa(build.Number)), cols, stageNames)
 		rows = append(rows, row)
 	}
 
@@ -544,7 +1301,11 @@ func (m *BuildsModel) viewBuildList() string {
 	shortcuts := m.renderShortcuts()
 
 	// Sections with NO spacing
-	sections := []string{header, columnHeader, list, scrollInfo, shortcuts}
+	sections := []string{header, columnHeader, list, scrollInfo}
+	if batchPanel != "" {
+		sections = append(sections, batchPanel)
+	}
+	sections = append(sections, shortcuts)
 
 	content := lipgloss.JoinVertical(lipgloss.Left, sections...)
 
@@ -645,7 +1406,7 @@ func (m *BuildsModel) renderBuildTableHeader(cols []BuildColumn, stageNames []st
 }
 
 // renderBuildTableRow renders a single build row with proper column alignment
-func (m *BuildsModel) renderBuildTableRow(build models.BuildRef, selected bool, cols []BuildColumn, stageNames []string) string {
+func (m *BuildsModel) renderBuildTableRow(build models.BuildRef, selected, marked bool, cols []BuildColumn, stageNames []string) string {
 	style := lipgloss.NewStyle().Width(m.width - 6)
 	if selected {
 		style = style.Background(theme.Primary).Foreground(theme.Background).Bold(true)
@@ -666,11 +1427,20 @@ func (m *BuildsModel) renderBuildTableRow(build models.BuildRef, selected bool,
 		switch i {
 		case 0: // Icon
 			icon := theme.BuildResultIcon(result)
+			// Marked builds show the mark before the icon
+			if marked {
+				cellContent = renderMark(true, selected) + icon + " "
+				break
+			}
 			// Center icon in cell
 			cellContent = m.centerInWidth(icon, col.Width)
 
 		case 1: // Build number
-			cellContent = fmt.Sprintf("%-*s", col.Width, fmt.Sprintf("#%d", build.Number))
+			label := fmt.Sprintf("#%d", build.Number)
+			if build.Number == m.compareMark {
+				label += " ⇄"
+			}
+			cellContent = fmt.Sprintf("%-*s", col.Width, label)
 
 		case 2: // Result
 			if selected {
@@ -783,6 +1553,12 @@ func (m *BuildsModel) viewBuildDetail() string {
 	status := b.StatusText()
 	statusBadge := theme.StatusBadge(status)
 	infoRows = append(infoRows, "  Status:      "+statusBadge)
+	if b.DisplayName != "" && b.DisplayName != fmt.Sprintf("#%d", b.Number) {
+		infoRows = append(infoRows, components.InfoRowWithIcon(theme.IconBuild, "Name", b.DisplayName))
+	}
+	if b.KeepLog {
+		infoRows = append(infoRows, components.InfoRowWithIcon(theme.IconLock, "Retention", "Kept forever"))
+	}
 
 	// Timestamp
 	ts := time.UnixMilli(b.Timestamp)
@@ -804,6 +1580,20 @@ func (m *BuildsModel) viewBuildDetail() string {
 		infoRows = append(infoRows, "  Progress:    "+progressBar.Render())
 	}
 
+	// Description, limited to its first lines
+	if description := strings.TrimSpace(b.Description); description != "" {
+		infoRows = append(infoRows, "")
+		infoRows = append(infoRows, theme.SectionTitleStyle.Render(theme.IconFile+" Description"))
+		lines := strings.Split(strings.ReplaceAll(description, "\r\n", "\n"), "\n")
+		for i, line := range lines {
+			if i == 3 {
+				infoRows = append(infoRows, "    "+theme.MutedStyle.Render(fmt.Sprintf("(%d more lines)", len(lines)-3)))
+				break
+			}
+			infoRows = append(infoRows, "    "+theme.MutedStyle.Render(truncate(line, 80)))
+		}
+	}
+
 	// Trigger causes
 	causes := b.GetCauses()
 	if len(causes) > 0 {
@@ -892,22 +1682,9 @@ func (m *BuildsModel) viewBuildDetail() string {
 	var stagesRows []string
 	if m.pipelineRun != nil && len(m.pipelineRun.Stages) > 0 {
 		stagesRows = append(stagesRows, theme.SectionTitleStyle.Render(theme.IconBuild+" Pipeline Stages"))
+		stagesRows = append(stagesRows, m.renderStageGraph(m.width-12), "")
 		for i, stage := range m.pipelineRun.Stages {
-			status := components.StageStatusPending
-			switch stage.Status {
-			case "SUCCESS":
-				status = components.StageStatusSuccess
-			case "FAILED", "FAILURE":
-				status = components.StageStatusFailure
-			case "RUNNING", "IN_PROGRESS":
-				status = components.StageStatusRunning
-			case "UNSTABLE":
-				status = components.StageStatusUnstable
-			case "ABORTED":
-				status = components.StageStatusAborted
-			case "SKIPPED", "NOT_BUILT":
-				status = components.StageStatusSkipped
-			}
+			status := stageStatus(stage.Status)
 
 			prefix := "  "
 			if i == m.selectedStage {
@@ -925,7 +1702,11 @@ func (m *BuildsModel) viewBuildDetail() string {
 			}
 
 			dur := formatDuration(time.Duration(stage.DurationMillis) * time.Millisecond)
-			row := fmt.Sprintf("%s%s %-30s %s", prefix, statusIcon, truncate(stage.Name, 30), theme.MutedStyle.Render(dur))
+			name := truncate(stage.Name, 30)
+			if stage.ParentID != "" {
+				name = "└ " + truncate(stage.Name, 28)
+			}
+			row := fmt.Sprintf("%s%s %-30s %s", prefix, statusIcon, name, theme.MutedStyle.Render(dur))
 
 			if i == m.selectedStage {
 				row = theme.PrimaryStyle.Bold(true).Render(row)
@@ -945,11 +1726,19 @@ func (m *BuildsModel) viewBuildDetail() string {
 	}
 
 	// Combine all sections
-	sections := []string{breadcrumb, infoPanel, middleContent}
+	sections := []string{breadcrumb}
+	if len(m.pendingInputs) > 0 {
+		sections = append(sections, renderInputBanner(m.pendingInputs, m.width-6))
+	}
+	sections = append(sections, infoPanel, middleContent)
 	if stagesPanel != "" {
 		sections = append(sections, stagesPanel)
 	}
 
+	if m.renaming {
+		sections = append(sections, m.renderRenamePrompt())
+	}
+
 	// Shortcuts
 	shortcuts := m.renderShortcuts()
 	sections = append(sections, shortcuts)
@@ -963,6 +1752,46 @@ func (m *BuildsModel) viewBuildDetail() string {
 		Render(content)
 }
 
+// renderStageGraph renders the pipeline as a graph with parallel branches
+func (m *BuildsModel) renderStageGraph(width int) string {
+	graph := components.NewStageGraph(width)
+	for _, column := range models.StageColumns(m.pipelineRun.Stages) {
+		stages := make([]components.Stage, len(column))
+		for i, stage := range column {
+			stages[i] = components.Stage{
+				ID:       stage.ID,
+				Name:     stage.Name,
+				Status:   stageStatus(stage.Status),
+				Duration: time.Duration(stage.DurationMillis) * time.Millisecond,
+			}
+		}
+		graph.AddColumn(stages...)
+	}
+	if m.selectedStage < len(m.pipelineRun.Stages) {
+		graph.SetSelected(m.pipelineRun.Stages[m.selectedStage].ID)
+	}
+	return graph.Render()
+}
+
+// stageStatus maps wfapi and Blue Ocean stage statuses to display statuses
+func stageStatus(status string) components.StageStatus {
+	switch status {
+	case "SUCCESS":
+		return components.StageStatusSuccess
+	case "FAILED", "FAILURE":
+		return components.StageStatusFailure
+	case "RUNNING", "IN_PROGRESS":
+		return components.StageStatusRunning
+	case "UNSTABLE":
+		return components.StageStatusUnstable
+	case "ABORTED":
+		return components.StageStatusAborted
+	case "SKIPPED", "NOT_BUILT":
+		return components.StageStatusSkipped
+	}
+	return components.StageStatusPending
+}
+
 func (m *BuildsModel) viewLog() string {
 	// Header
 	buildNum := 0
@@ -977,6 +1806,9 @@ func (m *BuildsModel) viewLog() string {
 	title := "Log"
 	if m.mode == ModeStageLogView && m.pipelineRun != nil {
 		title = "Stage: " + m.pipelineRun.Stages[m.selectedStage].Name
+		if step := m.logStep(); step != nil {
+			title = "Step: " + step.Name
+		}
 	}
 
 	breadcrumb := components.NewBreadcrumb("Builds", jobName, fmt.Sprintf("#%d", buildNum), title).Render()
@@ -990,16 +1822,20 @@ func (m *BuildsModel) viewLog() string {
 		statusItems = append(statusItems, theme.RunningStyle.Render("[FOLLOW ON]"))
 	}
 
-	if m.logFilter != "" {
-		matches := strings.Count(strings.ToLower(m.logContent), strings.ToLower(m.logFilter))
-		statusItems = append(statusItems, theme.AccentStyle.Render(fmt.Sprintf("Found: %d matches", matches)))
+	if status := m.grepStatus(); status != "" {
+		statusItems = append(statusItems, theme.InfoStyle.Render(status))
+	}
+	if status := m.logSearchStatus(); status != "" {
+		statusItems = append(statusItems, theme.AccentStyle.Render(status))
 	}
 
 	statusBar := strings.Join(statusItems, " │ ")
 
 	// Search bar (if searching)
 	var searchBar string
-	if m.logSearching || m.logFilter != "" {
+	if m.grepPrompting {
+		searchBar = theme.SearchBarStyle.Width(m.width - 4).Render(m.grepInput.View())
+	} else if m.logSearching || m.logFilter != "" {
 		searchBar = theme.SearchBarStyle.Width(m.width - 4).Render(m.logSearchInput.View())
 	}
 
@@ -1035,7 +1871,7 @@ func (m *BuildsModel) viewLog() string {
 		Render(content)
 }
 
-func (m *BuildsModel) renderJobRow(job models.Job, selected bool, width int) string {
+func (m *BuildsModel) renderJobRow(job models.Job, selected, marked bool, width int) string {
 	style := lipgloss.NewStyle().Width(width)
 	if selected {
 		style = style.Background(theme.Primary).Foreground(theme.Background).Bold(true)
@@ -1044,8 +1880,8 @@ func (m *BuildsModel) renderJobRow(job models.Job, selected bool, width int) str
 	// Status icon
 	statusIcon := theme.BuildStatusIcon(job.Color)
 
-	// Job name
-	name := truncate(job.Name, 33)
+	// Job name, with the characters matching the filter highlighted
+	name := renderJobName(job.Name, m.filter, 33, 35, selected)
 
 	// Last build
 	lastBuild := "-"
@@ -1072,8 +1908,8 @@ func (m *BuildsModel) renderJobRow(job models.Job, selected bool, width int) str
 		resultStr = theme.BuildResultStyle(result).Render(result)
 	}
 
-	row := fmt.Sprintf("  %s %-35s %-12s %-10s %-8s %-12s",
-		statusIcon, name, lastBuild, resultStr, health, timeAgo)
+	row := fmt.Sprintf("%s %s %s %-12s %-10s %-8s %-12s",
+		renderMark(marked, selected), statusIcon, name, lastBuild, resultStr, health, timeAgo)
 
 	return style.Render(row)
 }
@@ -1081,27 +1917,187 @@ func (m *BuildsModel) renderJobRow(job models.Job, selected bool, width int) str
 func (m *BuildsModel) renderShortcuts() string {
 	bar := components.NewShortkeyBar(m.width)
 
+	if m.confirm != nil {
+		return renderConfirmPrompt(m.confirm.prompt, m.width)
+	}
+
+	jobName := m.selectedJobName()
+	canBuild := canPerform(m.client, jenkins.PermBuild, jobName, 0)
+	canCancel := canPerform(m.client, jenkins.PermCancel, jobName, 0)
+	canConfigure := canPerform(m.client, jenkins.PermConfigure, jobName, 0)
+
 	switch m.mode {
 	case ModeJobList:
+		if m.copyPrompt != nil {
+			bar.Add("Enter", "Copy").
+				Add("Esc", "Cancel")
+			break
+		}
+		if m.jobMarks.count() > 0 {
+			marked, _ := shownMarkedJobs(m.jobs, m.getFilteredJobs(), m.jobMarks)
+			bar.Add("Space/V/*", "Mark/Range/All").
+				AddIf(canPerformAny(m.client, jenkins.PermBuild, marked), "b", "Build marked").
+				AddIf(canPerformAny(m.client, jenkins.PermCancel, marked), "x", "Abort marked").
+				AddIf(canPerformAny(m.client, jenkins.PermConfigure, marked), "E", "Enable/Disable marked").
+				Add("Esc", "Clear marks")
+			break
+		}
 		bar.Add("/", "Search").
 			Add("Enter", "View builds").
+			Add("Space", "Mark").
+			AddIf(canBuild, "b", "Build").
+			Add("e", "Config").
+			AddIf(canConfigure, "E", "Enable/Disable").
+			AddIf(canPerform(m.client, jenkins.PermCreate, jobFolder(jobName), 0), "C", "Copy").
+			AddIf(canPerform(m.client, jenkins.PermDelete, jobName, 0), "X", "Delete").
+			Add("L", "Lint Jenkinsfile").
 			Add("o", "Open URL").
 			Add("r", "Refresh").
 			Add("g/G", "Top/Bottom")
 	case ModeBuildList:
+		if m.buildMarks.count() > 0 {
+			bar.Add("Space/V/*", "Mark/Range/All").
+				AddIf(canCancel, "x", "Abort marked").
+				Add("Esc", "Clear marks")
+			break
+		}
 		bar.Add("Enter", "Details").
+			Add("Space", "Mark").
 			Add("l", "View log").
+			Add("a", "Analytics").
+			Add("c/C", "Compare/vs green").
+			Add("F", "Flaky tests").
+			Add("e", "Config").
+			AddIf(canBuild, "b", "Build").
+			AddIf(canCancel, "x", "Abort").
 			Add("o", "Open URL").
 			Add("Esc", "Back").
 			Add("g/G", "Top/Bottom")
 	case ModeBuildDetail:
-		bar.Add("Enter", "Stage log").
+		if m.renaming {
+			bar.Add("Enter", "Rename").
+				Add("Esc", "Cancel")
+			break
+		}
+		buildNum := 0
+		keep := "Keep forever"
+		if m.buildDetail != nil {
+			buildNum = m.buildDetail.Number
+			if m.buildDetail.KeepLog {
+				keep = "Release"
+			}
+		}
+		canUpdate := canPerform(m.client, jenkins.PermUpdateBuild, jobName, buildNum)
+		bar.AddIf(len(m.pendingInputs) > 0 && canBuild, "i", "Input").
+			Add("Enter", "Stage steps").
 			Add("l", "View full log").
+			Add("t", "Timeline").
+			Add("T", "Tests").
+			Add("A", "Artifacts").
+			AddIf(canBuild, "b", "Build").
+			AddIf(canPerform(m.client, jenkins.PermReplay, jobName, buildNum), "R", "Replay").
+			AddIf(canCancel, "x", "Abort").
+			AddIf(canUpdate, "K", keep).
+			AddIf(canUpdate, "N", "Rename").
+			AddIf(canUpdate, "D", "Description").
+			AddIf(canPerform(m.client, jenkins.PermDeleteBuild, jobName, buildNum), "X", "Delete").
+			Add("o", "Open URL").
+			Add("Esc", "Back")
+	case ModeTimeline:
+		bar.Add("j/k", "Scroll").
+			Add("o", "Open URL").
+			Add("Esc", "Back")
+	case ModeJobAnalytics:
+		bar.Add("r", "Refresh").
+			Add("Esc", "Back")
+	case ModeTests:
+		bar.Add("Enter", "Expand/Details").
+			Add("/", "Filter").
+			Add("f", "Status: "+m.testStatus.String()).
+			Add("o", "Open URL").
+			Add("r", "Refresh").
+			Add("Esc", "Back")
+	case ModeFlakyTests:
+		bar.Add("j/k", "Select test").
+			Add("←/→", "Failed build").
+			Add("Enter", "Test detail").
+			Add("o", "Open URL").
+			Add("r", "Refresh").
+			Add("Esc", "Back")
+	case ModeTestDetail:
+		bar.Add("j/k", "Scroll").
+			Add("g/G", "Top/Bottom").
+			Add("o", "Open URL").
+			Add("Esc", "Back")
+	case ModeArtifacts:
+		bar.Add("Enter", "Preview").
+			Add("d", "Download").
+			Add("D", "Download all")
+		if m.download != nil {
+			bar.Add("c", "Cancel download")
+		}
+		bar.Add("y", "Copy URL").
+			Add("o", "Open URL").
+			Add("Esc", "Back")
+	case ModeJobConfig:
+		if m.configEdited != "" {
+			bar.AddIf(canConfigure, "Enter", "Save").
+				AddIf(canConfigure, "e", "Edit again").
+				Add("j/k", "Scroll").
+				Add("Esc", "Discard")
+			break
+		}
+		bar.AddIf(canConfigure, "e", "Edit").
+			Add("j/k", "Scroll").
+			Add("r", "Reload").
+			Add("Esc", "Back")
+	case ModeLint:
+		if m.lintPrompting {
+			bar.Add("Enter", "Lint").
+				Add("Esc", "Cancel")
+			break
+		}
+		bar.Add("r", "Lint again").
+			Add("e", "Edit").
+			Add("L", "Other file").
+			Add("j/k", "Scroll").
+			Add("Esc", "Back")
+	case ModeInput:
+		bar.Add("↑/↓", "Field").
+			Add("Space/←/→", "Toggle/Choose").
+			Add("Enter", "Next/Submit").
+			Add("Esc", "Back")
+	case ModeArtifactPreview:
+		bar.Add("j/k", "Scroll").
+			Add("d", "Download").
+			Add("y", "Copy URL").
 			Add("o", "Open URL").
+			Add("r", "Reload").
+			Add("Esc", "Back")
+	case ModeCompare:
+		bar.Add("j/k", "Scroll").
+			AddIf(!m.compareShowLog, "d", "Log diff").
+			AddIf(m.compareShowLog, "d", "Summary").
+			Add("g/G", "Top/Bottom").
+			Add("Esc", "Back")
+	case ModeStageSteps:
+		bar.Add("Enter", "Step log").
+			Add("l", "Stage log").
+			Add("o", "Open URL").
+			Add("r", "Refresh").
 			Add("Esc", "Back")
 	case ModeLogView, ModeStageLogView:
-		bar.Add("/", "Search").
-			Add("s", "Toggle follow").
+		bar.Add("/", "Search")
+		if len(m.logMatches) > 0 {
+			bar.Add("n/N", "Next/prev match")
+		}
+		bar.Add("f", "Grep")
+		if m.grep.re != nil {
+			bar.Add("F", "Full/grep").
+				Add("v", "Invert").
+				Add("+/-", "Context")
+		}
+		bar.Add("s", "Toggle follow").
 			Add("o", "Open URL").
 			Add("g/G", "Top/Bottom").
 			Add("Esc", "Back")
@@ -1113,77 +2109,35 @@ func (m *BuildsModel) renderShortcuts() string {
 		lastUpdate = theme.MutedStyle.Render(fmt.Sprintf(" │ Updated: %s", m.lastUpdate.Format("15:04:05")))
 	}
 
-	return bar.Render() + lastUpdate
+	return bar.Render() + lastUpdate + renderNotice(m.notice, m.noticeErr)
 }
 
-func (m *BuildsModel) formatLogContent(content string) string {
-	// Split lines and format with compact line numbers
-	lines := strings.Split(content, "\n")
+func (m *BuildsModel) formatLogContent() string {
+	// Format the parsed lines with compact line numbers
+	lines := m.logLines
 	var formatted []string
 
 	// Calculate max line number width
 	maxLineNum := len(lines)
 	numWidth := len(fmt.Sprintf("%d", maxLineNum))
 
+	spans := m.logSpans()
+	if m.grep.active {
+		return m.formatGrepLines(lines, numWidth, spans)
+	}
 	for i, line := range lines {
 		// Compact line number format
 		lineNum := theme.MutedStyle.Render(fmt.Sprintf("%*d│ ", numWidth, i+1))
 
-		// Colorize based on content
-		styledLine := line
-		lineLower := strings.ToLower(line)
-
-		if strings.Contains(lineLower, "error") || strings.Contains(lineLower, "failed") ||
-			strings.Contains(lineLower, "exception") {
-			styledLine = theme.ErrorStyle.Render(line)
-		} else if strings.Contains(lineLower, "warning") || strings.Contains(lineLower, "warn") {
-			styledLine = theme.WarningStyle.Render(line)
-		} else if strings.Contains(lineLower, "success") || strings.Contains(lineLower, "passed") {
-			styledLine = theme.SuccessStyle.Render(line)
-		} else if strings.HasPrefix(line, "[Pipeline]") || strings.HasPrefix(line, "[INFO]") {
-			styledLine = theme.InfoStyle.Render(line)
-		}
-
 		// NO añadir más saltos de línea, solo concatenar
-		formatted = append(formatted, lineNum+styledLine)
+		formatted = append(formatted, lineNum+renderLogLine(line, spans[i]))
 	}
 
 	return strings.Join(formatted, "\n")
 }
 
-func (m *BuildsModel) highlightLogSearch() {
-	if m.logFilter == "" {
-		m.viewport.SetContent(m.formatLogContent(m.logContent))
-		return
-	}
-
-	// First format, then highlight search term
-	formatted := m.formatLogContent(m.logContent)
-
-	// Simple case-insensitive highlight
-	highlighted := strings.ReplaceAll(formatted, m.logFilter,
-		theme.AccentStyle.Reverse(true).Render(m.logFilter))
-	highlighted = strings.ReplaceAll(highlighted, strings.ToLower(m.logFilter),
-		theme.AccentStyle.Reverse(true).Render(strings.ToLower(m.logFilter)))
-	highlighted = strings.ReplaceAll(highlighted, strings.ToUpper(m.logFilter),
-		theme.AccentStyle.Reverse(true).Render(strings.ToUpper(m.logFilter)))
-
-	m.viewport.SetContent(highlighted)
-}
-
 func (m *BuildsModel) getFilteredJobs() []models.Job {
-	if m.filter == "" {
-		return m.jobs
-	}
-
-	var filtered []models.Job
-	filterLower := strings.ToLower(m.filter)
-	for _, job := range m.jobs {
-		if strings.Contains(strings.ToLower(job.Name), filterLower) {
-			filtered = append(filtered, job)
-		}
-	}
-	return filtered
+	return filterJobs(m.jobs, m.filter)
 }
 
 func (m *BuildsModel) navigateDown() {
@@ -1211,6 +2165,29 @@ func (m *BuildsModel) navigateDown() {
 		if m.pipelineRun != nil && m.selectedStage < len(m.pipelineRun.Stages)-1 {
 			m.selectedStage++
 		}
+	case ModeStageSteps:
+		if m.selectedStep < len(m.stageSteps)-1 {
+			m.selectedStep++
+			m.ensureStepVisible()
+		}
+	case ModeTimeline:
+		if m.pipelineRun != nil && m.timelineScroll < len(m.pipelineRun.Stages)-m.timelineRows() {
+			m.timelineScroll++
+		}
+	case ModeCompare:
+		m.scrollCompare(1)
+	case ModeTests:
+		m.moveTestSelection(1)
+	case ModeFlakyTests:
+		m.moveFlakySelection(1)
+	case ModeTestDetail:
+		m.scrollTestDetail(1)
+	case ModeLint:
+		m.scrollLint(1)
+	case ModeArtifacts:
+		m.moveArtifactSelection(1)
+	case ModeArtifactPreview, ModeJobConfig:
+		m.viewport.LineDown(1)
 	}
 }
 
@@ -1236,20 +2213,113 @@ func (m *BuildsModel) navigateUp() {
 		if m.selectedStage > 0 {
 			m.selectedStage--
 		}
+	case ModeStageSteps:
+		if m.selectedStep > 0 {
+			m.selectedStep--
+			m.ensureStepVisible()
+		}
+	case ModeTimeline:
+		if m.timelineScroll > 0 {
+			m.timelineScroll--
+		}
+	case ModeCompare:
+		m.scrollCompare(-1)
+	case ModeTests:
+		m.moveTestSelection(-1)
+	case ModeFlakyTests:
+		m.moveFlakySelection(-1)
+	case ModeTestDetail:
+		m.scrollTestDetail(-1)
+	case ModeLint:
+		m.scrollLint(-1)
+	case ModeArtifacts:
+		m.moveArtifactSelection(-1)
+	case ModeArtifactPreview, ModeJobConfig:
+		m.viewport.LineUp(1)
 	}
 }
 
-func (m *BuildsModel) triggerBuild(jobName string) tea.Cmd {
-	return func() tea.Msg {
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-		defer cancel()
-		err := m.client.TriggerBuild(ctx, jobName)
-		if err != nil {
-			return BuildsDataMsg{Error: err}
+// selectedJobName returns the job the current mode is focused on
+func (m *BuildsModel) selectedJobName() string {
+	if m.mode == ModeJobList {
+		filtered := m.getFilteredJobs()
+		if m.selectedJob < len(filtered) {
+			return filtered[m.selectedJob].Name
+		}
+		return ""
+	}
+	if m.jobDetail != nil {
+		return m.jobDetail.Name
+	}
+	return ""
+}
+
+// selectedRunningBuild returns the build number that can be aborted, or 0
+func (m *BuildsModel) selectedRunningBuild() int {
+	switch m.mode {
+	case ModeBuildList:
+		if m.selectedBuild < len(m.builds) {
+			b := m.builds[m.selectedBuild]
+			if b.Building || b.Result == "" {
+				return b.Number
+			}
+		}
+	case ModeBuildDetail, ModeStageSteps, ModeTimeline, ModeLogView, ModeStageLogView:
+		if m.buildDetail != nil && m.buildDetail.Building {
+			return m.buildDetail.Number
 		}
-		// Return a message that triggers a refresh
-		return BuildsDataMsg{Error: nil} // We could use a specific "Toast" or "Status" message later
 	}
+	return 0
+}
+
+// requestTriggerBuild asks for confirmation before triggering a build
+func (m *BuildsModel) requestTriggerBuild() tea.Cmd {
+	if m.mode != ModeJobList && m.mode != ModeBuildList && m.mode != ModeBuildDetail {
+		return nil
+	}
+	jobName := m.selectedJobName()
+	if jobName == "" {
+		return nil
+	}
+	if !canPerform(m.client, jenkins.PermBuild, jobName, 0) {
+		m.notice, m.noticeErr = blockedNotice(m.client, jenkins.PermBuild), true
+		return nil
+	}
+	m.confirm = &confirmAction{
+		prompt: fmt.Sprintf("Trigger a new build of %s?", jobName),
+		run:    m.triggerBuild(jobName),
+	}
+	return nil
+}
+
+// requestAbortBuild asks for confirmation before aborting a running build
+func (m *BuildsModel) requestAbortBuild() tea.Cmd {
+	jobName := m.selectedJobName()
+	buildNum := m.selectedRunningBuild()
+	if jobName == "" || buildNum == 0 {
+		return nil
+	}
+	if !canPerform(m.client, jenkins.PermCancel, jobName, buildNum) {
+		m.notice, m.noticeErr = blockedNotice(m.client, jenkins.PermCancel), true
+		return nil
+	}
+	m.confirm = &confirmAction{
+		prompt: fmt.Sprintf("Abort %s #%d?", jobName, buildNum),
+		run:    m.abortBuild(jobName, buildNum),
+	}
+	return nil
+}
+
+func (m *BuildsModel) triggerBuild(jobName string) tea.Cmd {
+	return runAction("Build triggered for "+jobName, func(ctx context.Context) error {
+		return m.client.TriggerBuild(ctx, jobName)
+	})
+}
+
+func (m *BuildsModel) abortBuild(jobName string, buildNum int) tea.Cmd {
+	return runAction(fmt.Sprintf("Abort requested for %s #%d", jobName, buildNum), func(ctx context.Context) error {
+		return m.client.AbortBuild(ctx, jobName, buildNum)
+	})
 }
 
 func (m *BuildsModel) pageDown() {
@@ -1270,6 +2340,18 @@ func (m *BuildsModel) pageDown() {
 		if m.selectedBuild >= m.buildsScroll+listHeight {
 			m.buildsScroll = m.selectedBuild - listHeight + 1
 		}
+	case ModeCompare:
+		m.scrollCompare(m.compareRows())
+	case ModeTests:
+		m.moveTestSelection(m.testListHeight())
+	case ModeFlakyTests:
+		m.moveFlakySelection(m.flakyListHeight())
+	case ModeTestDetail:
+		m.scrollTestDetail(m.testDetailRows())
+	case ModeLint:
+		m.scrollLint(m.lintRows())
+	case ModeArtifacts:
+		m.moveArtifactSelection(m.artifactListHeight())
 	}
 }
 
@@ -1288,6 +2370,18 @@ func (m *BuildsModel) pageUp() {
 		if m.selectedBuild < m.buildsScroll {
 			m.buildsScroll = m.selectedBuild
 		}
+	case ModeCompare:
+		m.scrollCompare(-m.compareRows())
+	case ModeTests:
+		m.moveTestSelection(-m.testListHeight())
+	case ModeFlakyTests:
+		m.moveFlakySelection(-m.flakyListHeight())
+	case ModeTestDetail:
+		m.scrollTestDetail(-m.testDetailRows())
+	case ModeLint:
+		m.scrollLint(-m.lintRows())
+	case ModeArtifacts:
+		m.moveArtifactSelection(-m.artifactListHeight())
 	}
 }
 
@@ -1323,9 +2417,15 @@ func (m *BuildsModel) fetchBuildDetail(jobName string, buildNum int) tea.Cmd {
 		}
 
 		// Also try to fetch pipeline stages (may return nil if not a pipeline job)
-		pipelineRun, _ := m.client.GetPipelineRun(ctx, jobName, buildNum)
+		pipelineRun, _ := m.client.GetPipelineGraph(ctx, jobName, buildNum)
+
+		// Running pipelines may be paused on input steps
+		var inputs []models.PendingInput
+		if build.Building {
+			inputs, _ = m.client.GetPendingInputs(ctx, jobName, buildNum)
+		}
 
-		return BuildsDataMsg{BuildDetail: build, PipelineRun: pipelineRun, Error: nil}
+		return BuildsDataMsg{BuildDetail: build, PipelineRun: pipelineRun, PendingInputs: inputs, Error: nil}
 	}
 }
 
@@ -1351,12 +2451,13 @@ func (m *BuildsModel) fetchStageLog(jobName string, buildNum int, stageID string
 
 // BuildsDataMsg carries builds data updates
 type BuildsDataMsg struct {
-	Jobs        []models.Job
-	JobDetail   *models.JobDetail
-	BuildDetail *models.Build
-	PipelineRun *models.PipelineRun
-	LogContent  string
-	Error       error
+	Jobs          []models.Job
+	JobDetail     *models.JobDetail
+	BuildDetail   *models.Build
+	PipelineRun   *models.PipelineRun
+	PendingInputs []models.PendingInput
+	LogContent    string
+	Error         error
 }
 
 func formatDuration(d time.Duration) string {
@@ -0,0 +1,402 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/elogrono/jenkins-tui/internal/diff"
+	"github.com/elogrono/jenkins-tui/internal/jenkins/models"
+	"github.com/elogrono/jenkins-tui/internal/ui/components"
+	"github.com/elogrono/jenkins-tui/internal/ui/theme"
+)
+
+// compareMaxBetween caps how many intermediate builds are fetched for commits
+const compareMaxBetween = 30
+
+// CompareDataMsg carries the comparison of two builds
+type CompareDataMsg struct {
+	Comparison *models.BuildComparison
+	Skipped    int // Intermediate builds whose commits were not loaded
+	Error      error
+}
+
+// CompareLogMsg carries the unified diff of two console logs
+type CompareLogMsg struct {
+	Diff  []string
+	Error error
+}
+
+// logNoise matches console log fragments that differ between otherwise identical runs
+var logNoise = []struct {
+	re   *regexp.Regexp
+	repl string
+}{
+	{consoleNoteRe, ""},
+	{regexp.MustCompile(`\x1b\[[0-9;]*[A-Za-z]`), ""},
+	{regexp.MustCompile(`\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}:\d{2}(?:[.,]\d+)?(?:Z|[+-]\d{2}:?\d{2})?`), "<time>"},
+	{regexp.MustCompile(`\b\d{1,2}:\d{2}:\d{2}(?:[.,]\d+)?\b`), "<time>"},
+	{regexp.MustCompile(`(?i)\b[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}\b`), "<uuid>"},
+	{regexp.MustCompile(`\b[0-9a-f]{12,40}\b`), "<hash>"},
+	{regexp.MustCompile(`\b\d+(?:\.\d+)?\s?(?:ms|s|sec|secs|seconds|min|mins|minutes)\b`), "<duration>"},
+}
+
+// normalizeLog splits a console log into lines with timestamps, durations,
+// ids and trailing whitespace normalized so that only real changes diff
+func normalizeLog(text string) []string {
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	for i, line := range lines {
+		for _, n := range logNoise {
+			line = n.re.ReplaceAllString(line, n.repl)
+		}
+		lines[i] = strings.TrimRight(line, " \t\r")
+	}
+	return lines
+}
+
+// toggleCompareMark marks the selected build as the comparison base, or
+// compares it with the build already marked
+func (m *BuildsModel) toggleCompareMark() tea.Cmd {
+	if m.jobDetail == nil || m.selectedBuild >= len(m.builds) {
+		return nil
+	}
+	selected := m.builds[m.selectedBuild].Number
+	switch m.compareMark {
+	case 0:
+		m.compareMark = selected
+		m.notice, m.noticeErr = fmt.Sprintf("Marked #%d, press c on another build to compare", selected), false
+		return nil
+	case selected:
+		m.compareMark = 0
+		m.notice = ""
+		return nil
+	}
+	base, target := m.compareMark, selected
+	m.compareMark = 0
+	return m.openCompare(base, target)
+}
+
+// compareWithLastSuccess compares the selected build with the last green build before it
+func (m *BuildsModel) compareWithLastSuccess() tea.Cmd {
+	if m.jobDetail == nil || m.selectedBuild >= len(m.builds) {
+		return nil
+	}
+	selected := m.builds[m.selectedBuild].Number
+	base := 0
+	for _, b := range m.builds {
+		if b.Result == "SUCCESS" && b.Number < selected && b.Number > base {
+			base = b.Number
+		}
+	}
+	if base == 0 {
+		m.notice, m.noticeErr = fmt.Sprintf("No successful build before #%d", selected), true
+		return nil
+	}
+	return m.openCompare(base, selected)
+}
+
+// openCompare switches to compare mode for two builds; the older one is the base
+func (m *BuildsModel) openCompare(a, b int) tea.Cmd {
+	base, target := minInt(a, b), maxInt(a, b)
+	m.mode = ModeCompare
+	m.comparison = nil
+	m.compareLog = nil
+	m.compareShowLog = false
+	m.compareScroll = 0
+	m.compareSkipped = 0
+	m.notice = ""
+	return m.fetchComparison(m.jobDetail.Name, base, target)
+}
+
+// compareLines renders the comparison, or the log diff, as scrollable lines
+func (m *BuildsModel) compareLines(width int) []string {
+	if m.comparison == nil {
+		return []string{"  " + m.spinner.View() + " Comparing builds..."}
+	}
+	if m.compareShowLog {
+		return m.compareLogLines(width)
+	}
+
+	c := m.comparison
+	section := func(icon, title string) []string {
+		return []string{"", theme.SectionTitleStyle.Render(icon + " " + title)}
+	}
+	none := func(text string) string {
+		return theme.MutedStyle.Render("  " + text)
+	}
+
+	var rows []string
+	rows = append(rows,
+		compareBuildLine("Base  ", c.Base),
+		compareBuildLine("Target", c.Target),
+	)
+
+	rows = append(rows, section(theme.IconFilter, "Parameters")...)
+	if len(c.Parameters) == 0 {
+		rows = append(rows, none("No parameter changes"))
+	}
+	for _, p := range c.Parameters {
+		base, target := p.Base, p.Target
+		if !p.InBase {
+			base = "(unset)"
+		}
+		if !p.InTarget {
+			target = "(unset)"
+		}
+		rows = append(rows, fmt.Sprintf("  %s  %s %s %s",
+			theme.AccentStyle.Render(p.Name),
+			theme.ErrorStyle.Render(truncate(base, 40)),
+			theme.MutedStyle.Render(theme.IconArrowRight),
+			theme.SuccessStyle.Render(truncate(target, 40))))
+	}
+
+	rows = append(rows, section(theme.IconUser, "Causes")...)
+	rows = append(rows, "  "+theme.MutedStyle.Render("Base:   ")+truncate(strings.Join(c.BaseCauses, "; "), width-12))
+	rows = append(rows, "  "+theme.MutedStyle.Render("Target: ")+truncate(strings.Join(c.TargetCauses, "; "), width-12))
+
+	rows = append(rows, section(theme.IconCommit, fmt.Sprintf("Commits (%d)", len(c.Commits)))...)
+	if len(c.Commits) == 0 {
+		rows = append(rows, none("No commits between these builds"))
+	}
+	for _, item := range c.Commits {
+		id := item.CommitID
+		if len(id) > 8 {
+			id = id[:8]
+		}
+		rows = append(rows, fmt.Sprintf("  %s %s %s",
+			theme.AccentStyle.Render(fmt.Sprintf("%-8s", id)),
+			truncate(strings.TrimSpace(item.Msg), maxInt(width-32, 10)),
+			theme.MutedStyle.Render(truncate(item.Author.FullName, 18))))
+	}
+	if m.compareSkipped > 0 {
+		rows = append(rows, none(fmt.Sprintf("Commits of %d older intermediate builds not loaded", m.compareSkipped)))
+	}
+
+	rows = append(rows, section(theme.IconBuild, "Stages")...)
+	if len(c.Stages) == 0 {
+		rows = append(rows, none("No pipeline stages"))
+	}
+	for _, s := range c.Stages {
+		rows = append(rows, renderStageChange(s))
+	}
+
+	rows = append(rows, section(theme.IconArtifact, "Artifacts")...)
+	if len(c.Artifacts) == 0 {
+		rows = append(rows, none("No artifacts"))
+	}
+	for _, a := range c.Artifacts {
+		marker := theme.MutedStyle.Render(" ")
+		switch {
+		case a.InTarget && !a.InBase:
+			marker = theme.SuccessStyle.Render("+")
+		case a.InBase && !a.InTarget:
+			marker = theme.ErrorStyle.Render("-")
+		}
+		rows = append(rows, "  "+marker+" "+truncate(a.Path, width-6))
+	}
+	return rows
+}
+
+// compareBuildLine renders the summary of one side of the comparison
+func compareBuildLine(label string, b *models.Build) string {
+	result := b.Result
+	if b.Building {
+		result = "RUNNING"
+	}
+	return fmt.Sprintf("%s  %s %s  %s  %s",
+		theme.MutedStyle.Render(label),
+		theme.BuildResultStyle(result).Render(theme.BuildResultIcon(result)),
+		theme.AccentStyle.Render(fmt.Sprintf("#%d", b.Number)),
+		theme.BuildResultStyle(result).Render(result),
+		theme.MutedStyle.Render(time.UnixMilli(b.Timestamp).Format("2006-01-02 15:04")+" │ "+
+			formatDuration(time.Duration(b.Duration)*time.Millisecond)))
+}
+
+// renderStageChange renders base and target status/duration of one stage
+func renderStageChange(s models.StageChange) string {
+	side := func(status string, millis int64) string {
+		if status == "" {
+			return theme.MutedStyle.Render(fmt.Sprintf("%-20s", "not run"))
+		}
+		icon, color := components.StageIcon(stageStatus(status))
+		text := fmt.Sprintf("%s %-9s %8s", icon, status, formatDuration(time.Duration(millis)*time.Millisecond))
+		return lipgloss.NewStyle().Foreground(color).Render(text)
+	}
+
+	name := fmt.Sprintf("  %-24s", truncate(s.Name, 24))
+	if s.Changed() {
+		name = theme.WarningStyle.Render(name)
+	}
+	return name + " " + side(s.BaseStatus, s.BaseDuration) + theme.MutedStyle.Render(" "+theme.IconArrowRight+" ") + side(s.TargetStatus, s.TargetDuration)
+}
+
+// compareLogLines renders the log diff with added/removed lines colored
+func (m *BuildsModel) compareLogLines(width int) []string {
+	if m.compareLog == nil {
+		return []string{"  " + m.spinner.View() + " Diffing console logs..."}
+	}
+	if len(m.compareLog) == 0 {
+		return []string{theme.MutedStyle.Render("  Console logs are identical after normalization")}
+	}
+
+	rows := make([]string, len(m.compareLog))
+	for i, line := range m.compareLog {
+		rows[i] = truncate(line, width)
+	}
+	return colorDiff(rows)
+}
+
+// colorDiff colors the lines of a unified diff: added lines green, removed
+// lines red and hunk headers in the secondary color
+func colorDiff(lines []string) []string {
+	rows := make([]string, 0, len(lines))
+	for _, line := range lines {
+		text := strings.ReplaceAll(line, "\t", "    ")
+		switch {
+		case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+			rows = append(rows, lipgloss.NewStyle().Bold(true).Render(text))
+		case strings.HasPrefix(line, "@@"):
+			rows = append(rows, lipgloss.NewStyle().Foreground(theme.Secondary).Render(text))
+		case strings.HasPrefix(line, "+"):
+			rows = append(rows, lipgloss.NewStyle().Foreground(theme.BuildSuccess).Render(text))
+		case strings.HasPrefix(line, "-"):
+			rows = append(rows, lipgloss.NewStyle().Foreground(theme.BuildFailure).Render(text))
+		default:
+			rows = append(rows, theme.MutedStyle.Render(text))
+		}
+	}
+	return rows
+}
+
+// compareRows returns how many lines of the comparison fit on screen
+func (m *BuildsModel) compareRows() int {
+	return maxInt(m.height-14, 3)
+}
+
+// scrollCompare moves the comparison window by delta lines
+func (m *BuildsModel) scrollCompare(delta int) {
+	total := len(m.compareLines(m.width - 12))
+	m.compareScroll = maxInt(0, minInt(m.compareScroll+delta, total-m.compareRows()))
+}
+
+// toggleCompareLog switches between the summary and the log diff
+func (m *BuildsModel) toggleCompareLog() tea.Cmd {
+	if m.comparison == nil {
+		return nil
+	}
+	m.compareShowLog = !m.compareShowLog
+	m.compareScroll = 0
+	if m.compareShowLog && m.compareLog == nil && m.jobDetail != nil {
+		return m.fetchCompareLog(m.jobDetail.Name, m.comparison.Base.Number, m.comparison.Target.Number)
+	}
+	return nil
+}
+
+// viewCompare renders the comparison of two builds
+func (m *BuildsModel) viewCompare() string {
+	jobName := ""
+	if m.jobDetail != nil {
+		jobName = m.jobDetail.Name
+	}
+	crumb := "Compare"
+	if c := m.comparison; c != nil {
+		crumb = fmt.Sprintf("#%d ⇄ #%d", c.Base.Number, c.Target.Number)
+	}
+	breadcrumb := components.NewBreadcrumb("Builds", jobName, crumb).Render()
+
+	title := theme.IconBuild + " Comparison"
+	if m.compareShowLog {
+		title = theme.IconLog + " Console log diff"
+	}
+
+	lines := m.compareLines(m.width - 12)
+	end := minInt(m.compareScroll+m.compareRows(), len(lines))
+	rows := []string{theme.SectionTitleStyle.Render(title)}
+	rows = append(rows, lines[minInt(m.compareScroll, end):end]...)
+	if len(lines) > m.compareRows() {
+		rows = append(rows, theme.MutedStyle.Render(fmt.Sprintf("Lines %d-%d of %d", m.compareScroll+1, end, len(lines))))
+	}
+
+	panel := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Border).
+		Width(m.width-6).
+		Padding(1, 2).
+		Render(strings.Join(rows, "\n"))
+
+	content := lipgloss.JoinVertical(lipgloss.Left, breadcrumb, panel, m.renderShortcuts())
+
+	return lipgloss.NewStyle().
+		Width(m.width).
+		Height(m.height-4).
+		Padding(0, 1).
+		Render(content)
+}
+
+func (m *BuildsModel) fetchComparison(jobName string, base, target int) tea.Cmd {
+	m.loading = true
+	var between []int
+	for _, b := range m.builds {
+		if b.Number > base && b.Number < target {
+			between = append(between, b.Number)
+		}
+	}
+	// m.builds is newest first, so the cap drops the oldest builds
+	skipped := maxInt(len(between)-compareMaxBetween, 0)
+	between = between[:len(between)-skipped]
+
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		baseBuild, err := m.client.GetBuild(ctx, jobName, base)
+		if err != nil {
+			return CompareDataMsg{Error: err}
+		}
+		targetBuild, err := m.client.GetBuild(ctx, jobName, target)
+		if err != nil {
+			return CompareDataMsg{Error: err}
+		}
+
+		// Stages and intermediate builds are best effort
+		baseRun, _ := m.client.GetPipelineRun(ctx, jobName, base)
+		targetRun, _ := m.client.GetPipelineRun(ctx, jobName, target)
+		var builds []models.Build
+		for _, number := range between {
+			if b, err := m.client.GetBuild(ctx, jobName, number); err == nil {
+				builds = append(builds, *b)
+			}
+		}
+
+		comparison := models.CompareBuilds(baseBuild, targetBuild, baseRun, targetRun, builds)
+		return CompareDataMsg{Comparison: &comparison, Skipped: skipped}
+	}
+}
+
+func (m *BuildsModel) fetchCompareLog(jobName string, base, target int) tea.Cmd {
+	m.loading = true
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		baseLog, err := m.client.GetBuildLog(ctx, jobName, base, 500000)
+		if err != nil {
+			return CompareLogMsg{Error: err}
+		}
+		targetLog, err := m.client.GetBuildLog(ctx, jobName, target, 500000)
+		if err != nil {
+			return CompareLogMsg{Error: err}
+		}
+
+		unified := diff.Unified(fmt.Sprintf("#%d", base), fmt.Sprintf("#%d", target), normalizeLog(baseLog), normalizeLog(targetLog), 3)
+		lines := []string{}
+		if unified != "" {
+			lines = strings.Split(strings.TrimRight(unified, "\n"), "\n")
+		}
+		return CompareLogMsg{Diff: lines}
+	}
+}
@@ -44,6 +44,7 @@ type DashboardModel struct {
 	selectedNode      int
 	selectedQueueItem int
 	selectedBuild     int
+	focusNode         string // Node to select once the nodes are loaded
 
 	// State
 	loading    bool
@@ -61,6 +62,7 @@ type RunningBuildInfo struct {
 	Duration  time.Duration
 	Progress  int
 	URL       string
+	Waiting   string // Message of the input step the build is paused on
 }
 
 // RecentBuildInfo contains information about a recent build for dashboard display
@@ -117,9 +119,14 @@ func (m *DashboardModel) Update(msg tea.Msg) tea.Cmd {
 		if msg.RootInfo != nil {
 			m.rootInfo = msg.RootInfo
 		}
+		var cmd tea.Cmd
 		if msg.Nodes != nil {
 			m.nodes = msg.Nodes
 			m.runningBuilds = m.extractRunningBuilds()
+			if m.focusNode != "" {
+				m.applyFocusNode()
+			}
+			cmd = m.fetchPendingInputs()
 		}
 		if msg.Queue != nil {
 			m.queue = msg.Queue
@@ -130,6 +137,12 @@ func (m *DashboardModel) Update(msg tea.Msg) tea.Cmd {
 		if msg.Error != nil {
 			m.lastError = msg.Error
 		}
+		return cmd
+
+	case DashboardInputsMsg:
+		for i := range m.runningBuilds {
+			m.runningBuilds[i].Waiting = msg.Waiting[m.runningBuilds[i].URL]
+		}
 		return nil
 
 	case spinner.TickMsg:
@@ -505,6 +518,10 @@ func (m *DashboardModel) renderRunningBuildsPanel(width, height int) string {
 	} else {
 		var rows []string
 		maxRows := height - 4 // -1 for header
+		if banner := m.renderWaitingBanner(width - 2); banner != "" {
+			rows = append(rows, banner)
+			maxRows--
+		}
 		if maxRows < 1 {
 			maxRows = 1
 		}
@@ -533,6 +550,11 @@ func (m *DashboardModel) renderRunningBuildsPanel(width, height int) string {
 			jobName := build.JobName
 			nodeName := build.NodeName
 
+			icon, iconStyle := theme.IconRunning, theme.RunningStyle
+			if build.Waiting != "" {
+				icon, iconStyle = theme.IconPaused, theme.WarningStyle
+			}
+
 			var row string
 			if isSelected {
 				row = lipgloss.NewStyle().
@@ -541,7 +563,7 @@ func (m *DashboardModel) renderRunningBuildsPanel(width, height int) string {
 					Bold(true).
 					Width(width - 2).
 					Render(fmt.Sprintf(" %s#%-5d %-70s %-12s %-15s",
-						theme.IconRunning,
+						icon,
 						build.BuildNum,
 						truncate(jobName, 70),
 						progressBar.Render(),
@@ -549,7 +571,7 @@ func (m *DashboardModel) renderRunningBuildsPanel(width, height int) string {
 					))
 			} else {
 				row = fmt.Sprintf("  %s#%-5d %-70s %-12s %s",
-					theme.RunningStyle.Render(theme.IconRunning),
+					iconStyle.Render(icon),
 					build.BuildNum,
 					theme.BaseStyle.Render(truncate(jobName, 70)),
 					progressBar.Render(),
@@ -575,6 +597,25 @@ func (m *DashboardModel) renderRunningBuildsPanel(width, height int) string {
 	return panelStyle.Render(lipgloss.JoinVertical(lipgloss.Left, title, content))
 }
 
+// renderWaitingBanner summarises the running builds paused on an input step
+func (m *DashboardModel) renderWaitingBanner(width int) string {
+	var waiting []RunningBuildInfo
+	for _, b := range m.runningBuilds {
+		if b.Waiting != "" {
+			waiting = append(waiting, b)
+		}
+	}
+	if len(waiting) == 0 {
+		return ""
+	}
+	text := fmt.Sprintf(" %s %s waiting for input: %s", theme.IconPaused, waiting[0].JobName, waiting[0].Waiting)
+	if len(waiting) > 1 {
+		text = fmt.Sprintf(" %s %d builds waiting for input", theme.IconPaused, len(waiting))
+	}
+	hint := "  open in Builds, press i"
+	return theme.WarningStyle.Bold(true).Render(truncate(text, maxInt(width-len(hint)-1, 20))) + theme.MutedStyle.Render(hint)
+}
+
 func (m *DashboardModel) renderNodesPanel(width, height int) string {
 	titleStyle := theme.SectionTitleStyle.Copy().Width(width - 2)
 	title := titleStyle.Render(theme.IconServer + " Nodes")
@@ -844,9 +885,15 @@ func (m *DashboardModel) renderShortcuts() string {
 
 func (m *DashboardModel) extractRunningBuilds() []RunningBuildInfo {
 	var running []RunningBuildInfo
+	seen := make(map[string]bool)
 	for _, node := range m.nodes {
-		for _, exec := range node.Executors {
-			if exec.CurrentExecutable.URL != "" {
+		// Pipelines hold a flyweight executor while outside node blocks,
+		// e.g. when paused on an input step
+		executors := append(append([]models.Executor(nil), node.Executors...), node.OneOffExecutors...)
+		for _, exec := range executors {
+			if exec.CurrentExecutable.URL != "" && !seen[exec.CurrentExecutable.URL] {
+				seen[exec.CurrentExecutable.URL] = true
+
 				// Parse job name from URL
 				jobName := exec.CurrentExecutable.FullDisplayName
 				if jobName == "" {
@@ -939,6 +986,44 @@ func (m *DashboardModel) fetchJobs() tea.Cmd {
 	}
 }
 
+// dashboardInputChecks bounds how many running builds are checked for input
+const dashboardInputChecks = 10
+
+// DashboardInputsMsg carries the input messages of paused builds, by build URL
+type DashboardInputsMsg struct {
+	Waiting map[string]string
+}
+
+// fetchPendingInputs checks which running builds are paused on an input step
+func (m *DashboardModel) fetchPendingInputs() tea.Cmd {
+	if m.client == nil || len(m.runningBuilds) == 0 {
+		return nil
+	}
+	var urls []string
+	for _, b := range m.runningBuilds {
+		if len(urls) == dashboardInputChecks {
+			break
+		}
+		urls = append(urls, b.URL)
+	}
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		waiting := make(map[string]string)
+		for _, u := range urls {
+			job, build := m.client.ParseBuildURL(u)
+			if job == "" {
+				continue
+			}
+			// Builds that are not pipelines answer 404 and have no inputs
+			if inputs, err := m.client.GetPendingInputs(ctx, job, build); err == nil && len(inputs) > 0 {
+				waiting[u] = inputs[0].Message
+			}
+		}
+		return DashboardInputsMsg{Waiting: waiting}
+	}
+}
+
 // DashboardDataMsg carries dashboard data updates
 type DashboardDataMsg struct {
 	RootInfo *models.RootInfo
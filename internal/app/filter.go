@@ -0,0 +1,204 @@
+package app
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/elogrono/jenkins-tui/internal/fuzzy"
+	"github.com/elogrono/jenkins-tui/internal/jenkins/models"
+	"github.com/elogrono/jenkins-tui/internal/ui/theme"
+)
+
+// jobStatuses are the values of the status: qualifier, by ball color
+var jobStatuses = map[string]string{
+	"red":      "failed",
+	"blue":     "success",
+	"green":    "success",
+	"yellow":   "unstable",
+	"aborted":  "aborted",
+	"disabled": "disabled",
+	"notbuilt": "notbuilt",
+	"grey":     "notbuilt",
+}
+
+// matchStyle highlights the characters of a name matched by the filter
+var matchStyle = lipgloss.NewStyle().Foreground(theme.Warning).Bold(true).Underline(true)
+
+// jobQuery is a parsed list filter: words matched fuzzily against the job
+// name, and qualifiers such as status:failed, node:linux or health:<50
+type jobQuery struct {
+	words  []string
+	status []string             // Any of these status prefixes
+	node   string               // Part of the agent the last build ran on; empty for pipelines
+	health func(score int) bool // Nil without a health: qualifier
+}
+
+// parseJobQuery splits a filter into words and qualifiers. Jenkins refuses
+// ':' in job names, so every word with a colon is a qualifier; unknown or
+// malformed ones are kept as words and match nothing.
+func parseJobQuery(filter string) jobQuery {
+	var q jobQuery
+	for _, field := range strings.Fields(filter) {
+		key, value, found := strings.Cut(field, ":")
+		switch {
+		case !found || value == "":
+			q.words = append(q.words, field)
+		case strings.EqualFold(key, "status"):
+			q.status = append(q.status, strings.Split(strings.ToLower(value), ",")...)
+		case strings.EqualFold(key, "node"):
+			q.node = strings.ToLower(value)
+		case strings.EqualFold(key, "health"):
+			if cmp := parseHealth(value); cmp != nil {
+				q.health = cmp
+				continue
+			}
+			q.words = append(q.words, field)
+		default:
+			q.words = append(q.words, field)
+		}
+	}
+	return q
+}
+
+// parseHealth parses "<50", "<=50", ">80", ">=80" or "100" into a test of
+// the health score, or returns nil
+func parseHealth(value string) func(int) bool {
+	op := strings.TrimRight(value, "0123456789")
+	n, err := strconv.Atoi(value[len(op):])
+	if err != nil {
+		return nil
+	}
+	switch op {
+	case "<":
+		return func(score int) bool { return score < n }
+	case "<=":
+		return func(score int) bool { return score <= n }
+	case ">":
+		return func(score int) bool { return score > n }
+	case ">=":
+		return func(score int) bool { return score >= n }
+	case "", "=":
+		return func(score int) bool { return score == n }
+	}
+	return nil
+}
+
+// empty reports whether the query lets every job through
+func (q jobQuery) empty() bool {
+	return len(q.words) == 0 && len(q.status) == 0 && q.node == "" && q.health == nil
+}
+
+// match reports whether the job passes the qualifiers and its name matches
+// every word, scoring the name match and how recently the job ran
+func (q jobQuery) match(job models.Job, now time.Time) (int, bool) {
+	if len(q.status) > 0 && !q.matchStatus(job) {
+		return 0, false
+	}
+	if q.node != "" && (job.LastBuild == nil || !strings.Contains(strings.ToLower(job.LastBuild.BuiltOn), q.node)) {
+		return 0, false
+	}
+	if q.health != nil {
+		score := job.GetHealthScore()
+		if score < 0 || !q.health(score) {
+			return 0, false
+		}
+	}
+
+	total := 0
+	for _, word := range q.words {
+		r, ok := fuzzy.Match(word, job.Name)
+		if !ok {
+			return 0, false
+		}
+		total += r.Score
+	}
+	if len(q.words) > 0 && job.LastBuild != nil {
+		total += fuzzy.Recency(time.UnixMilli(job.LastBuild.Timestamp), now)
+	}
+	return total, true
+}
+
+// matchStatus reports whether the job has one of the statuses; a prefix
+// such as "fail" is enough
+func (q jobQuery) matchStatus(job models.Job) bool {
+	status := jobStatuses[strings.TrimSuffix(job.Color, "_anime")]
+	for _, want := range q.status {
+		if want == "" {
+			continue
+		}
+		if strings.HasPrefix(status, want) || (job.IsRunning() && strings.HasPrefix("running", want)) {
+			return true
+		}
+	}
+	return false
+}
+
+// positions returns the characters of name matched by the words
+func (q jobQuery) positions(name string) []int {
+	var positions []int
+	for _, word := range q.words {
+		if r, ok := fuzzy.Match(word, name); ok {
+			positions = append(positions, r.Positions...)
+		}
+	}
+	return positions
+}
+
+// filterJobs returns the jobs matching the filter, best matches first when
+// it has words and in their current order otherwise
+func filterJobs(jobs []models.Job, filter string) []models.Job {
+	q := parseJobQuery(filter)
+	if q.empty() {
+		return jobs
+	}
+	now := time.Now()
+	var filtered []models.Job
+	scores := make(map[string]int)
+	for _, job := range jobs {
+		if score, ok := q.match(job, now); ok {
+			filtered = append(filtered, job)
+			scores[job.Name] = score
+		}
+	}
+	if len(q.words) > 0 {
+		sort.SliceStable(filtered, func(i, j int) bool {
+			return scores[filtered[i].Name] > scores[filtered[j].Name]
+		})
+	}
+	return filtered
+}
+
+// filterViews returns the views whose name matches the filter, best first
+func filterViews(views []models.View, filter string) []models.View {
+	filter = strings.TrimSpace(filter)
+	if filter == "" {
+		return views
+	}
+	var filtered []models.View
+	scores := make(map[string]int)
+	for _, view := range views {
+		if r, ok := fuzzy.Match(filter, view.Name); ok {
+			filtered = append(filtered, view)
+			scores[view.Name] = r.Score
+		}
+	}
+	sort.SliceStable(filtered, func(i, j int) bool {
+		return scores[filtered[i].Name] > scores[filtered[j].Name]
+	})
+	return filtered
+}
+
+// renderJobName renders a job name truncated to max and padded to width,
+// with the characters matched by the filter highlighted
+func renderJobName(name, filter string, max, width int, selected bool) string {
+	text := truncate(name, max)
+	if !selected && filter != "" {
+		var positions []int
+		text, positions = truncateMatches(name, parseJobQuery(filter).positions(name), max)
+		text = highlightMatches(text, positions, lipgloss.NewStyle(), matchStyle)
+	}
+	return text + strings.Repeat(" ", maxInt(width-lipgloss.Width(text), 0))
+}
@@ -0,0 +1,66 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/elogrono/jenkins-tui/internal/jenkins/models"
+)
+
+func TestFilterJobs(t *testing.T) {
+	now := time.Now()
+	jobs := []models.Job{
+		{Name: "docs-publish", Color: "red", LastBuild: &models.BuildRef{Timestamp: now.Add(-30 * 24 * time.Hour).UnixMilli(), BuiltOn: "linux-1"},
+			HealthReport: []models.HealthReport{{Score: 20}}},
+		{Name: "deploy-prod", Color: "blue_anime", LastBuild: &models.BuildRef{Timestamp: now.Add(-time.Minute).UnixMilli(), BuiltOn: "windows-2"},
+			HealthReport: []models.HealthReport{{Score: 100}}},
+		{Name: "api-tests", Color: "red", LastBuild: &models.BuildRef{Timestamp: now.Add(-time.Hour).UnixMilli(), BuiltOn: "linux-2"},
+			HealthReport: []models.HealthReport{{Score: 40}}},
+		{Name: "sandbox", Color: "disabled"},
+	}
+	names := func(filter string) string {
+		var out []string
+		for _, job := range filterJobs(jobs, filter) {
+			out = append(out, job.Name)
+		}
+		return strings.Join(out, ",")
+	}
+
+	tests := []struct {
+		filter string
+		want   string
+	}{
+		{"", "docs-publish,deploy-prod,api-tests,sandbox"},
+		{"dp", "deploy-prod,docs-publish"}, // Word starts and recency rank deploy-prod first
+		{"status:failed", "docs-publish,api-tests"},
+		{"status:fail,disabled", "docs-publish,api-tests,sandbox"},
+		{"status:running", "deploy-prod"},
+		{"node:linux", "docs-publish,api-tests"},
+		{"health:<50", "docs-publish,api-tests"},
+		{"health:>=100", "deploy-prod"},
+		{"status:failed health:<30", "docs-publish"},
+		{"api status:failed", "api-tests"},
+		{"color:red", ""}, // Unknown qualifiers match nothing
+		{"xyz", ""},
+	}
+	for _, tt := range tests {
+		if got := names(tt.filter); got != tt.want {
+			t.Errorf("filter %q: expected %q, got %q", tt.filter, tt.want, got)
+		}
+	}
+}
+
+func TestRenderJobNameHighlightsMatches(t *testing.T) {
+	plain := renderJobName("deploy-prod", "status:failed", 33, 35, false)
+	if plain != "deploy-prod"+strings.Repeat(" ", 24) {
+		t.Errorf("expected a padded plain name without words, got %q", plain)
+	}
+	if got := parseJobQuery("dp status:failed").positions("deploy-prod"); fmt.Sprint(got) != "[0 7]" {
+		t.Errorf("expected the word starts highlighted, got %v", got)
+	}
+	if got := renderJobName("deploy-prod", "dp", 33, 35, true); strings.Contains(got, "\x1b") {
+		t.Errorf("expected no highlighting on the selected row, got %q", got)
+	}
+}
@@ -0,0 +1,235 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/elogrono/jenkins-tui/internal/jenkins/models"
+	"github.com/elogrono/jenkins-tui/internal/ui/components"
+	"github.com/elogrono/jenkins-tui/internal/ui/theme"
+)
+
+// flakyBuilds is how many recent builds are scanned for flaky tests
+const flakyBuilds = 30
+
+// FlakyTestsMsg carries the test history of a job
+type FlakyTestsMsg struct {
+	Job     string
+	History []models.BuildTestReport
+	Error   error
+}
+
+// openFlakyTests switches to the flaky tests table of the current job
+func (m *BuildsModel) openFlakyTests() tea.Cmd {
+	if m.jobDetail == nil {
+		return nil
+	}
+	m.mode = ModeFlakyTests
+	m.flakyHistory = nil
+	m.flakyTests = nil
+	m.selectedFlaky = 0
+	m.flakyScroll = 0
+	m.flakyBuild = 0
+	return m.fetchFlakyTests(m.jobDetail.Name)
+}
+
+// applyFlakyTests stores the history and ranks its flaky tests
+func (m *BuildsModel) applyFlakyTests(msg FlakyTestsMsg) {
+	m.loading = false
+	if msg.Error != nil {
+		m.lastError = msg.Error
+		return
+	}
+	if m.jobDetail == nil || m.jobDetail.Name != msg.Job {
+		return
+	}
+	m.flakyHistory = msg.History
+	m.flakyTests = models.DetectFlakyTests(msg.History)
+	m.selectedFlaky = minInt(m.selectedFlaky, maxInt(len(m.flakyTests)-1, 0))
+	m.flakyBuild = 0
+}
+
+// flakyListHeight returns how many flaky tests fit on screen
+func (m *BuildsModel) flakyListHeight() int {
+	return maxInt(m.height-22, 3)
+}
+
+// moveFlakySelection moves the selected test by delta rows
+func (m *BuildsModel) moveFlakySelection(delta int) {
+	if len(m.flakyTests) == 0 {
+		return
+	}
+	m.selectedFlaky = maxInt(0, minInt(m.selectedFlaky+delta, len(m.flakyTests)-1))
+	m.flakyBuild = 0
+	if m.selectedFlaky < m.flakyScroll {
+		m.flakyScroll = m.selectedFlaky
+	}
+	if m.selectedFlaky >= m.flakyScroll+m.flakyListHeight() {
+		m.flakyScroll = m.selectedFlaky - m.flakyListHeight() + 1
+	}
+}
+
+// moveFlakyBuild selects another failed build of the selected test
+func (m *BuildsModel) moveFlakyBuild(delta int) {
+	if m.selectedFlaky >= len(m.flakyTests) {
+		return
+	}
+	failed := m.flakyTests[m.selectedFlaky].FailedBuilds()
+	m.flakyBuild = maxInt(0, minInt(m.flakyBuild+delta, len(failed)-1))
+}
+
+// openFlakyFailure shows the selected test as it failed in the selected build
+func (m *BuildsModel) openFlakyFailure() {
+	if m.selectedFlaky >= len(m.flakyTests) {
+		return
+	}
+	test := m.flakyTests[m.selectedFlaky]
+	failed := test.FailedBuilds()
+	if m.flakyBuild >= len(failed) {
+		return
+	}
+	build := failed[m.flakyBuild]
+	for _, r := range m.flakyHistory {
+		if r.Build != build || r.Report == nil {
+			continue
+		}
+		if c := r.Report.FindTestCase(test.FullName()); c != nil {
+			m.openTestDetail(c, build, ModeFlakyTests)
+		}
+		return
+	}
+}
+
+// viewFlakyTests renders the ranked flaky tests of the job
+func (m *BuildsModel) viewFlakyTests() string {
+	jobName := ""
+	if m.jobDetail != nil {
+		jobName = m.jobDetail.Name
+	}
+	breadcrumb := components.NewBreadcrumb("Builds", jobName, "Flaky tests").Render()
+
+	width := m.width - 12
+	rows := []string{theme.SectionTitleStyle.Render(theme.IconWarning + " Flaky tests")}
+
+	switch {
+	case m.flakyHistory == nil && m.loading:
+		rows = append(rows, "  "+m.spinner.View()+fmt.Sprintf(" Loading test reports of the last %d builds...", flakyBuilds))
+	case len(m.flakyTests) == 0:
+		withReports := 0
+		for _, r := range m.flakyHistory {
+			if r.Report != nil {
+				withReports++
+			}
+		}
+		rows = append(rows, theme.MutedStyle.Render(fmt.Sprintf("  No flaky tests in %d builds with test results", withReports)))
+	default:
+		rows = append(rows,
+			theme.MutedStyle.Render(fmt.Sprintf("%d tests both passed and failed in the last %d builds, ranked by flip rate", len(m.flakyTests), len(m.flakyHistory))),
+			"",
+			theme.MutedStyle.Render(fmt.Sprintf("  %-6s %-7s %-*s %s", "Flips", "Fails", flakyBuilds, "History (oldest → newest)", "Test")),
+		)
+		end := minInt(m.flakyScroll+m.flakyListHeight(), len(m.flakyTests))
+		for i := m.flakyScroll; i < end; i++ {
+			rows = append(rows, m.renderFlakyRow(m.flakyTests[i], i == m.selectedFlaky, width))
+		}
+		if len(m.flakyTests) > m.flakyListHeight() {
+			rows = append(rows, theme.MutedStyle.Render(fmt.Sprintf("  %d-%d of %d", m.flakyScroll+1, end, len(m.flakyTests))))
+		}
+		rows = append(rows, "", m.renderFlakyFailures(width))
+	}
+
+	panel := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Border).
+		Width(m.width-6).
+		Padding(1, 2).
+		Render(strings.Join(rows, "\n"))
+
+	content := lipgloss.JoinVertical(lipgloss.Left, breadcrumb, panel, m.renderShortcuts())
+
+	return lipgloss.NewStyle().
+		Width(m.width).
+		Height(m.height-4).
+		Padding(0, 1).
+		Render(content)
+}
+
+// renderFlakyRow renders flip rate, failures, pass/fail history and name of a test
+func (m *BuildsModel) renderFlakyRow(test models.FlakyTest, selected bool, width int) string {
+	prefix := "  "
+	if selected {
+		prefix = theme.PrimaryStyle.Render("> ")
+	}
+
+	rate := fmt.Sprintf("%-6s", fmt.Sprintf("%.0f%%", test.FlipRate*100))
+	rateStyle := theme.WarningStyle
+	if test.FlipRate >= 0.5 {
+		rateStyle = theme.ErrorStyle
+	}
+
+	var history strings.Builder
+	for _, outcome := range test.History {
+		if outcome.Failed {
+			history.WriteString(lipgloss.NewStyle().Foreground(theme.BuildFailure).Render("▮"))
+		} else {
+			history.WriteString(lipgloss.NewStyle().Foreground(theme.BuildSuccess).Render("▮"))
+		}
+	}
+	pad := strings.Repeat(" ", maxInt(flakyBuilds-len(test.History), 0))
+
+	nameWidth := maxInt(width-flakyBuilds-18, 10)
+	name := truncate(test.FullName(), nameWidth)
+	if selected {
+		name = theme.PrimaryStyle.Bold(true).Render(name)
+	}
+
+	return fmt.Sprintf("%s%s %-7s %s%s %s",
+		prefix,
+		rateStyle.Render(rate),
+		fmt.Sprintf("%d/%d", test.Failures, test.Runs),
+		history.String(), pad,
+		name)
+}
+
+// renderFlakyFailures lists the builds where the selected test failed
+func (m *BuildsModel) renderFlakyFailures(width int) string {
+	if m.selectedFlaky >= len(m.flakyTests) {
+		return ""
+	}
+	test := m.flakyTests[m.selectedFlaky]
+
+	var builds []string
+	for i, build := range test.FailedBuilds() {
+		label := fmt.Sprintf("#%d", build)
+		if i == m.flakyBuild {
+			builds = append(builds, lipgloss.NewStyle().Background(theme.Primary).Foreground(theme.Background).Bold(true).Render(label))
+		} else {
+			builds = append(builds, theme.ErrorStyle.Render(label))
+		}
+	}
+
+	return theme.MutedStyle.Render("Failed in ") + strings.Join(builds, " ") + "\n" +
+		theme.MutedStyle.Render(truncate(fmt.Sprintf("←/→ choose a build, Enter shows %s as it failed there", test.Name), width))
+}
+
+func (m *BuildsModel) fetchFlakyTests(jobName string) tea.Cmd {
+	m.loading = true
+	var builds []models.BuildRef
+	for _, b := range m.builds {
+		if len(builds) == flakyBuilds {
+			break
+		}
+		builds = append(builds, b)
+	}
+	return func() tea.Msg {
+		// Uncached reports are fetched one per build; allow for a slow server
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		defer cancel()
+		history, err := m.client.GetTestHistory(ctx, jobName, builds)
+		return FlakyTestsMsg{Job: jobName, History: history, Error: err}
+	}
+}
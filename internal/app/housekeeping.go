@@ -0,0 +1,243 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/elogrono/jenkins-tui/internal/jenkins"
+	"github.com/elogrono/jenkins-tui/internal/ui/theme"
+)
+
+// descriptionFileName is the file a build description is edited in
+const descriptionFileName = "description.txt"
+
+// BuildDescriptionEditedMsg reports that the editor on a build description exited
+type BuildDescriptionEditedMsg struct {
+	JobName  string
+	Build    int
+	Dir      string // Temporary directory holding the edited description
+	Original string
+	Error    error
+}
+
+// BuildDeletedMsg reports the outcome of deleting a build
+type BuildDeletedMsg struct {
+	JobName string
+	Build   int
+	Error   error
+}
+
+// requestToggleKeep asks for confirmation before keeping the build forever,
+// or releasing it to the job's log rotation
+func (m *BuildsModel) requestToggleKeep() {
+	if m.jobDetail == nil || m.buildDetail == nil {
+		return
+	}
+	// Jenkins checks Run/Delete when a kept build is released
+	perm := jenkins.PermUpdateBuild
+	if m.buildDetail.KeepLog {
+		perm = jenkins.PermDeleteBuild
+	}
+	jobName, buildNum := m.jobDetail.Name, m.buildDetail.Number
+	if !canPerform(m.client, perm, jobName, buildNum) {
+		m.notice, m.noticeErr = blockedNotice(m.client, perm), true
+		return
+	}
+
+	prompt := fmt.Sprintf("Keep %s #%d forever?", jobName, buildNum)
+	success := fmt.Sprintf("%s #%d will be kept forever", jobName, buildNum)
+	if m.buildDetail.KeepLog {
+		prompt = fmt.Sprintf("Release %s #%d so log rotation may delete it?", jobName, buildNum)
+		success = fmt.Sprintf("%s #%d is no longer kept", jobName, buildNum)
+	}
+	m.confirm = &confirmAction{
+		prompt: prompt,
+		run: runAction(success, func(ctx context.Context) error {
+			return m.client.ToggleKeepBuild(ctx, jobName, buildNum)
+		}),
+	}
+}
+
+// editBuildDescription opens the description of the build in $EDITOR, as
+// descriptions often span several lines of HTML
+func (m *BuildsModel) editBuildDescription() tea.Cmd {
+	if m.jobDetail == nil || m.buildDetail == nil {
+		return nil
+	}
+	if !canPerform(m.client, jenkins.PermUpdateBuild, m.jobDetail.Name, m.buildDetail.Number) {
+		m.notice, m.noticeErr = blockedNotice(m.client, jenkins.PermUpdateBuild), true
+		return nil
+	}
+
+	dir, err := os.MkdirTemp("", "jenkins-tui-description-")
+	if err != nil {
+		m.notice, m.noticeErr = "Description: "+err.Error(), true
+		return nil
+	}
+	file := filepath.Join(dir, descriptionFileName)
+	original := m.buildDetail.Description
+	if err := os.WriteFile(file, []byte(original), 0o600); err != nil {
+		os.RemoveAll(dir)
+		m.notice, m.noticeErr = "Description: "+err.Error(), true
+		return nil
+	}
+
+	jobName, buildNum := m.jobDetail.Name, m.buildDetail.Number
+	return tea.ExecProcess(editorCommand([]string{file}), func(err error) tea.Msg {
+		return BuildDescriptionEditedMsg{JobName: jobName, Build: buildNum, Dir: dir, Original: original, Error: err}
+	})
+}
+
+// applyBuildDescriptionEdited asks to save the edited description
+func (m *BuildsModel) applyBuildDescriptionEdited(msg BuildDescriptionEditedMsg) {
+	defer os.RemoveAll(msg.Dir)
+	if msg.Error != nil {
+		m.notice, m.noticeErr = "Edit cancelled: "+msg.Error.Error(), true
+		return
+	}
+	edited, err := os.ReadFile(filepath.Join(msg.Dir, descriptionFileName))
+	if err != nil {
+		m.notice, m.noticeErr = "Description: "+err.Error(), true
+		return
+	}
+
+	// Editors append a final newline the original did not have
+	description := strings.TrimRight(string(edited), "\r\n")
+	if description == strings.TrimRight(msg.Original, "\r\n") {
+		m.notice, m.noticeErr = "No changes to the description", false
+		return
+	}
+	prompt := fmt.Sprintf("Set the description of %s #%d?", msg.JobName, msg.Build)
+	if description == "" {
+		prompt = fmt.Sprintf("Clear the description of %s #%d?", msg.JobName, msg.Build)
+	}
+	jobName, buildNum := msg.JobName, msg.Build
+	m.confirm = &confirmAction{
+		prompt: prompt,
+		run: runAction(fmt.Sprintf("Description of %s #%d saved", jobName, buildNum), func(ctx context.Context) error {
+			return m.client.SetBuildDescription(ctx, jobName, buildNum, description)
+		}),
+	}
+}
+
+// openRenamePrompt asks for a new display name of the build
+func (m *BuildsModel) openRenamePrompt() tea.Cmd {
+	if m.jobDetail == nil || m.buildDetail == nil {
+		return nil
+	}
+	if !canPerform(m.client, jenkins.PermUpdateBuild, m.jobDetail.Name, m.buildDetail.Number) {
+		m.notice, m.noticeErr = blockedNotice(m.client, jenkins.PermUpdateBuild), true
+		return nil
+	}
+	name := m.buildDetail.DisplayName
+	if name == fmt.Sprintf("#%d", m.buildDetail.Number) {
+		name = ""
+	}
+	m.renameInput.SetValue(name)
+	m.renameInput.CursorEnd()
+	m.renaming = true
+	m.renameInput.Focus()
+	return textinput.Blink
+}
+
+// updateRenamePrompt handles keys while the display name is edited
+func (m *BuildsModel) updateRenamePrompt(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc":
+		m.renaming = false
+		m.renameInput.Blur()
+		return nil
+	case "enter":
+		m.renaming = false
+		m.renameInput.Blur()
+		if m.jobDetail == nil || m.buildDetail == nil {
+			return nil
+		}
+		jobName, buildNum := m.jobDetail.Name, m.buildDetail.Number
+		name, description := strings.TrimSpace(m.renameInput.Value()), m.buildDetail.Description
+		prompt := fmt.Sprintf("Rename %s #%d to %q?", jobName, buildNum, name)
+		if name == "" {
+			prompt = fmt.Sprintf("Reset the name of %s #%d to #%d?", jobName, buildNum, buildNum)
+		}
+		m.confirm = &confirmAction{
+			prompt: prompt,
+			run: runAction(fmt.Sprintf("%s #%d renamed", jobName, buildNum), func(ctx context.Context) error {
+				return m.client.SetBuildDisplayName(ctx, jobName, buildNum, name, description)
+			}),
+		}
+		return nil
+	}
+	var cmd tea.Cmd
+	m.renameInput, cmd = m.renameInput.Update(msg)
+	return cmd
+}
+
+// renderRenamePrompt renders the display name prompt of build detail
+func (m *BuildsModel) renderRenamePrompt() string {
+	return theme.MutedStyle.Render(fmt.Sprintf("Display name of #%d (empty for the default):", m.buildDetail.Number)) + "\n" +
+		theme.SearchBarStyle.Width(maxInt(m.width-6, 20)).Render(m.renameInput.View())
+}
+
+// requestDeleteBuild asks for confirmation before deleting the build
+func (m *BuildsModel) requestDeleteBuild() {
+	if m.jobDetail == nil || m.buildDetail == nil {
+		return
+	}
+	jobName, buildNum := m.jobDetail.Name, m.buildDetail.Number
+	switch {
+	case m.buildDetail.Building:
+		m.notice, m.noticeErr = "A running build cannot be deleted: abort it first", true
+		return
+	case m.buildDetail.KeepLog:
+		m.notice, m.noticeErr = fmt.Sprintf("%s #%d is kept forever: press K to release it first", jobName, buildNum), true
+		return
+	case !canPerform(m.client, jenkins.PermDeleteBuild, jobName, buildNum):
+		m.notice, m.noticeErr = blockedNotice(m.client, jenkins.PermDeleteBuild), true
+		return
+	}
+	m.confirm = &confirmAction{
+		prompt: fmt.Sprintf("Delete %s #%d with its log and artifacts? This cannot be undone", jobName, buildNum),
+		run:    m.deleteBuild(jobName, buildNum),
+	}
+}
+
+func (m *BuildsModel) deleteBuild(jobName string, buildNum int) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		err := m.client.DeleteBuild(ctx, jobName, buildNum)
+		return BuildDeletedMsg{JobName: jobName, Build: buildNum, Error: err}
+	}
+}
+
+// applyBuildDeleted leaves the deleted build and reloads the build list
+func (m *BuildsModel) applyBuildDeleted(msg BuildDeletedMsg) tea.Cmd {
+	if msg.Error != nil {
+		m.notice, m.noticeErr = "Delete failed: "+msg.Error.Error(), true
+		return nil
+	}
+	m.notice, m.noticeErr = fmt.Sprintf("Deleted %s #%d", msg.JobName, msg.Build), false
+	if m.jobDetail == nil || m.jobDetail.Name != msg.JobName {
+		return nil
+	}
+	if m.mode == ModeBuildDetail && m.buildDetail != nil && m.buildDetail.Number == msg.Build {
+		m.closeBuildDetail()
+	}
+	return m.fetchJobDetail(msg.JobName)
+}
+
+// closeBuildDetail returns from build detail to the build list
+func (m *BuildsModel) closeBuildDetail() {
+	m.mode = ModeBuildList
+	m.buildDetail = nil
+	m.pipelineRun = nil
+	m.pendingInputs = nil
+	m.selectedArtifact = 0
+	m.artifactsScroll = 0
+}
@@ -0,0 +1,322 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/elogrono/jenkins-tui/internal/jenkins"
+	"github.com/elogrono/jenkins-tui/internal/jenkins/models"
+	"github.com/elogrono/jenkins-tui/internal/ui/components"
+	"github.com/elogrono/jenkins-tui/internal/ui/theme"
+)
+
+// inputForm is the form answering a pending input step
+type inputForm struct {
+	input  models.PendingInput
+	fields []inputField
+	focus  int // Field index; len(fields) is Proceed, len(fields)+1 is Abort
+}
+
+// inputField is one parameter of the input step
+type inputField struct {
+	param models.InputParam
+	text  textinput.Model // String, text and password parameters
+	value string          // Boolean ("true"/"false") and choice parameters
+}
+
+// newInputForm builds the form of an input step with its default values
+func newInputForm(input models.PendingInput) *inputForm {
+	form := &inputForm{input: input}
+	for _, p := range input.Inputs {
+		field := inputField{param: p, value: p.Default(), text: textinput.New()}
+		field.text.Prompt = ""
+		field.text.Width = 50
+		field.text.SetValue(p.Default())
+		if p.Kind() == models.InputPassword {
+			field.text.EchoMode = textinput.EchoPassword
+		}
+		form.fields = append(form.fields, field)
+	}
+	form.setFocus(0)
+	return form
+}
+
+// proceedFocus and abortFocus are the focus positions of the buttons
+func (f *inputForm) proceedFocus() int { return len(f.fields) }
+func (f *inputForm) abortFocus() int   { return len(f.fields) + 1 }
+
+// setFocus moves the focus, focusing the text input of the field if any
+func (f *inputForm) setFocus(focus int) {
+	f.focus = maxInt(0, minInt(focus, f.abortFocus()))
+	for i := range f.fields {
+		if i == f.focus {
+			f.fields[i].text.Focus()
+		} else {
+			f.fields[i].text.Blur()
+		}
+	}
+}
+
+// focusedField returns the field with the focus, or nil on the buttons
+func (f *inputForm) focusedField() *inputField {
+	if f.focus < len(f.fields) {
+		return &f.fields[f.focus]
+	}
+	return nil
+}
+
+// cycle changes a boolean or choice field; it reports whether it applied
+func (field *inputField) cycle(delta int) bool {
+	switch field.param.Kind() {
+	case models.InputBoolean:
+		if field.value == "true" {
+			field.value = "false"
+		} else {
+			field.value = "true"
+		}
+		return true
+	case models.InputChoice:
+		choices := field.param.Choices()
+		if len(choices) == 0 {
+			return true
+		}
+		current := 0
+		for i, c := range choices {
+			if c == field.value {
+				current = i
+			}
+		}
+		field.value = choices[(current+delta+len(choices))%len(choices)]
+		return true
+	}
+	return false
+}
+
+// values returns the entered value of every parameter
+func (f *inputForm) values() map[string]string {
+	values := make(map[string]string, len(f.fields))
+	for _, field := range f.fields {
+		switch field.param.Kind() {
+		case models.InputBoolean, models.InputChoice:
+			values[field.param.Name] = field.value
+		default:
+			values[field.param.Name] = field.text.Value()
+		}
+	}
+	return values
+}
+
+// openInputForm shows the form of the first pending input of the build
+func (m *BuildsModel) openInputForm() tea.Cmd {
+	if len(m.pendingInputs) == 0 || m.buildDetail == nil {
+		return nil
+	}
+	if !canPerform(m.client, jenkins.PermBuild, m.selectedJobName(), 0) {
+		m.notice, m.noticeErr = blockedNotice(m.client, jenkins.PermBuild), true
+		return nil
+	}
+	m.inputForm = newInputForm(m.pendingInputs[0])
+	m.mode = ModeInput
+	return textinput.Blink
+}
+
+// closeInputForm returns to the build detail and reloads the build
+func (m *BuildsModel) closeInputForm() tea.Cmd {
+	m.mode = ModeBuildDetail
+	m.inputForm = nil
+	if m.jobDetail == nil || m.buildDetail == nil {
+		return nil
+	}
+	m.pendingInputs = nil
+	return tea.Batch(m.fetchJobDetail(m.jobDetail.Name), m.fetchBuildDetail(m.jobDetail.Name, m.buildDetail.Number))
+}
+
+// updateInputForm handles keys while the input form is shown
+func (m *BuildsModel) updateInputForm(msg tea.KeyMsg) tea.Cmd {
+	form := m.inputForm
+	field := form.focusedField()
+
+	switch msg.String() {
+	case "esc":
+		m.mode = ModeBuildDetail
+		m.inputForm = nil
+		return nil
+	case "up", "shift+tab":
+		form.setFocus(form.focus - 1)
+		return nil
+	case "down", "tab":
+		form.setFocus(form.focus + 1)
+		return nil
+	case "enter":
+		switch form.focus {
+		case form.proceedFocus():
+			m.requestProceedInput()
+		case form.abortFocus():
+			m.requestAbortInput()
+		default:
+			form.setFocus(form.focus + 1)
+		}
+		return nil
+	case "left", "right", " ":
+		delta := 1
+		if msg.String() == "left" {
+			delta = -1
+		}
+		if field == nil {
+			// Arrows switch between the buttons
+			if msg.String() != " " && form.focus == form.proceedFocus() {
+				form.setFocus(form.abortFocus())
+			} else if msg.String() != " " {
+				form.setFocus(form.proceedFocus())
+			}
+			return nil
+		}
+		if field.cycle(delta) {
+			return nil
+		}
+	}
+
+	if field != nil && field.param.Kind() != models.InputBoolean && field.param.Kind() != models.InputChoice {
+		var cmd tea.Cmd
+		field.text, cmd = field.text.Update(msg)
+		return cmd
+	}
+	return nil
+}
+
+// requestProceedInput asks for confirmation before approving the input
+func (m *BuildsModel) requestProceedInput() {
+	form := m.inputForm
+	jobName, buildNum := m.jobDetail.Name, m.buildDetail.Number
+	input, values := form.input, form.values()
+	m.confirm = &confirmAction{
+		prompt: fmt.Sprintf("%s %s #%d at %q?", proceedLabel(input), jobName, buildNum, truncate(input.Message, 40)),
+		run: runAction(fmt.Sprintf("Input approved for %s #%d", jobName, buildNum), func(ctx context.Context) error {
+			return m.client.ProceedInput(ctx, jobName, buildNum, input, values)
+		}),
+	}
+}
+
+// requestAbortInput asks for confirmation before rejecting the input
+func (m *BuildsModel) requestAbortInput() {
+	jobName, buildNum := m.jobDetail.Name, m.buildDetail.Number
+	inputID := m.inputForm.input.ID
+	m.confirm = &confirmAction{
+		prompt: fmt.Sprintf("Abort %s #%d at %q?", jobName, buildNum, truncate(m.inputForm.input.Message, 40)),
+		run: runAction(fmt.Sprintf("Input rejected, %s #%d aborted", jobName, buildNum), func(ctx context.Context) error {
+			return m.client.AbortInput(ctx, jobName, buildNum, inputID)
+		}),
+	}
+}
+
+// proceedLabel returns the caption of the step's proceed button
+func proceedLabel(input models.PendingInput) string {
+	if input.ProceedText != "" {
+		return input.ProceedText
+	}
+	return "Proceed"
+}
+
+// renderInputBanner renders the notice shown while the build waits for input
+func renderInputBanner(inputs []models.PendingInput, width int) string {
+	text := theme.IconPaused + " Waiting for input: " + inputs[0].Message
+	if len(inputs) > 1 {
+		text += fmt.Sprintf(" (+%d more)", len(inputs)-1)
+	}
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Warning).
+		Width(width).
+		Padding(0, 2).
+		Render(theme.WarningStyle.Bold(true).Render(truncate(text, maxInt(width-30, 20))) +
+			theme.MutedStyle.Render("  press i to respond"))
+}
+
+// viewInputForm renders the parameters and buttons of a pending input
+func (m *BuildsModel) viewInputForm() string {
+	jobName, buildLabel := "", ""
+	if m.jobDetail != nil {
+		jobName = m.jobDetail.Name
+	}
+	if m.buildDetail != nil {
+		buildLabel = fmt.Sprintf("#%d", m.buildDetail.Number)
+	}
+	breadcrumb := components.NewBreadcrumb("Builds", jobName, buildLabel, "Input").Render()
+
+	form := m.inputForm
+	rows := []string{
+		theme.SectionTitleStyle.Render(theme.IconPaused + " Waiting for input"),
+		theme.BaseStyle.Bold(true).Render(form.input.Message),
+		"",
+	}
+
+	for i, field := range form.fields {
+		focused := i == form.focus
+		label := field.param.Name
+		if focused {
+			label = theme.PrimaryStyle.Render("> ") + theme.PrimaryStyle.Bold(true).Render(label)
+		} else {
+			label = "  " + theme.AccentStyle.Render(label)
+		}
+		rows = append(rows, label)
+		if field.param.Description != "" {
+			rows = append(rows, "    "+theme.MutedStyle.Render(truncate(field.param.Description, m.width-20)))
+		}
+		rows = append(rows, "    "+renderInputControl(field, focused), "")
+	}
+
+	button := func(label string, focused bool, color lipgloss.Color) string {
+		style := lipgloss.NewStyle().Padding(0, 2).Foreground(color).Border(lipgloss.RoundedBorder()).BorderForeground(theme.Border)
+		if focused {
+			style = style.BorderForeground(color).Bold(true)
+		}
+		return style.Render(label)
+	}
+	rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top,
+		"  ",
+		button(proceedLabel(form.input), form.focus == form.proceedFocus(), theme.Success),
+		" ",
+		button("Abort", form.focus == form.abortFocus(), theme.Error),
+	))
+
+	panel := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Warning).
+		Width(m.width-6).
+		Padding(1, 2).
+		Render(strings.Join(rows, "\n"))
+
+	content := lipgloss.JoinVertical(lipgloss.Left, breadcrumb, panel, m.renderShortcuts())
+
+	return lipgloss.NewStyle().
+		Width(m.width).
+		Height(m.height-4).
+		Padding(0, 1).
+		Render(content)
+}
+
+// renderInputControl renders the editable value of a field
+func renderInputControl(field inputField, focused bool) string {
+	switch field.param.Kind() {
+	case models.InputBoolean:
+		box := "[ ]"
+		if field.value == "true" {
+			box = "[" + theme.IconSuccess + "]"
+		}
+		if focused {
+			return theme.PrimaryStyle.Render(box) + theme.MutedStyle.Render("  space to toggle")
+		}
+		return box
+	case models.InputChoice:
+		value := "‹ " + field.value + " ›"
+		if focused {
+			return theme.PrimaryStyle.Render(value) + theme.MutedStyle.Render(fmt.Sprintf("  ←/→ %d options", len(field.param.Choices())))
+		}
+		return value
+	}
+	return field.text.View()
+}
@@ -0,0 +1,185 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/elogrono/jenkins-tui/internal/jenkins"
+	"github.com/elogrono/jenkins-tui/internal/jenkins/models"
+	"github.com/elogrono/jenkins-tui/internal/ui/theme"
+)
+
+// unsafeJobNameChars are the characters Jenkins refuses in item names
+const unsafeJobNameChars = `?*/\%!@#$^&|<>[]:;`
+
+// copyPrompt asks for the name of the copy of a job
+type copyPrompt struct {
+	source string
+	input  textinput.Model
+}
+
+// newCopyPrompt starts the prompt with a name derived from the source job
+func newCopyPrompt(source string) *copyPrompt {
+	input := textinput.New()
+	input.Placeholder = "Name of the new job"
+	input.Width = 40
+	input.Prompt = theme.IconFile + " "
+	input.SetValue(jobShortName(source) + "-copy")
+	input.CursorEnd()
+	input.Focus()
+	return &copyPrompt{source: source, input: input}
+}
+
+// view renders the prompt above the shortcuts of a job list
+func (p *copyPrompt) view(width int) string {
+	return theme.MutedStyle.Render("Copy "+p.source+" as:") + "\n" +
+		theme.SearchBarStyle.Width(maxInt(width-4, 20)).Render(p.input.View())
+}
+
+// jobShortName returns the name of a job without its folders
+func jobShortName(jobName string) string {
+	return jobName[strings.LastIndex(jobName, "/")+1:]
+}
+
+// jobFolder returns the folder holding a job, "" for the root, where Jenkins
+// checks Job/Create for copies of it
+func jobFolder(jobName string) string {
+	if i := strings.LastIndex(jobName, "/"); i >= 0 {
+		return jobName[:i]
+	}
+	return ""
+}
+
+// validateJobName applies the naming rules Jenkins enforces on new items
+func validateJobName(name string) error {
+	if name == "" {
+		return fmt.Errorf("the name is empty")
+	}
+	if name == "." || name == ".." {
+		return fmt.Errorf("%q is not a valid name", name)
+	}
+	if i := strings.IndexAny(name, unsafeJobNameChars); i >= 0 {
+		return fmt.Errorf("%q is not allowed in a job name", name[i:i+1])
+	}
+	return nil
+}
+
+// toggleJobAction returns the confirmation enabling or disabling a job, or
+// the notice explaining why the action is not available
+func toggleJobAction(client *jenkins.Client, job models.Job) (*confirmAction, string) {
+	if !canPerform(client, jenkins.PermConfigure, job.Name, 0) {
+		return nil, blockedNotice(client, jenkins.PermConfigure)
+	}
+	name := job.Name
+	if job.IsDisabled() {
+		return &confirmAction{
+			prompt: fmt.Sprintf("Enable %s?", name),
+			run: runAction(name+" enabled", func(ctx context.Context) error {
+				return client.EnableJob(ctx, name)
+			}),
+		}, ""
+	}
+	return &confirmAction{
+		prompt: fmt.Sprintf("Disable %s? It will not build until enabled again", name),
+		run: runAction(name+" disabled", func(ctx context.Context) error {
+			return client.DisableJob(ctx, name)
+		}),
+	}, ""
+}
+
+// copyJobAction returns the confirmation copying a job under a new name in
+// the same folder, or the notice explaining why it cannot be copied
+func copyJobAction(client *jenkins.Client, source, newName string) (*confirmAction, string) {
+	if err := validateJobName(newName); err != nil {
+		return nil, "Copy: " + err.Error()
+	}
+	if newName == jobShortName(source) {
+		return nil, "Copy: choose a name other than the original"
+	}
+	return &confirmAction{
+		prompt: fmt.Sprintf("Copy %s to %s?", source, newName),
+		run: runAction(fmt.Sprintf("Copied %s to %s", source, newName), func(ctx context.Context) error {
+			return client.CopyJob(ctx, source, newName)
+		}),
+	}, ""
+}
+
+// deleteJobAction returns the confirmation deleting a job, or the notice
+// explaining why the action is not available
+func deleteJobAction(client *jenkins.Client, jobName string) (*confirmAction, string) {
+	if !canPerform(client, jenkins.PermDelete, jobName, 0) {
+		return nil, blockedNotice(client, jenkins.PermDelete)
+	}
+	return &confirmAction{
+		prompt: fmt.Sprintf("Delete %s and all its builds? This cannot be undone", jobName),
+		run: runAction(jobName+" deleted", func(ctx context.Context) error {
+			return client.DeleteJob(ctx, jobName)
+		}),
+	}, ""
+}
+
+// jobCopier holds the copy prompt of a job list model
+type jobCopier struct {
+	copyPrompt *copyPrompt
+}
+
+// openCopyPrompt asks for the name of a copy of the job, or returns the
+// notice explaining why it cannot be copied
+func (c *jobCopier) openCopyPrompt(client *jenkins.Client, job *models.Job) (tea.Cmd, string) {
+	if job == nil {
+		return nil, ""
+	}
+	if !canPerform(client, jenkins.PermCreate, jobFolder(job.Name), 0) {
+		return nil, blockedNotice(client, jenkins.PermCreate)
+	}
+	c.copyPrompt = newCopyPrompt(job.Name)
+	return textinput.Blink, ""
+}
+
+// updateCopyPrompt handles keys while the name of the copy is edited. Enter
+// closes the prompt with the confirmation of the copy, or keeps it open with
+// the notice rejecting the name.
+func (c *jobCopier) updateCopyPrompt(client *jenkins.Client, msg tea.KeyMsg) (tea.Cmd, *confirmAction, string) {
+	switch msg.String() {
+	case "esc":
+		c.copyPrompt = nil
+		return nil, nil, ""
+	case "enter":
+		prompt := c.copyPrompt
+		confirm, notice := copyJobAction(client, prompt.source, strings.TrimSpace(prompt.input.Value()))
+		if confirm != nil {
+			c.copyPrompt = nil
+		}
+		return nil, confirm, notice
+	}
+	var cmd tea.Cmd
+	c.copyPrompt.input, cmd = c.copyPrompt.input.Update(msg)
+	return cmd, nil, ""
+}
+
+// jobAt returns the job at index i of a list, or nil when out of range
+func jobAt(jobs []models.Job, i int) *models.Job {
+	if i < 0 || i >= len(jobs) {
+		return nil
+	}
+	return &jobs[i]
+}
+
+// selectedListJob returns the job selected in the job list, or nil
+func (m *BuildsModel) selectedListJob() *models.Job {
+	if m.mode != ModeJobList {
+		return nil
+	}
+	return jobAt(m.getFilteredJobs(), m.selectedJob)
+}
+
+// selectedListJob returns the job selected in the jobs of a view, or nil
+func (m *ViewsModel) selectedListJob() *models.Job {
+	if m.mode != ViewsModeJobs {
+		return nil
+	}
+	return jobAt(m.getFilteredJobs(), m.selectedJob)
+}
@@ -0,0 +1,344 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/elogrono/jenkins-tui/internal/cache"
+	"github.com/elogrono/jenkins-tui/internal/config"
+	"github.com/elogrono/jenkins-tui/internal/diff"
+	"github.com/elogrono/jenkins-tui/internal/jenkins"
+	"github.com/elogrono/jenkins-tui/internal/ui/components"
+	"github.com/elogrono/jenkins-tui/internal/ui/theme"
+)
+
+// configBackupDirName is the directory inside the config directory keeping
+// the config.xml a job had before it was saved from the UI
+const configBackupDirName = "backups"
+
+// errConfigChanged is returned when config.xml changed on Jenkins while it
+// was being edited
+var errConfigChanged = errors.New("config.xml changed on Jenkins since it was opened; press r to reload")
+
+// JobConfigMsg carries the config.xml of a job
+type JobConfigMsg struct {
+	JobName string
+	XML     string
+	Error   error
+}
+
+// JobConfigEditedMsg reports that the editor on config.xml exited
+type JobConfigEditedMsg struct {
+	JobName string
+	Dir     string // Temporary directory holding the edited file
+	Error   error
+}
+
+// JobConfigSavedMsg reports the outcome of saving config.xml
+type JobConfigSavedMsg struct {
+	JobName string
+	Backup  string // File keeping the previous configuration
+	Error   error
+}
+
+// openJobConfig shows the config.xml of the selected job
+func (m *BuildsModel) openJobConfig() tea.Cmd {
+	jobName := m.selectedJobName()
+	if jobName == "" {
+		return nil
+	}
+	m.configFrom = m.mode
+	m.mode = ModeJobConfig
+	m.configJob, m.configXML, m.configEdited = jobName, "", ""
+	m.viewport.SetContent("")
+	return m.fetchJobConfig(jobName)
+}
+
+// applyJobConfig shows the fetched config.xml
+func (m *BuildsModel) applyJobConfig(msg JobConfigMsg) {
+	m.loading = false
+	if m.mode != ModeJobConfig || msg.JobName != m.configJob {
+		return
+	}
+	if msg.Error != nil {
+		// A failed reload keeps the loaded configuration and any pending edit
+		if m.configXML == "" {
+			m.mode = m.configFrom
+		}
+		m.notice, m.noticeErr = "config.xml: "+msg.Error.Error(), true
+		if errors.Is(msg.Error, jenkins.ErrForbidden) {
+			m.notice = "config.xml: reading it needs Job/ExtendedRead or Job/Configure"
+		}
+		return
+	}
+	// A pending edit survives a reload and is diffed against the new version
+	m.configXML = msg.XML
+	if m.configEdited == m.configXML {
+		m.configEdited = ""
+	}
+	m.renderJobConfig()
+}
+
+// renderJobConfig puts the XML, or the diff of a pending edit, in the viewport
+func (m *BuildsModel) renderJobConfig() {
+	if m.configEdited == "" {
+		m.viewport.SetContent(formatArtifact("config.xml", m.configXML, false))
+	} else {
+		m.viewport.SetContent(strings.Join(colorDiff(m.configDiff()), "\n"))
+	}
+	m.viewport.GotoTop()
+}
+
+// configDiff returns the unified diff of the pending edit
+func (m *BuildsModel) configDiff() []string {
+	unified := diff.Unified("a/config.xml", "b/config.xml", splitConfigLines(m.configXML), splitConfigLines(m.configEdited), 3)
+	return strings.Split(strings.TrimRight(unified, "\n"), "\n")
+}
+
+// splitConfigLines splits a configuration into lines, ignoring line endings
+func splitConfigLines(xml string) []string {
+	return strings.Split(strings.TrimRight(strings.ReplaceAll(xml, "\r\n", "\n"), "\n"), "\n")
+}
+
+// diffStat counts the added and removed lines of a unified diff
+func diffStat(lines []string) (added, removed int) {
+	for _, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+		case strings.HasPrefix(line, "+"):
+			added++
+		case strings.HasPrefix(line, "-"):
+			removed++
+		}
+	}
+	return added, removed
+}
+
+// editJobConfig opens config.xml, or the pending edit, in $EDITOR
+func (m *BuildsModel) editJobConfig() tea.Cmd {
+	if m.configXML == "" {
+		return nil
+	}
+	if !canPerform(m.client, jenkins.PermConfigure, m.configJob, 0) {
+		m.notice, m.noticeErr = blockedNotice(m.client, jenkins.PermConfigure), true
+		return nil
+	}
+
+	content := m.configXML
+	if m.configEdited != "" {
+		content = m.configEdited
+	}
+	dir, err := os.MkdirTemp("", "jenkins-tui-config-")
+	if err != nil {
+		m.notice, m.noticeErr = "config.xml: "+err.Error(), true
+		return nil
+	}
+	file := filepath.Join(dir, "config.xml")
+	if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
+		os.RemoveAll(dir)
+		m.notice, m.noticeErr = "config.xml: "+err.Error(), true
+		return nil
+	}
+
+	jobName := m.configJob
+	return tea.ExecProcess(editorCommand([]string{file}), func(err error) tea.Msg {
+		return JobConfigEditedMsg{JobName: jobName, Dir: dir, Error: err}
+	})
+}
+
+// applyJobConfigEdited shows the diff of the edited config.xml for review
+func (m *BuildsModel) applyJobConfigEdited(msg JobConfigEditedMsg) {
+	defer os.RemoveAll(msg.Dir)
+	if msg.JobName != m.configJob {
+		return
+	}
+	if msg.Error != nil {
+		m.notice, m.noticeErr = "Edit cancelled: "+msg.Error.Error(), true
+		return
+	}
+	edited, err := os.ReadFile(filepath.Join(msg.Dir, "config.xml"))
+	if err != nil {
+		m.notice, m.noticeErr = "config.xml: "+err.Error(), true
+		return
+	}
+
+	m.configEdited = string(edited)
+	if m.configEdited == m.configXML {
+		m.configEdited = ""
+		m.notice, m.noticeErr = "No changes to config.xml", false
+	} else {
+		m.notice, m.noticeErr = "Review the changes; Enter saves them", false
+	}
+	m.renderJobConfig()
+}
+
+// discardJobConfigEdit drops the pending edit, or leaves the view without one
+func (m *BuildsModel) discardJobConfigEdit() {
+	if m.configEdited != "" {
+		m.configEdited = ""
+		m.notice, m.noticeErr = "Changes discarded", false
+		m.renderJobConfig()
+		return
+	}
+	m.mode = m.configFrom
+	m.configJob, m.configXML = "", ""
+	m.viewport.SetContent("")
+}
+
+// requestSaveJobConfig asks for confirmation before saving the pending edit
+func (m *BuildsModel) requestSaveJobConfig() {
+	if m.configEdited == "" {
+		return
+	}
+	if !canPerform(m.client, jenkins.PermConfigure, m.configJob, 0) {
+		m.notice, m.noticeErr = blockedNotice(m.client, jenkins.PermConfigure), true
+		return
+	}
+	added, removed := diffStat(m.configDiff())
+	m.confirm = &confirmAction{
+		prompt: fmt.Sprintf("Save config.xml of %s (+%d -%d lines)?", m.configJob, added, removed),
+		run:    m.saveJobConfig(m.configJob, m.configXML, m.configEdited),
+	}
+}
+
+// saveJobConfig backs up the configuration on Jenkins and replaces it,
+// unless it changed since it was fetched
+func (m *BuildsModel) saveJobConfig(jobName, original, edited string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		current, err := m.client.GetJobConfig(ctx, jobName)
+		if err != nil {
+			return JobConfigSavedMsg{JobName: jobName, Error: err}
+		}
+		if current != original {
+			return JobConfigSavedMsg{JobName: jobName, Error: errConfigChanged}
+		}
+
+		dir, err := jobConfigBackupDir()
+		if err != nil {
+			return JobConfigSavedMsg{JobName: jobName, Error: err}
+		}
+		backup, err := backupJobConfig(dir, m.client.ProfileName(), jobName, current, time.Now())
+		if err != nil {
+			return JobConfigSavedMsg{JobName: jobName, Error: fmt.Errorf("backup failed, nothing saved: %w", err)}
+		}
+
+		if err := m.client.UpdateJobConfig(ctx, jobName, edited); err != nil {
+			return JobConfigSavedMsg{JobName: jobName, Error: err}
+		}
+		return JobConfigSavedMsg{JobName: jobName, Backup: backup}
+	}
+}
+
+// applyJobConfigSaved reports the save and reloads the configuration, which
+// Jenkins may have normalized
+func (m *BuildsModel) applyJobConfigSaved(msg JobConfigSavedMsg) tea.Cmd {
+	if msg.Error != nil {
+		m.notice, m.noticeErr = "Save failed: "+msg.Error.Error(), true
+		return nil
+	}
+	m.notice, m.noticeErr = "config.xml saved; previous version in "+msg.Backup, false
+	if m.mode != ModeJobConfig || msg.JobName != m.configJob {
+		return nil
+	}
+	m.configEdited = ""
+	return m.fetchJobConfig(msg.JobName)
+}
+
+// jobConfigBackupDir returns the backup directory next to the configuration file
+func jobConfigBackupDir() (string, error) {
+	configPath, err := config.ConfigPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(configPath), configBackupDirName), nil
+}
+
+// backupJobConfig writes a job's configuration to a timestamped file under
+// dir/<profile>/<job>/ and returns its path. The profile and job are escaped
+// by cache.Key, so a job in a folder gets one directory ("folder%2Fjob") that
+// no other job maps to. An existing backup is never overwritten: saves within
+// the same millisecond get a numbered name.
+func backupJobConfig(dir, profile, jobName, xml string, now time.Time) (string, error) {
+	jobDir := filepath.Join(dir, filepath.FromSlash(cache.Key(profile, jobName)))
+	if err := os.MkdirAll(jobDir, 0o700); err != nil {
+		return "", err
+	}
+	stamp := "config-" + now.Format("20060102-150405.000")
+	for n := 0; ; n++ {
+		name := stamp + ".xml"
+		if n > 0 {
+			name = fmt.Sprintf("%s-%d.xml", stamp, n)
+		}
+		path := filepath.Join(jobDir, name)
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+		if errors.Is(err, os.ErrExist) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		if _, err := f.WriteString(xml); err != nil {
+			f.Close()
+			return "", err
+		}
+		return path, f.Close()
+	}
+}
+
+// viewJobConfig renders config.xml, or the diff of a pending edit
+func (m *BuildsModel) viewJobConfig() string {
+	crumb := "config.xml"
+	if m.configEdited != "" {
+		crumb = "config.xml (modified)"
+	}
+	breadcrumb := components.NewBreadcrumb("Builds", m.configJob, crumb).Render()
+
+	var statusItems []string
+	switch {
+	case m.loading && m.configXML == "":
+		statusItems = append(statusItems, m.spinner.View()+" Loading config.xml...")
+	case m.configEdited != "":
+		added, removed := diffStat(m.configDiff())
+		statusItems = append(statusItems,
+			theme.WarningStyle.Render("Unsaved changes"),
+			lipgloss.NewStyle().Foreground(theme.BuildSuccess).Render(fmt.Sprintf("+%d", added))+" "+
+				lipgloss.NewStyle().Foreground(theme.BuildFailure).Render(fmt.Sprintf("-%d", removed)))
+	default:
+		statusItems = append(statusItems, theme.MutedStyle.Render(fmt.Sprintf("%d lines", len(splitConfigLines(m.configXML)))))
+	}
+	statusBar := strings.Join(statusItems, " │ ")
+
+	m.viewport.Width = m.width - 2
+	m.viewport.Height = m.height - 10
+
+	return lipgloss.NewStyle().
+		Width(m.width).
+		Height(m.height-4).
+		Padding(0, 1).
+		Render(lipgloss.JoinVertical(lipgloss.Left,
+			breadcrumb,
+			statusBar,
+			m.viewport.View(),
+			theme.MutedStyle.Render(fmt.Sprintf(" %d%% ", int(m.viewport.ScrollPercent()*100))),
+			m.renderShortcuts()))
+}
+
+func (m *BuildsModel) fetchJobConfig(jobName string) tea.Cmd {
+	m.loading = true
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		xml, err := m.client.GetJobConfig(ctx, jobName)
+		return JobConfigMsg{JobName: jobName, XML: xml, Error: err}
+	}
+}
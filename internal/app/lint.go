@@ -0,0 +1,215 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/elogrono/jenkins-tui/internal/jenkins/models"
+	"github.com/elogrono/jenkins-tui/internal/ui/components"
+	"github.com/elogrono/jenkins-tui/internal/ui/theme"
+)
+
+// LintResultMsg carries the linter verdict on a local Jenkinsfile
+type LintResultMsg struct {
+	Path   string
+	Source string // File content that was linted
+	Result *models.LintResult
+	Error  error
+}
+
+// LintEditedMsg reports that the editor on the linted file exited
+type LintEditedMsg struct {
+	Error error
+}
+
+// openLint shows the linter, asking for the Jenkinsfile to check
+func (m *BuildsModel) openLint() tea.Cmd {
+	if m.mode != ModeLint {
+		m.lintFrom = m.mode
+		m.mode = ModeLint
+		m.lintScroll = 0
+	}
+	path := m.lintPath
+	if path == "" {
+		path = "Jenkinsfile"
+	}
+	m.lintInput.SetValue(path)
+	m.lintInput.CursorEnd()
+	m.lintPrompting = true
+	m.lintInput.Focus()
+	return textinput.Blink
+}
+
+// closeLint returns to the view the linter was opened from
+func (m *BuildsModel) closeLint() {
+	m.mode = m.lintFrom
+	m.lintPrompting = false
+	m.lintInput.Blur()
+}
+
+// updateLintPrompt handles keys while the Jenkinsfile path is edited
+func (m *BuildsModel) updateLintPrompt(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc":
+		m.lintPrompting = false
+		m.lintInput.Blur()
+		if m.lintResult == nil && m.lintErr == nil {
+			m.closeLint()
+		}
+		return nil
+	case "enter":
+		path := expandHome(strings.TrimSpace(m.lintInput.Value()))
+		if path == "" {
+			return nil
+		}
+		m.lintPrompting = false
+		m.lintInput.Blur()
+		m.lintPath = path
+		return m.runLint()
+	}
+	var cmd tea.Cmd
+	m.lintInput, cmd = m.lintInput.Update(msg)
+	return cmd
+}
+
+// runLint reads the local Jenkinsfile and sends it to the linter
+func (m *BuildsModel) runLint() tea.Cmd {
+	if m.lintPath == "" {
+		return nil
+	}
+	path := m.lintPath
+	m.lintRunning = true
+	return func() tea.Msg {
+		source, err := os.ReadFile(path)
+		if err != nil {
+			return LintResultMsg{Path: path, Error: err}
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		result, err := m.client.Lint(ctx, string(source))
+		return LintResultMsg{Path: path, Source: string(source), Result: result, Error: err}
+	}
+}
+
+// applyLintResult stores the verdict unless another file was picked since
+func (m *BuildsModel) applyLintResult(msg LintResultMsg) {
+	if msg.Path != m.lintPath {
+		return
+	}
+	m.lintRunning = false
+	m.lintResult, m.lintErr = msg.Result, msg.Error
+	m.lintSource = strings.Split(strings.ReplaceAll(msg.Source, "\r\n", "\n"), "\n")
+	m.lintScroll = 0
+}
+
+// editLintFile opens the linted file in $EDITOR and lints it again afterwards
+func (m *BuildsModel) editLintFile() tea.Cmd {
+	if m.lintPath == "" {
+		return nil
+	}
+	return tea.ExecProcess(editorCommand([]string{m.lintPath}), func(err error) tea.Msg {
+		return LintEditedMsg{Error: err}
+	})
+}
+
+// lintLines renders the verdict with the offending source line under each error
+func (m *BuildsModel) lintLines(width int) []string {
+	if m.lintErr != nil {
+		return []string{theme.ErrorStyle.Render(theme.IconFailure + " " + m.lintErr.Error())}
+	}
+	if m.lintResult == nil {
+		return nil
+	}
+	if m.lintResult.Valid {
+		return []string{theme.SuccessStyle.Render(theme.IconSuccess + " Jenkinsfile successfully validated")}
+	}
+
+	lines := []string{
+		theme.ErrorStyle.Render(fmt.Sprintf("%s %d error(s)", theme.IconFailure, len(m.lintResult.Errors))),
+	}
+	for _, e := range m.lintResult.Errors {
+		lines = append(lines, "")
+		pos := e.Position()
+		if pos == "" {
+			lines = append(lines, truncate(e.Message, width))
+			continue
+		}
+		lines = append(lines, theme.AccentStyle.Render(pos)+"  "+truncate(e.Message, maxInt(width-len(pos)-2, 10)))
+		if e.Line > len(m.lintSource) {
+			continue
+		}
+		gutter := fmt.Sprintf("%5d │ ", e.Line)
+		gutterWidth := lipgloss.Width(gutter)
+		source := strings.ReplaceAll(m.lintSource[e.Line-1], "\t", " ")
+		lines = append(lines, theme.MutedStyle.Render(gutter)+truncate(source, maxInt(width-gutterWidth, 10)))
+		if e.Column > 0 && e.Column-1 < width-gutterWidth {
+			lines = append(lines, strings.Repeat(" ", gutterWidth+e.Column-1)+theme.ErrorStyle.Render("^"))
+		}
+	}
+	return lines
+}
+
+// lintRows returns how many verdict lines fit on screen
+func (m *BuildsModel) lintRows() int {
+	return maxInt(m.height-16, 3)
+}
+
+// scrollLint moves the verdict window by delta lines
+func (m *BuildsModel) scrollLint(delta int) {
+	total := len(m.lintLines(m.width - 12))
+	m.lintScroll = maxInt(0, minInt(m.lintScroll+delta, total-m.lintRows()))
+}
+
+// viewLint renders the path prompt and the verdict of the linter
+func (m *BuildsModel) viewLint() string {
+	breadcrumb := components.NewBreadcrumb("Builds", "Lint", filepath.Base(m.lintPath)).Render()
+
+	rows := []string{theme.SectionTitleStyle.Render("Declarative Jenkinsfile linter")}
+	if m.lintPrompting {
+		rows = append(rows, theme.MutedStyle.Render("Jenkinsfile to lint:"),
+			theme.SearchBarStyle.Width(maxInt(m.width-14, 20)).Render(m.lintInput.View()))
+	} else if m.lintPath != "" {
+		rows = append(rows, theme.MutedStyle.Render("File: ")+m.lintPath)
+	}
+	rows = append(rows, "")
+
+	if m.lintRunning {
+		rows = append(rows, theme.MutedStyle.Render("Linting..."))
+	} else {
+		lines := m.lintLines(m.width - 12)
+		end := minInt(m.lintScroll+m.lintRows(), len(lines))
+		rows = append(rows, lines[minInt(m.lintScroll, end):end]...)
+		if len(lines) > m.lintRows() {
+			rows = append(rows, theme.MutedStyle.Render(fmt.Sprintf("Lines %d-%d of %d", m.lintScroll+1, end, len(lines))))
+		}
+	}
+
+	border := theme.Border
+	switch {
+	case m.lintResult != nil && m.lintResult.Valid:
+		border = theme.Success
+	case m.lintResult != nil || m.lintErr != nil:
+		border = theme.Error
+	}
+	panel := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(border).
+		Width(m.width-6).
+		Padding(1, 2).
+		Render(strings.Join(rows, "\n"))
+
+	content := lipgloss.JoinVertical(lipgloss.Left, breadcrumb, panel, m.renderShortcuts())
+
+	return lipgloss.NewStyle().
+		Width(m.width).
+		Height(m.height-4).
+		Padding(0, 1).
+		Render(content)
+}
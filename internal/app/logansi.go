@@ -0,0 +1,269 @@
+package app
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// consoleNoteRe matches Jenkins console notes: serialized annotations hidden
+// in the log as ESC[8mha:<base64>ESC[0m, or left bare once escapes are lost
+var consoleNoteRe = regexp.MustCompile(`\x1b\[8mha:[A-Za-z0-9+/=]*\x1b\[0m|ha:////[A-Za-z0-9+/=]+`)
+
+// ansiStyle is the SGR state of console text
+type ansiStyle struct {
+	fg, bg                                          string // lipgloss colors, empty for the default
+	bold, faint, italic, underline, reverse, strike bool
+}
+
+// logRun is a colored part of a log line, a byte range of its text
+type logRun struct {
+	start, end int
+	style      ansiStyle
+}
+
+// logLine is a console log line with its escape codes parsed out
+type logLine struct {
+	text string
+	runs []logRun // Nil for a line the console did not color
+}
+
+// parseLog strips the console notes and escape codes from a log, keeping
+// the colors they set. Colors carry over to the next lines like they do in
+// a terminal.
+func parseLog(content string) []logLine {
+	content = consoleNoteRe.ReplaceAllString(content, "")
+	raw := strings.Split(content, "\n")
+	lines := make([]logLine, len(raw))
+	var style ansiStyle
+	for i, text := range raw {
+		lines[i], style = parseLogLine(text, style)
+	}
+	return lines
+}
+
+// logTexts returns the text of the lines, as search and grep see it
+func logTexts(lines []logLine) []string {
+	texts := make([]string, len(lines))
+	for i, line := range lines {
+		texts[i] = line.text
+	}
+	return texts
+}
+
+// parseLogLine parses a raw line starting in the given style, returning it
+// and the style at its end
+func parseLogLine(raw string, style ansiStyle) (logLine, ansiStyle) {
+	if style == (ansiStyle{}) && strings.IndexByte(raw, '\x1b') < 0 {
+		return logLine{text: raw}, style
+	}
+
+	var b strings.Builder
+	var runs []logRun
+	start := 0
+	flush := func() {
+		if b.Len() > start && style != (ansiStyle{}) {
+			runs = append(runs, logRun{start: start, end: b.Len(), style: style})
+		}
+		start = b.Len()
+	}
+	for i := 0; i < len(raw); {
+		if raw[i] != '\x1b' {
+			n := strings.IndexByte(raw[i:], '\x1b')
+			if n < 0 {
+				n = len(raw) - i
+			}
+			b.WriteString(raw[i : i+n])
+			i += n
+			continue
+		}
+		params, final, n := scanEscape(raw[i:])
+		i += n
+		if final == 'm' {
+			flush()
+			style = style.apply(params)
+		}
+	}
+	flush()
+	return logLine{text: b.String(), runs: runs}, style
+}
+
+// scanEscape reads the escape sequence at the start of s, returning the
+// parameters and final byte of a CSI sequence and the length read. Other
+// sequences, such as OSC hyperlinks, are skipped whole.
+func scanEscape(s string) (params string, final byte, n int) {
+	if len(s) < 2 {
+		return "", 0, len(s)
+	}
+	switch s[1] {
+	case '[':
+		for i := 2; i < len(s); i++ {
+			if c := s[i]; c >= 0x40 && c <= 0x7e {
+				return s[2:i], c, i + 1
+			}
+		}
+	case ']':
+		// Ends with BEL or ESC \
+		for i := 2; i < len(s); i++ {
+			if s[i] == '\a' {
+				return "", 0, i + 1
+			}
+			if s[i] == '\x1b' && i+1 < len(s) && s[i+1] == '\\' {
+				return "", 0, i + 2
+			}
+		}
+	default:
+		return "", 0, 2
+	}
+	return "", 0, len(s)
+}
+
+// apply returns the style after an SGR sequence with the given parameters
+func (s ansiStyle) apply(params string) ansiStyle {
+	codes := strings.Split(params, ";")
+	for i := 0; i < len(codes); i++ {
+		code, _ := strconv.Atoi(codes[i])
+		switch {
+		case code == 0:
+			s = ansiStyle{}
+		case code == 1:
+			s.bold = true
+		case code == 2:
+			s.faint = true
+		case code == 3:
+			s.italic = true
+		case code == 4:
+			s.underline = true
+		case code == 7:
+			s.reverse = true
+		case code == 9:
+			s.strike = true
+		case code == 21 || code == 22:
+			s.bold, s.faint = false, false
+		case code == 23:
+			s.italic = false
+		case code == 24:
+			s.underline = false
+		case code == 27:
+			s.reverse = false
+		case code == 29:
+			s.strike = false
+		case code >= 30 && code <= 37:
+			s.fg = strconv.Itoa(code - 30)
+		case code == 38:
+			s.fg, i = extendedColor(codes, i)
+		case code == 39:
+			s.fg = ""
+		case code >= 40 && code <= 47:
+			s.bg = strconv.Itoa(code - 40)
+		case code == 48:
+			s.bg, i = extendedColor(codes, i)
+		case code == 49:
+			s.bg = ""
+		case code >= 90 && code <= 97:
+			s.fg = strconv.Itoa(code - 90 + 8)
+		case code >= 100 && code <= 107:
+			s.bg = strconv.Itoa(code - 100 + 8)
+		}
+	}
+	return s
+}
+
+// extendedColor reads the 5;n (256 colors) or 2;r;g;b (true color) arguments
+// of the 38 or 48 code at i, returning the color and the index of its last
+// argument. Malformed arguments end the sequence.
+func extendedColor(codes []string, i int) (string, int) {
+	arg := func(j int) int {
+		n, _ := strconv.Atoi(codes[j])
+		return minInt(maxInt(n, 0), 255)
+	}
+	switch {
+	case i+2 < len(codes) && codes[i+1] == "5":
+		return strconv.Itoa(arg(i + 2)), i + 2
+	case i+4 < len(codes) && codes[i+1] == "2":
+		return fmt.Sprintf("#%02x%02x%02x", arg(i+2), arg(i+3), arg(i+4)), i + 4
+	}
+	return "", len(codes)
+}
+
+// lipgloss returns the style rendering text like the console colored it
+func (s ansiStyle) lipgloss() lipgloss.Style {
+	style := lipgloss.NewStyle().
+		Bold(s.bold).
+		Faint(s.faint).
+		Italic(s.italic).
+		Underline(s.underline).
+		Reverse(s.reverse).
+		Strikethrough(s.strike)
+	if s.fg != "" {
+		style = style.Foreground(lipgloss.Color(s.fg))
+	}
+	if s.bg != "" {
+		style = style.Background(lipgloss.Color(s.bg))
+	}
+	return style
+}
+
+// renderLogLine renders a log line in its console colors, or the color of
+// its keywords when it has none, with the search matches in it highlighted
+func renderLogLine(line logLine, spans []logSpan) string {
+	var keyword lipgloss.Style
+	useKeyword := false
+	if len(line.runs) == 0 {
+		keyword, useKeyword = logLineStyle(line.text)
+	}
+	if len(spans) == 0 && len(line.runs) == 0 {
+		if useKeyword {
+			return keyword.Render(line.text)
+		}
+		return line.text
+	}
+
+	cuts := []int{0, len(line.text)}
+	for _, run := range line.runs {
+		cuts = append(cuts, run.start, run.end)
+	}
+	for _, span := range spans {
+		cuts = append(cuts, span.start, span.end)
+	}
+	sort.Ints(cuts)
+
+	var b strings.Builder
+	for i := 1; i < len(cuts); i++ {
+		start, end := cuts[i-1], cuts[i]
+		if start == end {
+			continue
+		}
+		text := line.text[start:end]
+		if style, ok := segmentStyle(line, spans, start); ok {
+			text = style.Render(text)
+		} else if useKeyword {
+			text = keyword.Render(text)
+		}
+		b.WriteString(text)
+	}
+	return b.String()
+}
+
+// segmentStyle returns the style of the text at pos: a search match first,
+// then the console colors
+func segmentStyle(line logLine, spans []logSpan, pos int) (lipgloss.Style, bool) {
+	for _, span := range spans {
+		if pos >= span.start && pos < span.end {
+			if span.current {
+				return logCurrentMatchStyle, true
+			}
+			return logMatchStyle, true
+		}
+	}
+	for _, run := range line.runs {
+		if pos >= run.start && pos < run.end {
+			return run.style.lipgloss(), true
+		}
+	}
+	return lipgloss.Style{}, false
+}
@@ -0,0 +1,61 @@
+package app
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseLog(t *testing.T) {
+	note := "\x1b[8mha:////4DiQpW4fcoTIpJ+Zq0AAAA=\x1b[0m"
+	log := note + "[Pipeline] sh\n" +
+		"+ make \x1b[1;31mFAILED\x1b[0m in \x1b[38;5;208m3s\x1b[K\n" +
+		"\x1b[32mok\n" +
+		"still green\x1b[m\n" +
+		"\x1b[38;2;255;0;10mtrue color\x1b[39m plain"
+	lines := parseLog(log)
+
+	want := []string{"[Pipeline] sh", "+ make FAILED in 3s", "ok", "still green", "true color plain"}
+	if got := logTexts(lines); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected notes and escapes stripped, got %q", got)
+	}
+	if lines[0].runs != nil {
+		t.Error("expected the note line uncolored")
+	}
+	runs := []logRun{
+		{start: 7, end: 13, style: ansiStyle{fg: "1", bold: true}},
+		{start: 17, end: 19, style: ansiStyle{fg: "208"}},
+	}
+	if !reflect.DeepEqual(lines[1].runs, runs) {
+		t.Errorf("unexpected runs %+v", lines[1].runs)
+	}
+	// Colors carry over to the next line until reset
+	if len(lines[3].runs) != 1 || lines[3].runs[0].style.fg != "2" {
+		t.Errorf("expected the green to carry over, got %+v", lines[3].runs)
+	}
+	if len(lines[4].runs) != 1 || lines[4].runs[0].style.fg != "#ff000a" || lines[4].runs[0].end != 10 {
+		t.Errorf("expected a true color run, got %+v", lines[4].runs)
+	}
+}
+
+func TestColoredLogSearch(t *testing.T) {
+	m := newLogModel("\x1b[8mha:////AAAA\x1b[0mStarted by user\n\x1b[31mERROR\x1b[0m: exit 1\nERROR: plain")
+
+	// Search and grep see the text as displayed
+	searchLog(m, "by user|error: exit")
+	if len(m.logMatches) != 2 || m.logMatches[1].start != 0 || m.logMatches[1].end != 11 {
+		t.Fatalf("expected matches in the stripped text, got %+v", m.logMatches)
+	}
+	if view := m.viewport.View(); !strings.Contains(view, "1│ Started by user") || strings.Contains(view, "ha:") {
+		t.Errorf("expected the console note hidden, got:\n%s", view)
+	}
+
+	// Raw escape codes never reach the screen
+	if got := renderLogLine(m.logLines[1], nil); strings.Contains(got, "\x1b[31m") {
+		t.Errorf("expected the console colors rendered, got %q", got)
+	}
+	// Keyword colors are only for lines the console left uncolored
+	if m.logLines[1].runs == nil || m.logLines[2].runs != nil {
+		t.Error("expected only the console colored line to have runs")
+	}
+}
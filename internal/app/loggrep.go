@@ -0,0 +1,237 @@
+package app
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/elogrono/jenkins-tui/internal/ui/theme"
+)
+
+// maxGrepContext bounds the context lines shown around grep matches
+const maxGrepContext = 20
+
+// logGrep is the grep mode of the log viewer: only the lines matching the
+// pattern, or not matching it, with some context around them
+type logGrep struct {
+	pattern string
+	re      *regexp.Regexp
+	literal bool // pattern is not a valid expression
+	invert  bool // Show the lines not matching, like grep -v
+	context int
+	active  bool // The filtered log is shown rather than the full one
+
+	rows  []int       // Original line of each row shown, -1 for a gap
+	rowOf map[int]int // Row of each original line shown
+	hits  map[int]bool
+}
+
+// newGrepInput returns the input the grep pattern is entered in
+func newGrepInput() textinput.Model {
+	input := textinput.New()
+	input.Placeholder = "Show only lines matching..."
+	input.Width = 40
+	input.Prompt = theme.IconFilter + " "
+	return input
+}
+
+// grepLines selects the lines to show in grep mode: the lines matching re
+// (or not, when inverted) and the context lines around them. Rows of -1 mark
+// the gaps between groups of lines that are not adjacent.
+func grepLines(lines []string, re *regexp.Regexp, invert bool, context int) (rows []int, hits map[int]bool) {
+	keep := make([]bool, len(lines))
+	hits = make(map[int]bool)
+	for i, line := range lines {
+		if re.MatchString(line) == invert {
+			continue
+		}
+		hits[i] = true
+		for j := maxInt(i-context, 0); j <= minInt(i+context, len(lines)-1); j++ {
+			keep[j] = true
+		}
+	}
+	last := -1
+	for i, kept := range keep {
+		if !kept {
+			continue
+		}
+		if last >= 0 && i > last+1 {
+			rows = append(rows, -1)
+		}
+		rows = append(rows, i)
+		last = i
+	}
+	return rows, hits
+}
+
+// openGrepPrompt asks for the pattern of grep mode
+func (m *BuildsModel) openGrepPrompt() tea.Cmd {
+	m.grepInput.SetValue(m.grep.pattern)
+	m.grepInput.CursorEnd()
+	m.grepPrompting = true
+	m.grepInput.Focus()
+	return textinput.Blink
+}
+
+// updateGrepPrompt handles keys while the grep pattern is edited
+func (m *BuildsModel) updateGrepPrompt(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc":
+		m.grepPrompting = false
+		m.grepInput.Blur()
+		return nil
+	case "enter":
+		m.grepPrompting = false
+		m.grepInput.Blur()
+		top := m.topLogLine()
+		m.grep.pattern = m.grepInput.Value()
+		m.grep.re, m.grep.literal = nil, false
+		m.grep.active = m.grep.pattern != ""
+		if m.grep.active {
+			m.grep.re, m.grep.literal = compileLogSearch(m.grep.pattern)
+		}
+		m.refreshGrep(top)
+		return nil
+	}
+	var cmd tea.Cmd
+	m.grepInput, cmd = m.grepInput.Update(msg)
+	return cmd
+}
+
+// toggleGrep switches between the filtered and the full log, keeping the
+// line at the top of the screen
+func (m *BuildsModel) toggleGrep() {
+	if m.grep.re == nil {
+		return
+	}
+	top := m.topLogLine()
+	m.grep.active = !m.grep.active
+	m.refreshGrep(top)
+}
+
+// invertGrep switches between the matching and the non-matching lines
+func (m *BuildsModel) invertGrep() {
+	if m.grep.re == nil {
+		return
+	}
+	top := m.topLogLine()
+	m.grep.invert = !m.grep.invert
+	m.grep.active = true
+	m.refreshGrep(top)
+}
+
+// changeGrepContext shows more or fewer lines around the matches
+func (m *BuildsModel) changeGrepContext(delta int) {
+	if !m.grep.active {
+		return
+	}
+	context := minInt(maxInt(m.grep.context+delta, 0), maxGrepContext)
+	if context == m.grep.context {
+		return
+	}
+	top := m.topLogLine()
+	m.grep.context = context
+	m.refreshGrep(top)
+}
+
+// refreshGrep selects the lines again and renders the log with the given
+// original line, or the first one shown after it, at the top
+func (m *BuildsModel) refreshGrep(top int) {
+	m.selectGrepLines()
+	m.findLogSearchMatches()
+	m.viewport.SetContent(m.formatLogContent())
+	m.scrollToLogLine(top)
+}
+
+// selectGrepLines computes the rows of grep mode from the log content
+func (m *BuildsModel) selectGrepLines() {
+	m.grep.rows, m.grep.rowOf, m.grep.hits = nil, nil, nil
+	if !m.grep.active || m.grep.re == nil {
+		return
+	}
+	m.grep.rows, m.grep.hits = grepLines(logTexts(m.logLines), m.grep.re, m.grep.invert, m.grep.context)
+	m.grep.rowOf = make(map[int]int, len(m.grep.rows))
+	for row, line := range m.grep.rows {
+		if line >= 0 {
+			m.grep.rowOf[line] = row
+		}
+	}
+}
+
+// logRow returns the row an original log line is shown on, if it is shown
+func (m *BuildsModel) logRow(line int) (int, bool) {
+	if !m.grep.active {
+		return line, true
+	}
+	row, ok := m.grep.rowOf[line]
+	return row, ok
+}
+
+// topLogLine returns the original line at the top of the screen
+func (m *BuildsModel) topLogLine() int {
+	if !m.grep.active {
+		return m.viewport.YOffset
+	}
+	for row := m.viewport.YOffset; row < len(m.grep.rows); row++ {
+		if m.grep.rows[row] >= 0 {
+			return m.grep.rows[row]
+		}
+	}
+	return 0
+}
+
+// scrollToLogLine scrolls the original line, or the first one shown after
+// it, to the top of the screen
+func (m *BuildsModel) scrollToLogLine(line int) {
+	if !m.grep.active {
+		m.viewport.SetYOffset(line)
+		return
+	}
+	for row, shown := range m.grep.rows {
+		if shown >= line {
+			m.viewport.SetYOffset(row)
+			return
+		}
+	}
+	m.viewport.GotoBottom()
+}
+
+// formatGrepLines renders the rows of grep mode with their original line
+// numbers; context lines are numbered with a dotted bar
+func (m *BuildsModel) formatGrepLines(lines []logLine, numWidth int, spans map[int][]logSpan) string {
+	formatted := make([]string, 0, len(m.grep.rows))
+	for _, i := range m.grep.rows {
+		if i < 0 {
+			formatted = append(formatted, theme.MutedStyle.Render(strings.Repeat(" ", numWidth)+"┆"))
+			continue
+		}
+		bar := "│"
+		if !m.grep.hits[i] {
+			bar = "┆"
+		}
+		lineNum := theme.MutedStyle.Render(fmt.Sprintf("%*d%s ", numWidth, i+1, bar))
+		formatted = append(formatted, lineNum+renderLogLine(lines[i], spans[i]))
+	}
+	return strings.Join(formatted, "\n")
+}
+
+// grepStatus describes grep mode for the log status bar
+func (m *BuildsModel) grepStatus() string {
+	if !m.grep.active {
+		return ""
+	}
+	flag := "grep"
+	if m.grep.invert {
+		flag = "grep -v"
+	}
+	status := fmt.Sprintf("%s %q: %d lines", flag, m.grep.pattern, len(m.grep.hits))
+	if m.grep.context > 0 {
+		status += fmt.Sprintf(", %d context", m.grep.context)
+	}
+	if m.grep.literal {
+		status += " (invalid regex, matched literally)"
+	}
+	return status
+}
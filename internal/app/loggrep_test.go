@@ -0,0 +1,99 @@
+package app
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestGrepLines(t *testing.T) {
+	lines := []string{"a", "ERROR x", "b", "c", "d", "e", "ERROR y", "f"}
+	re := regexp.MustCompile("ERROR")
+
+	rows, hits := grepLines(lines, re, false, 0)
+	if !reflect.DeepEqual(rows, []int{1, -1, 6}) || len(hits) != 2 {
+		t.Errorf("expected the two matches with a gap, got %v", rows)
+	}
+	// Overlapping context merges the groups
+	rows, _ = grepLines(lines, re, false, 2)
+	if !reflect.DeepEqual(rows, []int{0, 1, 2, 3, 4, 5, 6, 7}) {
+		t.Errorf("expected the context to join up, got %v", rows)
+	}
+	rows, hits = grepLines(lines, re, true, 0)
+	if !reflect.DeepEqual(rows, []int{0, -1, 2, 3, 4, 5, -1, 7}) || hits[1] {
+		t.Errorf("expected the lines not matching, got %v", rows)
+	}
+}
+
+func TestGrepMode(t *testing.T) {
+	var lines []string
+	for i := 1; i <= 100; i++ {
+		line := fmt.Sprintf("step %d", i)
+		if i%10 == 0 {
+			line += " WARN slow"
+		}
+		lines = append(lines, line)
+	}
+	m := newLogModel(strings.Join(lines, "\n"))
+	m.viewport.SetYOffset(35)
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("f")})
+	if !m.InputActive() {
+		t.Fatal("expected the grep prompt")
+	}
+	m.grepInput.SetValue("warn")
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if !m.grep.active || len(m.grep.rows) != 19 {
+		t.Fatalf("expected 10 matches and 9 gaps, got %v", m.grep.rows)
+	}
+	// Original line numbers, starting at the first match below the old top
+	view := m.viewport.View()
+	if !strings.Contains(view, "40│ step 40 WARN slow") || strings.Contains(view, "30│") {
+		t.Errorf("expected line 40 at the top, got:\n%s", view)
+	}
+	if status := m.grepStatus(); status != `grep "warn": 10 lines` {
+		t.Errorf("unexpected status %q", status)
+	}
+
+	// Search only finds what grep shows
+	searchLog(m, "step 5")
+	if len(m.logMatches) != 1 || m.logMatches[0].line != 49 {
+		t.Errorf("expected only step 50 matched, got %v", m.logMatches)
+	}
+
+	// Line 100 is the last, so it has no context after it
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("+")})
+	if m.grep.context != 1 || len(m.grep.rows) != 10*3-1+9 {
+		t.Errorf("expected a line of context around each match, got %d rows", len(m.grep.rows))
+	}
+	if view := m.viewport.View(); !strings.Contains(view, "41┆ step 41") {
+		t.Errorf("expected context lines with a dotted bar, got:\n%s", view)
+	}
+
+	// The full log comes back at the same place, and Esc leaves it there
+	top := m.topLogLine()
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.grep.active || m.mode != ModeLogView || m.viewport.YOffset != top {
+		t.Errorf("expected the full log at line %d, at %d", top+1, m.viewport.YOffset+1)
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("F")})
+	if !m.grep.active {
+		t.Error("expected F to go back to grep mode")
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v")})
+	if !m.grep.invert || len(m.grep.hits) != 90 {
+		t.Errorf("expected the 90 lines without warnings, got %d", len(m.grep.hits))
+	}
+
+	// Leaving the log forgets grep mode
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.mode == ModeLogView || m.grep.re != nil {
+		t.Errorf("expected grep mode cleared on leaving the log, mode %d", m.mode)
+	}
+}
@@ -0,0 +1,194 @@
+package app
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/elogrono/jenkins-tui/internal/ui/theme"
+)
+
+// logMatchLimit caps the matches collected in one log
+const logMatchLimit = 10000
+
+var (
+	// logMatchStyle highlights search matches in the log
+	logMatchStyle = theme.AccentStyle.Copy().Reverse(true)
+	// logCurrentMatchStyle highlights the match n/N moved to
+	logCurrentMatchStyle = lipgloss.NewStyle().Background(theme.Primary).Foreground(theme.Foreground).Bold(true)
+)
+
+// logMatch is a search match: a byte range of the text of one log line
+type logMatch struct {
+	line       int
+	start, end int
+}
+
+// logSpan is a match within the line being rendered
+type logSpan struct {
+	start, end int
+	current    bool
+}
+
+// compileLogSearch compiles a log search as a regular expression, ignoring
+// case unless it has upper case letters. A pattern that is not a valid
+// expression is searched for literally.
+func compileLogSearch(query string) (re *regexp.Regexp, literal bool) {
+	flags := "(?i)"
+	if strings.ToLower(query) != query {
+		flags = ""
+	}
+	re, err := regexp.Compile(flags + query)
+	if err != nil {
+		return regexp.MustCompile(flags + regexp.QuoteMeta(query)), true
+	}
+	return re, false
+}
+
+// findLogMatches returns the non-empty matches of re in the lines
+func findLogMatches(lines []string, re *regexp.Regexp) []logMatch {
+	var matches []logMatch
+	for i, line := range lines {
+		for _, loc := range re.FindAllStringIndex(line, -1) {
+			if loc[0] == loc[1] {
+				continue
+			}
+			matches = append(matches, logMatch{line: i, start: loc[0], end: loc[1]})
+			if len(matches) == logMatchLimit {
+				return matches
+			}
+		}
+	}
+	return matches
+}
+
+// applyLogSearch searches the log for the entered pattern and moves to the
+// first match on screen or below it
+func (m *BuildsModel) applyLogSearch() {
+	m.logRegex, m.logLiteral = nil, false
+	m.logMatches, m.logMatchIdx = nil, 0
+	if m.logFilter != "" {
+		m.logRegex, m.logLiteral = compileLogSearch(m.logFilter)
+		m.findLogSearchMatches()
+		top := m.topLogLine()
+		for i, match := range m.logMatches {
+			if match.line >= top {
+				m.logMatchIdx = i
+				break
+			}
+		}
+	}
+	m.viewport.SetContent(m.formatLogContent())
+	m.showLogMatch()
+}
+
+// findLogSearchMatches searches the log again, keeping only the matches on
+// lines grep mode shows
+func (m *BuildsModel) findLogSearchMatches() {
+	if m.logRegex == nil {
+		return
+	}
+	matches := findLogMatches(logTexts(m.logLines), m.logRegex)
+	if m.grep.active {
+		shown := matches[:0]
+		for _, match := range matches {
+			if _, ok := m.grep.rowOf[match.line]; ok {
+				shown = append(shown, match)
+			}
+		}
+		matches = shown
+	}
+	m.logMatches = matches
+	m.logMatchIdx = minInt(m.logMatchIdx, maxInt(len(m.logMatches)-1, 0))
+}
+
+// clearLogSearch forgets the search and grep mode when leaving the log
+func (m *BuildsModel) clearLogSearch() {
+	m.logFilter = ""
+	m.logRegex, m.logLiteral = nil, false
+	m.logMatches, m.logMatchIdx = nil, 0
+	m.grep = logGrep{}
+}
+
+// setLogContent shows fetched log content, filtering and searching it again
+func (m *BuildsModel) setLogContent(content string) {
+	m.logContent = content
+	m.logLines = parseLog(content)
+	m.selectGrepLines()
+	m.findLogSearchMatches()
+	m.viewport.SetContent(m.formatLogContent())
+}
+
+// moveLogMatch moves to the next (1) or previous (-1) match, wrapping around
+func (m *BuildsModel) moveLogMatch(delta int) {
+	n := len(m.logMatches)
+	if n == 0 {
+		return
+	}
+	m.logMatchIdx = (m.logMatchIdx + delta + n) % n
+	// Following the tail would scroll the match away
+	m.followLog = false
+	m.viewport.SetContent(m.formatLogContent())
+	m.showLogMatch()
+}
+
+// showLogMatch scrolls the current match into the upper third of the log
+func (m *BuildsModel) showLogMatch() {
+	if m.logMatchIdx >= len(m.logMatches) {
+		return
+	}
+	row, ok := m.logRow(m.logMatches[m.logMatchIdx].line)
+	if !ok || (row >= m.viewport.YOffset && row < m.viewport.YOffset+m.viewport.Height) {
+		return
+	}
+	m.viewport.SetYOffset(maxInt(row-m.viewport.Height/3, 0))
+}
+
+// logSpans groups the matches by line for rendering
+func (m *BuildsModel) logSpans() map[int][]logSpan {
+	if len(m.logMatches) == 0 {
+		return nil
+	}
+	spans := make(map[int][]logSpan)
+	for i, match := range m.logMatches {
+		spans[match.line] = append(spans[match.line], logSpan{start: match.start, end: match.end, current: i == m.logMatchIdx})
+	}
+	return spans
+}
+
+// logSearchStatus describes the search for the log status bar
+func (m *BuildsModel) logSearchStatus() string {
+	if m.logRegex == nil {
+		return ""
+	}
+	status := "No matches"
+	if n := len(m.logMatches); n > 0 {
+		total := fmt.Sprint(n)
+		if n == logMatchLimit {
+			total += "+"
+		}
+		status = fmt.Sprintf("Match %d/%s", m.logMatchIdx+1, total)
+	}
+	if m.logLiteral {
+		status += " (invalid regex, searched literally)"
+	}
+	return status
+}
+
+// logLineStyle picks the color of a log line from its keywords
+func logLineStyle(line string) (lipgloss.Style, bool) {
+	lineLower := strings.ToLower(line)
+	switch {
+	case strings.Contains(lineLower, "error") || strings.Contains(lineLower, "failed") ||
+		strings.Contains(lineLower, "exception"):
+		return theme.ErrorStyle, true
+	case strings.Contains(lineLower, "warning") || strings.Contains(lineLower, "warn"):
+		return theme.WarningStyle, true
+	case strings.Contains(lineLower, "success") || strings.Contains(lineLower, "passed"):
+		return theme.SuccessStyle, true
+	case strings.HasPrefix(line, "[Pipeline]") || strings.HasPrefix(line, "[INFO]"):
+		return theme.InfoStyle, true
+	}
+	return lipgloss.Style{}, false
+}
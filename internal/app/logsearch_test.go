@@ -0,0 +1,95 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/elogrono/jenkins-tui/internal/jenkins/models"
+	"github.com/elogrono/jenkins-tui/internal/ui/theme"
+)
+
+// newLogModel returns a builds model showing log in the log viewer
+func newLogModel(log string) *BuildsModel {
+	m := NewBuildsModel(nil, 120, 20)
+	m.loading = false
+	m.mode = ModeLogView
+	m.jobDetail = &models.JobDetail{Name: "app"}
+	m.buildDetail = &models.Build{Number: 7}
+	m.viewport.Height = 10
+	m.Update(BuildsDataMsg{LogContent: log})
+	return m
+}
+
+func searchLog(m *BuildsModel, query string) {
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	m.logSearchInput.SetValue(query)
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+}
+
+func TestLogSearchNavigation(t *testing.T) {
+	var lines []string
+	for i := 1; i <= 100; i++ {
+		line := fmt.Sprintf("step %d", i)
+		if i%25 == 0 {
+			line += " ERROR code=" + fmt.Sprint(i)
+		}
+		lines = append(lines, line)
+	}
+	m := newLogModel(strings.Join(lines, "\n"))
+
+	// Case-insensitive regular expression over the raw lines
+	searchLog(m, `error code=\d+`)
+	if len(m.logMatches) != 4 || m.logSearchStatus() != "Match 1/4" {
+		t.Fatalf("expected 4 matches, got %v %q", m.logMatches, m.logSearchStatus())
+	}
+	if m.logMatches[0].line != 24 || m.viewport.YOffset == 0 {
+		t.Errorf("expected to scroll to line 25, at offset %d", m.viewport.YOffset)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	if m.logSearchStatus() != "Match 3/4" || m.logMatches[m.logMatchIdx].line != 74 {
+		t.Errorf("expected the third match, got %q", m.logSearchStatus())
+	}
+	if m.viewport.YOffset > 74 || m.viewport.YOffset+m.viewport.Height <= 74 {
+		t.Errorf("expected line 75 on screen, at offset %d", m.viewport.YOffset)
+	}
+	// N goes back and wraps around
+	for i := 0; i < 3; i++ {
+		m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("N")})
+	}
+	if m.logSearchStatus() != "Match 4/4" {
+		t.Errorf("expected to wrap to the last match, got %q", m.logSearchStatus())
+	}
+	if !strings.Contains(m.View(), "Match 4/4") {
+		t.Error("expected the counter in the status bar")
+	}
+
+	// Upper case makes the search case-sensitive
+	searchLog(m, "Error")
+	if m.logSearchStatus() != "No matches" {
+		t.Errorf("expected no case-sensitive match, got %q", m.logSearchStatus())
+	}
+
+	// Invalid expressions are searched literally
+	searchLog(m, "code=(")
+	if !m.logLiteral || len(m.logMatches) != 0 {
+		t.Errorf("expected a literal search, got %v", m.logMatches)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.logRegex != nil || m.logMatches != nil || m.logFilter != "" {
+		t.Error("expected the search cleared when leaving the log")
+	}
+}
+
+func TestRenderLogLineHighlightsRawText(t *testing.T) {
+	line := logLine{text: "ERROR: build failed"}
+	got := renderLogLine(line, []logSpan{{start: 0, end: 5, current: true}, {start: 13, end: 19}})
+	want := logCurrentMatchStyle.Render("ERROR") + theme.ErrorStyle.Render(": build ") + logMatchStyle.Render("failed")
+	if got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
@@ -2,10 +2,14 @@
 package app
 
 import (
+	"context"
 	"time"
 
 	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/elogrono/jenkins-tui/internal/audit"
+	"github.com/elogrono/jenkins-tui/internal/cache"
 	"github.com/elogrono/jenkins-tui/internal/config"
 	"github.com/elogrono/jenkins-tui/internal/jenkins"
 	"github.com/elogrono/jenkins-tui/internal/logger"
@@ -64,6 +68,22 @@ type Model struct {
 	// Help visibility
 	showHelp bool
 
+	// Write unlock prompt for read-only profiles
+	unlocking   bool
+	unlockInput textinput.Model
+	unlockErr   string
+
+	// Activity overlay listing audited actions
+	showActivity   bool
+	activity       []audit.Entry
+	activityErr    error
+	activityOffset int
+
+	// Command palette; the data and picks outlive the palette being closed
+	palette     *palette
+	paletteData PaletteDataMsg
+	paletteUsed map[string]time.Time
+
 	// Auto-refresh
 	autoRefreshEnabled  bool
 	autoRefreshInterval time.Duration
@@ -127,6 +147,24 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if m.unlocking {
+			return m, m.updateUnlock(msg)
+		}
+		if m.showActivity {
+			return m, m.updateActivity(msg)
+		}
+		if m.palette != nil {
+			return m, m.updatePalette(msg)
+		}
+		if m.state == StateReady && m.activeTab == TabBuilds && m.buildsModel != nil &&
+			m.buildsModel.InputActive() && msg.String() != "ctrl+c" {
+			return m, m.buildsModel.Update(msg)
+		}
+		if m.state == StateReady && m.activeTab == TabViews && m.viewsModel != nil &&
+			m.viewsModel.InputActive() && msg.String() != "ctrl+c" {
+			return m, m.viewsModel.Update(msg)
+		}
+
 		// Global key handling
 		switch msg.String() {
 		case "ctrl+c", "q":
@@ -149,13 +187,19 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "ctrl+r":
 			// Toggle auto-refresh
 			if m.state == StateReady {
-				m.autoRefreshEnabled = !m.autoRefreshEnabled
-				if m.autoRefreshEnabled {
-					logger.Info("Auto-refresh enabled")
-					return m, m.scheduleAutoRefresh()
-				}
-				logger.Info("Auto-refresh disabled")
-				return m, nil
+				return m, m.toggleAutoRefresh()
+			}
+		case "ctrl+x":
+			if m.state == StateReady {
+				return m, m.toggleWriteLock()
+			}
+		case "ctrl+a":
+			if m.state == StateReady {
+				return m, m.toggleActivity()
+			}
+		case "ctrl+p":
+			if m.state == StateReady {
+				return m, m.openPalette()
 			}
 		case "tab":
 			if m.state == StateReady && !m.showHelp {
@@ -231,6 +275,32 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Start auto-refresh timer
 		return m, tea.Batch(m.loadTabData(), m.scheduleAutoRefresh())
 
+	case ActivityDataMsg:
+		m.activity = msg.Entries
+		m.activityErr = msg.Error
+		if m.activityOffset >= len(m.activity) {
+			m.activityOffset = 0
+		}
+		return m, nil
+
+	case PaletteDataMsg:
+		m.applyPaletteData(msg)
+		return m, nil
+
+	// Downloads and batches report progress to their tab even while another
+	// tab is shown; dropping a message would stall them for good
+	case ArtifactProgressMsg, ArtifactDownloadedMsg:
+		if m.buildsModel != nil {
+			return m, m.buildsModel.Update(msg)
+		}
+		return m, nil
+
+	case BatchItemMsg:
+		return m, m.routeBatch(msg.run, msg)
+
+	case BatchDoneMsg:
+		return m, m.routeBatch(msg.run, msg)
+
 	case ClientErrorMsg:
 		logger.Error("Client error received", "error", msg.Error)
 		m.lastError = msg.Error
@@ -311,6 +381,15 @@ func (m *Model) View() string {
 	case StateError:
 		return m.viewError()
 	case StateReady:
+		if m.unlocking {
+			return m.viewUnlock()
+		}
+		if m.showActivity {
+			return m.viewActivity()
+		}
+		if m.palette != nil {
+			return m.viewPalette()
+		}
 		if m.showHelp {
 			return m.viewHelp()
 		}
@@ -330,6 +409,20 @@ func (m *Model) initializeClient() tea.Cmd {
 			return ClientErrorMsg{Error: err}
 		}
 
+		// Record every mutating request; the log is created on first write
+		if path, err := audit.DefaultPath(); err == nil {
+			client.SetAuditLog(audit.New(path))
+		} else {
+			logger.Warn("Audit log disabled", "error", err)
+		}
+
+		// Finished build results never change, so they are kept on disk
+		if dir, err := cache.DefaultDir(); err == nil {
+			client.SetCache(cache.New(dir))
+		} else {
+			logger.Warn("Result cache disabled", "error", err)
+		}
+
 		logger.Info("Client created, testing connection...")
 		// Test connection
 		if err := client.TestConnection(); err != nil {
@@ -337,6 +430,11 @@ func (m *Model) initializeClient() tea.Cmd {
 			return ClientErrorMsg{Error: err}
 		}
 
+		// Identify the user so actions can be gated; failures are non-fatal
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		_ = client.LoadIdentity(ctx)
+
 		logger.Info("Connection test passed, returning ClientReadyMsg")
 		return ClientReadyMsg{Client: client}
 	}
@@ -387,6 +485,17 @@ func (m *Model) updateActiveTab(msg tea.Msg) tea.Cmd {
 	return nil
 }
 
+// routeBatch delivers the progress of a batch to the tab running it
+func (m *Model) routeBatch(run *batchRun, msg tea.Msg) tea.Cmd {
+	switch {
+	case m.buildsModel != nil && m.buildsModel.ownsBatch(run):
+		return m.buildsModel.Update(msg)
+	case m.viewsModel != nil && m.viewsModel.ownsBatch(run):
+		return m.viewsModel.Update(msg)
+	}
+	return nil
+}
+
 // Message types
 type SetupCompleteMsg struct {
 	Config *config.Config
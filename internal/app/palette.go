@@ -0,0 +1,509 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/elogrono/jenkins-tui/internal/fuzzy"
+	"github.com/elogrono/jenkins-tui/internal/jenkins/models"
+	"github.com/elogrono/jenkins-tui/internal/logger"
+	"github.com/elogrono/jenkins-tui/internal/ui/components"
+	"github.com/elogrono/jenkins-tui/internal/ui/theme"
+)
+
+// paletteRows is the number of matches listed in the command palette
+const paletteRows = 12
+
+// paletteRecentBuilds caps the latest builds offered by the palette
+const paletteRecentBuilds = 50
+
+// paletteUsedBonus ranks what was picked from the palette this session
+// above what merely matches as well
+const paletteUsedBonus = 32
+
+// paletteKind is what a palette entry opens
+type paletteKind int
+
+const (
+	paletteCommand paletteKind = iota
+	paletteJob
+	paletteBuild
+	paletteView
+	paletteNode
+)
+
+// paletteKindNames label the kinds in the palette
+var paletteKindNames = [...]string{"cmd", "job", "build", "view", "node"}
+
+// paletteItem is one entry of the command palette
+type paletteItem struct {
+	kind   paletteKind
+	label  string // Text matched against the query
+	detail string
+	when   time.Time // Last activity, for ranking
+	job    string
+	build  int                    // Selected build, or the last build of a job
+	run    func(m *Model) tea.Cmd // Commands only
+}
+
+// key identifies the entry across reloads of the palette data
+func (it paletteItem) key() string {
+	return paletteKindNames[it.kind] + ":" + it.label
+}
+
+// paletteMatch is an entry matching the query
+type paletteMatch struct {
+	item      *paletteItem
+	score     int
+	positions []int
+}
+
+// PaletteDataMsg carries the jobs, views and nodes offered by the palette
+type PaletteDataMsg struct {
+	Jobs  []models.Job
+	Views []models.View
+	Nodes []models.Node
+	Error error
+}
+
+// palette is the open command palette
+type palette struct {
+	input    textinput.Model
+	items    []paletteItem
+	matches  []paletteMatch
+	selected int
+	loading  bool
+}
+
+// openPalette opens the command palette with the data of the last opening
+// and reloads it
+func (m *Model) openPalette() tea.Cmd {
+	input := textinput.New()
+	input.Placeholder = "Jobs, builds, views, nodes and commands"
+	input.Prompt = theme.IconSearch + " "
+	input.Width = 60
+	input.Focus()
+
+	m.palette = &palette{input: input, loading: true}
+	m.palette.items = m.paletteItems()
+	m.filterPalette()
+	return tea.Batch(textinput.Blink, m.loadPaletteData())
+}
+
+// loadPaletteData fetches the jobs in all folders, the views and the nodes
+func (m *Model) loadPaletteData() tea.Cmd {
+	client := m.client
+	if client == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		jobs, jobsErr := client.GetJobTree(ctx)
+		views, viewsErr := client.GetViews(ctx)
+		nodes, nodesErr := client.GetNodes(ctx)
+		return PaletteDataMsg{Jobs: jobs, Views: views, Nodes: nodes, Error: errors.Join(jobsErr, viewsErr, nodesErr)}
+	}
+}
+
+// applyPaletteData keeps what loaded and refreshes the open palette
+func (m *Model) applyPaletteData(msg PaletteDataMsg) {
+	if msg.Jobs != nil {
+		m.paletteData.Jobs = msg.Jobs
+	}
+	if msg.Views != nil {
+		m.paletteData.Views = msg.Views
+	}
+	if msg.Nodes != nil {
+		m.paletteData.Nodes = msg.Nodes
+	}
+	m.paletteData.Error = msg.Error
+	if m.palette == nil {
+		return
+	}
+	m.palette.loading = false
+	m.palette.items = m.paletteItems()
+	m.filterPalette()
+}
+
+// paletteItems lists everything the palette can open
+func (m *Model) paletteItems() []paletteItem {
+	items := m.paletteCommands()
+
+	var builds []paletteItem
+	for _, job := range m.paletteData.Jobs {
+		name := job.FullName
+		if name == "" {
+			name = job.Name
+		}
+		item := paletteItem{kind: paletteJob, label: name, job: name}
+		if job.LastBuild != nil {
+			last := time.UnixMilli(job.LastBuild.Timestamp)
+			result := job.LastBuild.Result
+			if job.IsRunning() {
+				result = "RUNNING"
+			}
+			item.when = last
+			item.build = job.LastBuild.Number
+			item.detail = fmt.Sprintf("#%d %s", job.LastBuild.Number, components.FormatTimeAgo(last))
+			builds = append(builds, paletteItem{
+				kind:   paletteBuild,
+				label:  fmt.Sprintf("%s #%d", name, job.LastBuild.Number),
+				detail: strings.TrimSpace(result + " " + components.FormatTimeAgo(last)),
+				when:   last,
+				job:    name,
+				build:  job.LastBuild.Number,
+			})
+		}
+		items = append(items, item)
+	}
+	sort.SliceStable(builds, func(i, j int) bool { return builds[i].when.After(builds[j].when) })
+	items = append(items, builds[:minInt(len(builds), paletteRecentBuilds)]...)
+
+	for _, view := range m.paletteData.Views {
+		items = append(items, paletteItem{kind: paletteView, label: view.Name, detail: view.Description})
+	}
+	for _, node := range m.paletteData.Nodes {
+		detail := fmt.Sprintf("%d/%d executors busy", node.BusyExecutors(), node.NumExecutors)
+		if node.Offline {
+			detail = "offline"
+		}
+		items = append(items, paletteItem{kind: paletteNode, label: node.DisplayName, detail: detail})
+	}
+	return items
+}
+
+// paletteCommands lists the app commands, named after their shortcuts
+func (m *Model) paletteCommands() []paletteItem {
+	autoRefresh := "on"
+	if !m.autoRefreshEnabled {
+		autoRefresh = "off"
+	}
+	commands := []paletteItem{
+		{label: "Go to Dashboard", detail: "1", run: func(m *Model) tea.Cmd { return m.switchTab(TabDashboard) }},
+		{label: "Go to Views", detail: "2", run: func(m *Model) tea.Cmd { return m.switchTab(TabViews) }},
+		{label: "Go to Builds", detail: "3", run: func(m *Model) tea.Cmd { return m.switchTab(TabBuilds) }},
+		{label: "Refresh", detail: "r", run: func(m *Model) tea.Cmd { return m.loadTabData() }},
+		{label: "Toggle auto-refresh", detail: "ctrl+r, now " + autoRefresh, run: func(m *Model) tea.Cmd { return m.toggleAutoRefresh() }},
+		{label: "Open activity log", detail: "ctrl+a", run: func(m *Model) tea.Cmd { return m.toggleActivity() }},
+		{label: "Show help", detail: "?", run: func(m *Model) tea.Cmd { m.showHelp = true; return nil }},
+		{label: "Quit", detail: "q", run: func(m *Model) tea.Cmd { return tea.Quit }},
+	}
+	if m.client != nil && m.client.IsReadOnly() {
+		commands = append(commands, paletteItem{
+			label: "Lock or unlock writes", detail: "ctrl+x", run: func(m *Model) tea.Cmd { return m.toggleWriteLock() },
+		})
+	}
+	return commands
+}
+
+// filterPalette ranks the entries matching the query by match quality, then
+// by what was picked before and how recently the job or build ran
+func (m *Model) filterPalette() {
+	p := m.palette
+	query := strings.TrimSpace(p.input.Value())
+	now := time.Now()
+
+	p.matches = p.matches[:0]
+	for i := range p.items {
+		item := &p.items[i]
+		r, ok := fuzzy.Match(query, item.label)
+		if !ok {
+			continue
+		}
+		score := r.Score + fuzzy.Recency(item.when, now)
+		if _, used := m.paletteUsed[item.key()]; used {
+			score += paletteUsedBonus
+		}
+		p.matches = append(p.matches, paletteMatch{item: item, score: score, positions: r.Positions})
+	}
+	sort.SliceStable(p.matches, func(i, j int) bool {
+		a, b := p.matches[i], p.matches[j]
+		if a.score != b.score {
+			return a.score > b.score
+		}
+		if ua, ub := m.paletteUsed[a.item.key()], m.paletteUsed[b.item.key()]; !ua.Equal(ub) {
+			return ua.After(ub)
+		}
+		if !a.item.when.Equal(b.item.when) {
+			return a.item.when.After(b.item.when)
+		}
+		return len(a.item.label) < len(b.item.label)
+	})
+	if len(p.matches) > paletteRows {
+		p.matches = p.matches[:paletteRows]
+	}
+	p.selected = minInt(p.selected, maxInt(len(p.matches)-1, 0))
+}
+
+// updatePalette handles keys while the command palette is open
+func (m *Model) updatePalette(msg tea.KeyMsg) tea.Cmd {
+	p := m.palette
+	switch msg.String() {
+	case "esc", "ctrl+p":
+		m.palette = nil
+		return nil
+	case "up", "ctrl+k":
+		if p.selected > 0 {
+			p.selected--
+		}
+		return nil
+	case "down", "ctrl+j":
+		if p.selected < len(p.matches)-1 {
+			p.selected++
+		}
+		return nil
+	case "enter":
+		if p.selected >= len(p.matches) {
+			return nil
+		}
+		return m.openPaletteItem(m.pickPaletteItem())
+	case "ctrl+l":
+		// Open the log of a build, or of the last build of a job
+		if p.selected >= len(p.matches) || p.matches[p.selected].item.build == 0 {
+			return nil
+		}
+		item := m.pickPaletteItem()
+		m.activeTab = TabBuilds
+		return m.buildsModel.OpenBuildLog(item.job, item.build)
+	}
+
+	var cmd tea.Cmd
+	p.input, cmd = p.input.Update(msg)
+	p.selected = 0
+	m.filterPalette()
+	return cmd
+}
+
+// pickPaletteItem closes the palette on the selected entry and remembers it
+// for ranking
+func (m *Model) pickPaletteItem() paletteItem {
+	item := *m.palette.matches[m.palette.selected].item
+	m.palette = nil
+	if m.paletteUsed == nil {
+		m.paletteUsed = make(map[string]time.Time)
+	}
+	m.paletteUsed[item.key()] = time.Now()
+	return item
+}
+
+// openPaletteItem runs a command or opens the job, build, view or node
+func (m *Model) openPaletteItem(item paletteItem) tea.Cmd {
+	switch item.kind {
+	case paletteCommand:
+		return item.run(m)
+	case paletteJob:
+		m.activeTab = TabBuilds
+		return m.buildsModel.OpenJob(item.job)
+	case paletteBuild:
+		m.activeTab = TabBuilds
+		return m.buildsModel.OpenBuild(item.job, item.build)
+	case paletteView:
+		m.activeTab = TabViews
+		return m.viewsModel.OpenView(m.paletteData.Views, item.label)
+	case paletteNode:
+		m.activeTab = TabDashboard
+		m.dashboardModel.FocusNode(item.label)
+		return m.loadTabData()
+	}
+	return nil
+}
+
+// viewPalette renders the command palette over the top of the screen
+func (m *Model) viewPalette() string {
+	p := m.palette
+	width := minInt(maxInt(m.width-8, 40), 100)
+	inner := width - 6
+
+	lines := []string{theme.SearchBarStyle.Width(inner).Render(p.input.View()), ""}
+	if len(p.matches) == 0 {
+		lines = append(lines, theme.MutedStyle.Render("No matches"))
+	}
+	for i, match := range p.matches {
+		item := match.item
+		base, cursor := lipgloss.NewStyle(), "  "
+		if i == p.selected {
+			base, cursor = theme.AccentStyle.Copy().Bold(true), theme.AccentStyle.Render(theme.IconExpand+" ")
+		}
+		kind := theme.MutedStyle.Render(fmt.Sprintf("%-6s", paletteKindNames[item.kind]))
+		text, positions := truncateMatches(item.label, match.positions, inner-12)
+		label := highlightMatches(text, positions, base, matchStyle)
+		line := cursor + kind + label
+		if item.detail != "" {
+			room := inner - lipgloss.Width(line) - 2
+			if room > 3 {
+				line += "  " + theme.MutedStyle.Render(truncate(item.detail, room))
+			}
+		}
+		lines = append(lines, line)
+	}
+
+	footer := "↑/↓: Select | Enter: Open | Esc: Close"
+	if p.selected < len(p.matches) && p.matches[p.selected].item.build > 0 {
+		footer = "↑/↓: Select | Enter: Open | Ctrl+L: Log | Esc: Close"
+	}
+	switch {
+	case p.loading:
+		footer = m.spinner.View() + " Loading jobs, views and nodes | " + footer
+	case m.paletteData.Error != nil:
+		lines = append(lines, "", theme.ErrorStyle.Render(truncate("Error: "+m.paletteData.Error.Error(), inner)))
+	}
+	lines = append(lines, "", theme.MutedStyle.Render(footer))
+
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Primary).
+		Padding(1, 2).
+		Width(width).
+		Render(strings.Join(lines, "\n"))
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Top, box,
+		lipgloss.WithWhitespaceChars(" "))
+}
+
+// truncateMatches truncates text like truncate and drops the match
+// positions that were cut off
+func truncateMatches(text string, positions []int, max int) (string, []int) {
+	short := truncate(text, max)
+	if short == text {
+		return text, positions
+	}
+	kept := len([]rune(short)) - len("...")
+	var inside []int
+	for _, pos := range positions {
+		if pos < kept {
+			inside = append(inside, pos)
+		}
+	}
+	return short, inside
+}
+
+// highlightMatches renders text with the runes at positions in the match style
+func highlightMatches(text string, positions []int, base, match lipgloss.Style) string {
+	if len(positions) == 0 {
+		return base.Render(text)
+	}
+	matched := make(map[int]bool, len(positions))
+	for _, pos := range positions {
+		matched[pos] = true
+	}
+
+	var b strings.Builder
+	var run []rune
+	inMatch := false
+	flush := func() {
+		if len(run) == 0 {
+			return
+		}
+		if inMatch {
+			b.WriteString(match.Render(string(run)))
+		} else {
+			b.WriteString(base.Render(string(run)))
+		}
+		run = run[:0]
+	}
+	for i, r := range []rune(text) {
+		if matched[i] != inMatch {
+			flush()
+			inMatch = matched[i]
+		}
+		run = append(run, r)
+	}
+	flush()
+	return b.String()
+}
+
+// switchTab shows another tab and loads its data
+func (m *Model) switchTab(tab TabID) tea.Cmd {
+	m.activeTab = tab
+	return m.loadTabData()
+}
+
+// toggleAutoRefresh pauses or resumes the periodic refresh
+func (m *Model) toggleAutoRefresh() tea.Cmd {
+	m.autoRefreshEnabled = !m.autoRefreshEnabled
+	if m.autoRefreshEnabled {
+		logger.Info("Auto-refresh enabled")
+		return m.scheduleAutoRefresh()
+	}
+	logger.Info("Auto-refresh disabled")
+	return nil
+}
+
+// OpenJob shows the builds of a job, which may be in a folder
+func (m *BuildsModel) OpenJob(jobName string) tea.Cmd {
+	m.closeBuildDetail()
+	m.mode = ModeBuildList
+	m.confirm = nil
+	// Placeholder until the job loads, so views never see a nil job
+	m.jobDetail = &models.JobDetail{Name: jobName}
+	m.builds = nil
+	m.selectedBuild = 0
+	m.buildsScroll = 0
+	m.buildMarks.clear()
+	cmds := []tea.Cmd{m.fetchJobDetail(jobName)}
+	if len(m.jobs) == 0 {
+		// Esc leads back to the job list
+		cmds = append(cmds, m.fetchJobs())
+	}
+	return tea.Batch(cmds...)
+}
+
+// OpenBuild shows the detail of a build
+func (m *BuildsModel) OpenBuild(jobName string, buildNum int) tea.Cmd {
+	cmd := m.OpenJob(jobName)
+	m.mode = ModeBuildDetail
+	return tea.Batch(cmd, m.fetchBuildDetail(jobName, buildNum))
+}
+
+// OpenBuildLog shows the log of a build; Esc leads back to its detail
+func (m *BuildsModel) OpenBuildLog(jobName string, buildNum int) tea.Cmd {
+	cmd := m.OpenBuild(jobName, buildNum)
+	m.mode = ModeLogView
+	return tea.Batch(cmd, m.fetchBuildLog(jobName, buildNum))
+}
+
+// OpenView shows the jobs of a view, taking the list of views along
+func (m *ViewsModel) OpenView(views []models.View, name string) tea.Cmd {
+	m.views = views
+	m.filter = ""
+	m.searchInput.SetValue("")
+	m.confirm = nil
+	for i, view := range m.views {
+		if view.Name == name {
+			m.selectedView = i
+			m.viewsScroll = i
+		}
+	}
+	m.mode = ViewsModeJobs
+	m.jobs = nil
+	m.selectedJob = 0
+	m.jobsScroll = 0
+	m.jobMarks.clear()
+	return m.fetchViewJobs(name)
+}
+
+// FocusNode selects a node in the Nodes panel, once the nodes are loaded
+func (m *DashboardModel) FocusNode(name string) {
+	m.selectedPanel = PanelNodes
+	m.focusNode = name
+	m.applyFocusNode()
+}
+
+// applyFocusNode selects the node asked for by FocusNode if it is listed
+func (m *DashboardModel) applyFocusNode() {
+	for i, node := range m.nodes {
+		if node.DisplayName == m.focusNode {
+			m.selectedNode = i
+			m.focusNode = ""
+			return
+		}
+	}
+}
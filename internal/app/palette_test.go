@@ -0,0 +1,104 @@
+package app
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/elogrono/jenkins-tui/internal/config"
+	"github.com/elogrono/jenkins-tui/internal/jenkins/models"
+)
+
+func typeKeys(m *Model, text string) {
+	for _, r := range text {
+		m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+}
+
+func TestCommandPalette(t *testing.T) {
+	m := NewModel(config.DefaultConfig())
+	m.state = StateReady
+	m.width, m.height = 120, 40
+	m.initTabModels()
+
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlP})
+	if m.palette == nil {
+		t.Fatal("expected the palette to open")
+	}
+	now := time.Now()
+	m.Update(PaletteDataMsg{
+		Jobs: []models.Job{
+			{Name: "deploy", FullName: "team/deploy", Color: "blue", LastBuild: &models.BuildRef{Number: 42, Timestamp: now.Add(-time.Minute).UnixMilli()}},
+			{Name: "docs-publish", FullName: "docs-publish", Color: "blue", LastBuild: &models.BuildRef{Number: 3, Timestamp: now.Add(-30 * 24 * time.Hour).UnixMilli()}},
+		},
+		Views: []models.View{{Name: "Production"}},
+		Nodes: []models.Node{{DisplayName: "linux-agent"}},
+	})
+
+	// Folders are part of the name; the recent build outranks the stale job
+	typeKeys(m, "dep")
+	if top := m.palette.matches[0].item; top.label != "team/deploy" {
+		t.Errorf("expected team/deploy first, got %q", top.label)
+	}
+	if view := m.View(); !strings.Contains(view, "team/deploy #42") {
+		t.Error("expected the recent build listed")
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.palette != nil || m.activeTab != TabBuilds {
+		t.Fatal("expected the job opened in the Builds tab")
+	}
+	if m.buildsModel.mode != ModeBuildList || m.buildsModel.jobDetail.Name != "team/deploy" {
+		t.Errorf("expected the builds of team/deploy, got mode %d", m.buildsModel.mode)
+	}
+
+	// Commands match too, and picks rank first next time
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlP})
+	if m.palette.matches[0].item.label != "team/deploy" {
+		t.Errorf("expected the last pick first, got %q", m.palette.matches[0].item.label)
+	}
+	typeKeys(m, "auto")
+	if m.palette.matches[0].item.label != "Toggle auto-refresh" {
+		t.Fatalf("expected the auto-refresh command, got %q", m.palette.matches[0].item.label)
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.autoRefreshEnabled {
+		t.Error("expected auto-refresh toggled off")
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlP})
+	typeKeys(m, "prod")
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.activeTab != TabViews || m.viewsModel.mode != ViewsModeJobs || len(m.viewsModel.views) != 1 {
+		t.Error("expected the Production view opened")
+	}
+
+	// Ctrl+L opens the log of the last build of a job
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlP})
+	typeKeys(m, "docs")
+	if !strings.Contains(m.View(), "Ctrl+L: Log") {
+		t.Error("expected the log shortcut offered")
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlL})
+	if m.palette != nil || m.activeTab != TabBuilds || m.buildsModel.mode != ModeLogView || m.buildsModel.jobDetail.Name != "docs-publish" {
+		t.Errorf("expected the log of docs-publish opened, got mode %d", m.buildsModel.mode)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlP})
+	typeKeys(m, "zzz")
+	if len(m.palette.matches) != 0 || !strings.Contains(m.View(), "No matches") {
+		t.Error("expected no matches")
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.palette != nil {
+		t.Error("expected Esc to close the palette")
+	}
+}
+
+func TestHighlightMatches(t *testing.T) {
+	text, positions := truncateMatches("folder/deploy-service", []int{0, 7, 20}, 12)
+	if text != "folder/de..." || len(positions) != 2 {
+		t.Errorf("expected the cut off match dropped, got %q %v", text, positions)
+	}
+}
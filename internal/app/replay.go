@@ -0,0 +1,219 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/elogrono/jenkins-tui/internal/jenkins"
+	"github.com/elogrono/jenkins-tui/internal/jenkins/models"
+)
+
+// Polling for the replayed build while it waits in the queue
+const (
+	replayPollInterval = 2 * time.Second
+	replayPollLimit    = 30
+)
+
+// ReplayScriptsMsg carries the scripts of a build about to be replayed
+type ReplayScriptsMsg struct {
+	JobName string
+	Build   int
+	Scripts *models.ReplayScripts
+	Error   error
+}
+
+// ReplayEditedMsg reports that the editor on the replay scripts exited
+type ReplayEditedMsg struct {
+	JobName  string
+	Build    int
+	Dir      string // Temporary directory holding the edited scripts
+	Original models.ReplayScripts
+	Error    error
+}
+
+// ReplayStartedMsg reports the outcome of submitting a replay
+type ReplayStartedMsg struct {
+	JobName  string
+	Build    int
+	NewBuild int // Number the replayed build is expected to get
+	Error    error
+}
+
+// ReplayPollMsg asks to look for the replayed build again
+type ReplayPollMsg struct{}
+
+// startReplay fetches the scripts of the current build for editing
+func (m *BuildsModel) startReplay() tea.Cmd {
+	if m.jobDetail == nil || m.buildDetail == nil {
+		return nil
+	}
+	jobName, buildNum := m.jobDetail.Name, m.buildDetail.Number
+	if !canPerform(m.client, jenkins.PermReplay, jobName, buildNum) {
+		m.notice, m.noticeErr = blockedNotice(m.client, jenkins.PermReplay), true
+		return nil
+	}
+	m.loading = true
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		scripts, err := m.client.GetReplayScripts(ctx, jobName, buildNum)
+		return ReplayScriptsMsg{JobName: jobName, Build: buildNum, Scripts: scripts, Error: err}
+	}
+}
+
+// editReplayScripts writes the fetched scripts to a temporary directory and
+// suspends the UI while $EDITOR runs on them
+func (m *BuildsModel) editReplayScripts(msg ReplayScriptsMsg) tea.Cmd {
+	m.loading = false
+	if msg.Error != nil {
+		m.notice, m.noticeErr = "Replay: "+msg.Error.Error(), true
+		return nil
+	}
+
+	dir, err := os.MkdirTemp("", "jenkins-tui-replay-")
+	if err != nil {
+		m.notice, m.noticeErr = "Replay: "+err.Error(), true
+		return nil
+	}
+	files, err := writeReplayScripts(dir, *msg.Scripts)
+	if err != nil {
+		os.RemoveAll(dir)
+		m.notice, m.noticeErr = "Replay: "+err.Error(), true
+		return nil
+	}
+
+	original := *msg.Scripts
+	return tea.ExecProcess(editorCommand(files), func(err error) tea.Msg {
+		return ReplayEditedMsg{JobName: msg.JobName, Build: msg.Build, Dir: dir, Original: original, Error: err}
+	})
+}
+
+// applyReplayEdited reads back the edited scripts and asks to submit them
+func (m *BuildsModel) applyReplayEdited(msg ReplayEditedMsg) {
+	defer os.RemoveAll(msg.Dir)
+	if msg.Error != nil {
+		// A failing editor (e.g. :cq in vim) cancels the replay
+		m.notice, m.noticeErr = "Replay cancelled: "+msg.Error.Error(), true
+		return
+	}
+	edited, err := readReplayScripts(msg.Dir, msg.Original)
+	if err != nil {
+		m.notice, m.noticeErr = "Replay: "+err.Error(), true
+		return
+	}
+
+	prompt := fmt.Sprintf("Replay %s #%d with unchanged scripts?", msg.JobName, msg.Build)
+	if changed := edited.Changed(msg.Original); changed > 0 {
+		prompt = fmt.Sprintf("Replay %s #%d with %d edited script(s)?", msg.JobName, msg.Build, changed)
+	}
+	m.confirm = &confirmAction{
+		prompt: prompt,
+		run:    m.submitReplay(msg.JobName, msg.Build, edited),
+	}
+}
+
+// submitReplay runs the build again with the edited scripts
+func (m *BuildsModel) submitReplay(jobName string, buildNum int, scripts models.ReplayScripts) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		newBuild, err := m.client.Replay(ctx, jobName, buildNum, scripts)
+		return ReplayStartedMsg{JobName: jobName, Build: buildNum, NewBuild: newBuild, Error: err}
+	}
+}
+
+// applyReplayStarted starts following the replayed build
+func (m *BuildsModel) applyReplayStarted(msg ReplayStartedMsg) tea.Cmd {
+	if msg.Error != nil {
+		m.notice, m.noticeErr = "Replay failed: "+msg.Error.Error(), true
+		return nil
+	}
+	m.notice, m.noticeErr = fmt.Sprintf("Replay of %s #%d queued as #%d", msg.JobName, msg.Build, msg.NewBuild), false
+	m.replayJob, m.replayBuild, m.replayPolls, m.replayMode = msg.JobName, msg.NewBuild, 0, m.mode
+	return m.fetchJobDetail(msg.JobName)
+}
+
+// followReplay opens the replayed build once the job lists it, polling
+// while the build waits in the queue
+func (m *BuildsModel) followReplay() tea.Cmd {
+	if m.replayBuild == 0 || m.jobDetail == nil || m.jobDetail.Name != m.replayJob {
+		return nil
+	}
+	for i, b := range m.builds {
+		if b.Number == m.replayBuild {
+			m.replayBuild = 0
+			m.selectedBuild = i
+			m.buildsScroll = minInt(m.buildsScroll, i)
+			m.mode = ModeBuildDetail
+			return m.fetchBuildDetail(m.replayJob, b.Number)
+		}
+	}
+	m.replayPolls++
+	if m.replayPolls > replayPollLimit {
+		m.notice, m.noticeErr = fmt.Sprintf("Replayed build #%d has not started yet", m.replayBuild), false
+		m.replayBuild = 0
+		return nil
+	}
+	return tea.Tick(replayPollInterval, func(time.Time) tea.Msg { return ReplayPollMsg{} })
+}
+
+// replayFileName returns the file a script is edited in
+func replayFileName(loaded string) string {
+	if loaded == "" {
+		return "Jenkinsfile"
+	}
+	return strings.ReplaceAll(loaded, string(filepath.Separator), "_") + ".groovy"
+}
+
+// writeReplayScripts writes the scripts into dir, main script first
+func writeReplayScripts(dir string, scripts models.ReplayScripts) ([]string, error) {
+	files := []string{filepath.Join(dir, replayFileName(""))}
+	if err := os.WriteFile(files[0], []byte(scripts.Main), 0o600); err != nil {
+		return nil, err
+	}
+	for _, l := range scripts.Loaded {
+		file := filepath.Join(dir, replayFileName(l.Name))
+		if err := os.WriteFile(file, []byte(l.Script), 0o600); err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+// readReplayScripts reads back the scripts written by writeReplayScripts
+func readReplayScripts(dir string, original models.ReplayScripts) (models.ReplayScripts, error) {
+	main, err := os.ReadFile(filepath.Join(dir, replayFileName("")))
+	if err != nil {
+		return models.ReplayScripts{}, err
+	}
+	edited := models.ReplayScripts{Main: string(main)}
+	for _, l := range original.Loaded {
+		script, err := os.ReadFile(filepath.Join(dir, replayFileName(l.Name)))
+		if err != nil {
+			return models.ReplayScripts{}, err
+		}
+		edited.Loaded = append(edited.Loaded, models.LoadedScript{Name: l.Name, Script: string(script)})
+	}
+	return edited, nil
+}
+
+// editorCommand returns the command opening files in $VISUAL or $EDITOR,
+// falling back to vi. The variables may carry arguments, e.g. "code --wait".
+func editorCommand(files []string) *exec.Cmd {
+	editor := os.Getenv("VISUAL")
+	if strings.TrimSpace(editor) == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	args := strings.Fields(editor)
+	if len(args) == 0 {
+		args = []string{"vi"}
+	}
+	return exec.Command(args[0], append(args[1:], files...)...)
+}
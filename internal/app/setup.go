@@ -2,6 +2,8 @@ package app
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/textinput"
@@ -19,6 +21,11 @@ const (
 	FieldURL SetupField = iota
 	FieldUsername
 	FieldToken
+	FieldProxy
+	FieldNoProxy
+	FieldCACert
+	FieldClientCert
+	FieldClientKey
 	FieldSubmit
 )
 
@@ -31,6 +38,14 @@ type SetupModel struct {
 	usernameInput textinput.Model
 	tokenInput    textinput.Model
 
+	// Advanced network settings (hidden until toggled)
+	proxyInput      textinput.Model
+	noProxyInput    textinput.Model
+	caCertInput     textinput.Model
+	clientCertInput textinput.Model
+	clientKeyInput  textinput.Model
+	showAdvanced    bool
+
 	focusedField SetupField
 	err          error
 	testing      bool
@@ -58,13 +73,26 @@ func NewSetupModel() *SetupModel {
 	tokenInput.Width = 50
 
 	return &SetupModel{
-		urlInput:      urlInput,
-		usernameInput: usernameInput,
-		tokenInput:    tokenInput,
-		focusedField:  FieldURL,
+		urlInput:        urlInput,
+		usernameInput:   usernameInput,
+		tokenInput:      tokenInput,
+		proxyInput:      newPathInput("http://proxy.example.com:3128"),
+		noProxyInput:    newPathInput("localhost,.internal.example.com"),
+		caCertInput:     newPathInput("/etc/ssl/certs/corp-ca.pem"),
+		clientCertInput: newPathInput("~/.jenkins/client.crt"),
+		clientKeyInput:  newPathInput("~/.jenkins/client.key"),
+		focusedField:    FieldURL,
 	}
 }
 
+func newPathInput(placeholder string) textinput.Model {
+	input := textinput.New()
+	input.Placeholder = placeholder
+	input.CharLimit = 512
+	input.Width = 50
+	return input
+}
+
 // Init implements tea.Model
 func (m *SetupModel) Init() tea.Cmd {
 	return textinput.Blink
@@ -80,6 +108,14 @@ func (m *SetupModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "ctrl+c":
 			return m, tea.Quit
 
+		case "ctrl+o":
+			m.showAdvanced = !m.showAdvanced
+			if !m.showAdvanced && m.isAdvancedField(m.focusedField) {
+				m.focusedField = FieldSubmit
+				m.updateFocus()
+			}
+			return m, nil
+
 		case "tab", "down":
 			m.nextField()
 			return m, nil
@@ -104,10 +140,7 @@ func (m *SetupModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		} else {
 			m.testResult = "Connection successful!"
 			// Return the completed config
-			cfg := config.DefaultConfig()
-			cfg.Profile.BaseURL = m.urlInput.Value()
-			cfg.Profile.Username = m.usernameInput.Value()
-			cfg.Profile.APIToken = m.tokenInput.Value()
+			cfg := m.buildConfig()
 			return m, func() tea.Msg {
 				return SetupCompleteMsg{Config: cfg}
 			}
@@ -124,6 +157,16 @@ func (m *SetupModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.usernameInput, cmd = m.usernameInput.Update(msg)
 	case FieldToken:
 		m.tokenInput, cmd = m.tokenInput.Update(msg)
+	case FieldProxy:
+		m.proxyInput, cmd = m.proxyInput.Update(msg)
+	case FieldNoProxy:
+		m.noProxyInput, cmd = m.noProxyInput.Update(msg)
+	case FieldCACert:
+		m.caCertInput, cmd = m.caCertInput.Update(msg)
+	case FieldClientCert:
+		m.clientCertInput, cmd = m.clientCertInput.Update(msg)
+	case FieldClientKey:
+		m.clientKeyInput, cmd = m.clientKeyInput.Update(msg)
 	}
 	cmds = append(cmds, cmd)
 
@@ -156,6 +199,22 @@ func (m *SetupModel) View() string {
 	b.WriteString(m.renderField("API Token:", m.tokenInput.View(), m.focusedField == FieldToken))
 	b.WriteString("\n\n")
 
+	// Advanced network settings
+	if m.showAdvanced {
+		b.WriteString(theme.SubtitleStyle.Render("Network (optional)"))
+		b.WriteString("\n")
+		b.WriteString(m.renderField("Proxy URL:", m.proxyInput.View(), m.focusedField == FieldProxy))
+		b.WriteString("\n\n")
+		b.WriteString(m.renderField("No Proxy:", m.noProxyInput.View(), m.focusedField == FieldNoProxy))
+		b.WriteString("\n\n")
+		b.WriteString(m.renderField("CA Bundle (PEM):", m.caCertInput.View(), m.focusedField == FieldCACert))
+		b.WriteString("\n\n")
+		b.WriteString(m.renderField("Client Certificate:", m.clientCertInput.View(), m.focusedField == FieldClientCert))
+		b.WriteString("\n\n")
+		b.WriteString(m.renderField("Client Key:", m.clientKeyInput.View(), m.focusedField == FieldClientKey))
+		b.WriteString("\n\n")
+	}
+
 	// Submit button
 	buttonStyle := theme.ButtonStyle
 	if m.focusedField == FieldSubmit {
@@ -177,7 +236,7 @@ func (m *SetupModel) View() string {
 	}
 
 	b.WriteString("\n\n")
-	b.WriteString(theme.MutedStyle.Render("Tab/Shift+Tab: Navigate | Enter: Submit | Ctrl+C: Quit"))
+	b.WriteString(theme.MutedStyle.Render("Tab/Shift+Tab: Navigate | Enter: Submit | Ctrl+O: Network options | Ctrl+C: Quit"))
 
 	// Center the content
 	content := b.String()
@@ -207,13 +266,38 @@ func (m *SetupModel) renderField(label, input string, focused bool) string {
 	return labelStyle.Render(label) + "\n" + input
 }
 
+// fieldOrder returns the navigable fields, including the network section when visible
+func (m *SetupModel) fieldOrder() []SetupField {
+	fields := []SetupField{FieldURL, FieldUsername, FieldToken}
+	if m.showAdvanced {
+		fields = append(fields, FieldProxy, FieldNoProxy, FieldCACert, FieldClientCert, FieldClientKey)
+	}
+	return append(fields, FieldSubmit)
+}
+
+func (m *SetupModel) isAdvancedField(f SetupField) bool {
+	return f >= FieldProxy && f <= FieldClientKey
+}
+
 func (m *SetupModel) nextField() {
-	m.focusedField = (m.focusedField + 1) % 4
+	fields := m.fieldOrder()
+	for i, f := range fields {
+		if f == m.focusedField {
+			m.focusedField = fields[(i+1)%len(fields)]
+			break
+		}
+	}
 	m.updateFocus()
 }
 
 func (m *SetupModel) prevField() {
-	m.focusedField = (m.focusedField + 3) % 4
+	fields := m.fieldOrder()
+	for i, f := range fields {
+		if f == m.focusedField {
+			m.focusedField = fields[(i+len(fields)-1)%len(fields)]
+			break
+		}
+	}
 	m.updateFocus()
 }
 
@@ -221,6 +305,11 @@ func (m *SetupModel) updateFocus() {
 	m.urlInput.Blur()
 	m.usernameInput.Blur()
 	m.tokenInput.Blur()
+	m.proxyInput.Blur()
+	m.noProxyInput.Blur()
+	m.caCertInput.Blur()
+	m.clientCertInput.Blur()
+	m.clientKeyInput.Blur()
 
 	switch m.focusedField {
 	case FieldURL:
@@ -229,31 +318,67 @@ func (m *SetupModel) updateFocus() {
 		m.usernameInput.Focus()
 	case FieldToken:
 		m.tokenInput.Focus()
+	case FieldProxy:
+		m.proxyInput.Focus()
+	case FieldNoProxy:
+		m.noProxyInput.Focus()
+	case FieldCACert:
+		m.caCertInput.Focus()
+	case FieldClientCert:
+		m.clientCertInput.Focus()
+	case FieldClientKey:
+		m.clientKeyInput.Focus()
 	}
 }
 
+// buildConfig assembles a configuration from the current input values
+func (m *SetupModel) buildConfig() *config.Config {
+	cfg := config.DefaultConfig()
+	cfg.Profile.BaseURL = strings.TrimSpace(m.urlInput.Value())
+	cfg.Profile.Username = strings.TrimSpace(m.usernameInput.Value())
+	cfg.Profile.APIToken = strings.TrimSpace(m.tokenInput.Value())
+	cfg.Profile.ProxyURL = strings.TrimSpace(m.proxyInput.Value())
+	cfg.Profile.NoProxy = strings.TrimSpace(m.noProxyInput.Value())
+	cfg.Profile.CACertFile = expandHome(strings.TrimSpace(m.caCertInput.Value()))
+	cfg.Profile.ClientCertFile = expandHome(strings.TrimSpace(m.clientCertInput.Value()))
+	cfg.Profile.ClientKeyFile = expandHome(strings.TrimSpace(m.clientKeyInput.Value()))
+	return cfg
+}
+
+// expandHome replaces a leading ~ with the user's home directory
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~"))
+}
+
 func (m *SetupModel) testAndSave() tea.Cmd {
 	// Validate inputs
-	url := strings.TrimSpace(m.urlInput.Value())
-	username := strings.TrimSpace(m.usernameInput.Value())
-	token := strings.TrimSpace(m.tokenInput.Value())
+	cfg := m.buildConfig()
 
-	if url == "" || username == "" || token == "" {
+	if cfg.Profile.BaseURL == "" || cfg.Profile.Username == "" || cfg.Profile.APIToken == "" {
 		m.err = fmt.Errorf("all fields are required")
 		m.testResult = "All fields are required"
 		return nil
 	}
 
+	if err := cfg.Profile.ValidateTransport(); err != nil {
+		m.err = err
+		m.testResult = fmt.Sprintf("Invalid network settings: %v", err)
+		m.showAdvanced = true
+		return nil
+	}
+
 	m.testing = true
 	m.err = nil
 	m.testResult = ""
 
 	return func() tea.Msg {
-		cfg := config.DefaultConfig()
-		cfg.Profile.BaseURL = url
-		cfg.Profile.Username = username
-		cfg.Profile.APIToken = token
-
 		client, err := jenkins.NewClient(cfg)
 		if err != nil {
 			return testConnectionResult{err: err}
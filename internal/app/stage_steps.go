@@ -0,0 +1,212 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/elogrono/jenkins-tui/internal/jenkins/models"
+	"github.com/elogrono/jenkins-tui/internal/ui/components"
+	"github.com/elogrono/jenkins-tui/internal/ui/theme"
+)
+
+// StageStepsMsg carries the steps of a pipeline stage
+type StageStepsMsg struct {
+	StageID string
+	Steps   []models.WFAPIFlowNode
+	Error   error
+}
+
+// selectedPipelineStage returns the stage highlighted in build detail
+func (m *BuildsModel) selectedPipelineStage() *models.Stage {
+	if m.pipelineRun == nil || m.selectedStage >= len(m.pipelineRun.Stages) {
+		return nil
+	}
+	return &m.pipelineRun.Stages[m.selectedStage]
+}
+
+// openStageSteps switches to the step list of the selected stage
+func (m *BuildsModel) openStageSteps() tea.Cmd {
+	stage := m.selectedPipelineStage()
+	if stage == nil || m.jobDetail == nil || m.buildDetail == nil {
+		return nil
+	}
+	m.mode = ModeStageSteps
+	m.stageSteps = nil
+	m.selectedStep = 0
+	m.stepsScroll = 0
+	return m.fetchStageSteps(m.jobDetail.Name, m.buildDetail.Number, stage.ID)
+}
+
+// applyStageSteps stores loaded steps and preselects the first failed one
+func (m *BuildsModel) applyStageSteps(msg StageStepsMsg) {
+	m.loading = false
+	if msg.Error != nil {
+		m.lastError = msg.Error
+		return
+	}
+	if stage := m.selectedPipelineStage(); stage == nil || stage.ID != msg.StageID {
+		return
+	}
+	m.stageSteps = msg.Steps
+	m.selectedStep = 0
+	for i, step := range msg.Steps {
+		if stageStatus(step.Status) == components.StageStatusFailure {
+			m.selectedStep = i
+			break
+		}
+	}
+	m.ensureStepVisible()
+}
+
+// ensureStepVisible scrolls the step list so the selection is on screen
+func (m *BuildsModel) ensureStepVisible() {
+	listHeight := maxInt(m.height-14, 3)
+	if m.selectedStep < m.stepsScroll {
+		m.stepsScroll = m.selectedStep
+	}
+	if m.selectedStep >= m.stepsScroll+listHeight {
+		m.stepsScroll = m.selectedStep - listHeight + 1
+	}
+}
+
+// selectedStageStep returns the highlighted step, or nil
+func (m *BuildsModel) selectedStageStep() *models.WFAPIFlowNode {
+	if m.selectedStep >= len(m.stageSteps) {
+		return nil
+	}
+	return &m.stageSteps[m.selectedStep]
+}
+
+// viewStageSteps renders the steps of the selected stage
+func (m *BuildsModel) viewStageSteps() string {
+	stage := m.selectedPipelineStage()
+	if stage == nil || m.buildDetail == nil {
+		return m.viewLoading()
+	}
+
+	jobName := ""
+	if m.jobDetail != nil {
+		jobName = m.jobDetail.Name
+	}
+	breadcrumb := components.NewBreadcrumb("Builds", jobName, fmt.Sprintf("#%d", m.buildDetail.Number), stage.Name).Render()
+
+	var rows []string
+	rows = append(rows, theme.SectionTitleStyle.Render(theme.IconBuild+" Steps"))
+
+	switch {
+	case m.loading && m.stageSteps == nil:
+		rows = append(rows, "  "+m.spinner.View()+" Loading steps...")
+	case len(m.stageSteps) == 0:
+		rows = append(rows, theme.MutedStyle.Render("  No steps recorded for this stage"))
+	default:
+		width := m.width - 12
+		listHeight := maxInt(m.height-14, 3)
+		end := minInt(m.stepsScroll+listHeight, len(m.stageSteps))
+		for i := m.stepsScroll; i < end; i++ {
+			rows = append(rows, m.renderStepRow(m.stageSteps[i], i == m.selectedStep, width))
+		}
+		if len(m.stageSteps) > listHeight {
+			rows = append(rows, theme.MutedStyle.Render(fmt.Sprintf("  %d-%d of %d", m.stepsScroll+1, end, len(m.stageSteps))))
+		}
+	}
+
+	panel := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Border).
+		Width(m.width-6).
+		Padding(1, 2).
+		Render(strings.Join(rows, "\n"))
+
+	content := lipgloss.JoinVertical(lipgloss.Left, breadcrumb, panel, m.renderShortcuts())
+
+	return lipgloss.NewStyle().
+		Width(m.width).
+		Height(m.height-4).
+		Padding(0, 1).
+		Render(content)
+}
+
+// renderStepRow renders one step: status, name, command and duration
+func (m *BuildsModel) renderStepRow(step models.WFAPIFlowNode, selected bool, width int) string {
+	status := stageStatus(step.Status)
+
+	statusIcon := theme.MutedStyle.Render(theme.IconPending)
+	switch status {
+	case components.StageStatusSuccess:
+		statusIcon = theme.SuccessStyle.Render(theme.IconSuccess)
+	case components.StageStatusFailure:
+		statusIcon = theme.ErrorStyle.Render(theme.IconFailure)
+	case components.StageStatusRunning:
+		statusIcon = theme.RunningStyle.Render(theme.IconRunning)
+	case components.StageStatusUnstable:
+		statusIcon = theme.WarningStyle.Render(theme.IconWarning)
+	case components.StageStatusAborted, components.StageStatusSkipped:
+		statusIcon = theme.MutedStyle.Render(theme.IconAborted)
+	}
+
+	prefix := "  "
+	if selected {
+		prefix = theme.PrimaryStyle.Render("> ")
+	}
+
+	dur := formatDuration(time.Duration(step.DurationMillis) * time.Millisecond)
+	descWidth := maxInt(width-48, 10)
+	description := strings.Join(strings.Fields(step.ParameterDescription), " ")
+
+	row := fmt.Sprintf("%s%s %-24s %-*s %s",
+		prefix,
+		statusIcon,
+		truncate(step.Name, 24),
+		descWidth,
+		truncate(description, descWidth),
+		theme.MutedStyle.Render(dur),
+	)
+
+	if selected {
+		row = theme.PrimaryStyle.Bold(true).Render(row)
+	}
+	return row
+}
+
+// openStageLog switches to the log view for the stage or one of its steps
+func (m *BuildsModel) openStageLog(stepID string, fromSteps bool) {
+	m.mode = ModeStageLogView
+	m.logStepID = stepID
+	m.logFromSteps = fromSteps
+	m.logContent, m.logLines = "", nil
+	m.viewport.SetContent("")
+}
+
+// logStep returns the step whose log is displayed, or nil for a stage log
+func (m *BuildsModel) logStep() *models.WFAPIFlowNode {
+	for i := range m.stageSteps {
+		if m.logStepID != "" && m.stageSteps[i].ID == m.logStepID {
+			return &m.stageSteps[i]
+		}
+	}
+	return nil
+}
+
+func (m *BuildsModel) fetchStageSteps(jobName string, buildNum int, stageID string) tea.Cmd {
+	m.loading = true
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		steps, err := m.client.GetStageSteps(ctx, jobName, buildNum, stageID)
+		return StageStepsMsg{StageID: stageID, Steps: steps, Error: err}
+	}
+}
+
+func (m *BuildsModel) fetchStepLog(jobName string, buildNum int, stageID, stepID string) tea.Cmd {
+	m.loading = true
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		log, err := m.client.GetStepLog(ctx, jobName, buildNum, stageID, stepID)
+		return BuildsDataMsg{LogContent: log, Error: err}
+	}
+}
@@ -0,0 +1,469 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/elogrono/jenkins-tui/internal/jenkins/models"
+	"github.com/elogrono/jenkins-tui/internal/ui/components"
+	"github.com/elogrono/jenkins-tui/internal/ui/theme"
+)
+
+// TestReportMsg carries the JUnit report of a build
+type TestReportMsg struct {
+	Build  int
+	Report *models.TestReport // nil when the build has no test results
+	Error  error
+}
+
+// testStatusFilter restricts the tests view to one kind of result
+type testStatusFilter int
+
+const (
+	testFilterAll testStatusFilter = iota
+	testFilterFailed
+	testFilterNew
+	testFilterSkipped
+	testFilterPassed
+)
+
+// String returns the label shown in the tests view
+func (f testStatusFilter) String() string {
+	switch f {
+	case testFilterFailed:
+		return "failed"
+	case testFilterNew:
+		return "new failures"
+	case testFilterSkipped:
+		return "skipped"
+	case testFilterPassed:
+		return "passed"
+	default:
+		return "all"
+	}
+}
+
+// matches reports whether a case passes the status filter
+func (f testStatusFilter) matches(c models.TestCase) bool {
+	switch f {
+	case testFilterFailed:
+		return c.IsFailure()
+	case testFilterNew:
+		return c.IsNewFailure()
+	case testFilterSkipped:
+		return c.IsSkipped()
+	case testFilterPassed:
+		return !c.IsFailure() && !c.IsSkipped()
+	default:
+		return true
+	}
+}
+
+// testRow is one line of the tests view: a failure, a suite or a case in a suite
+type testRow struct {
+	failure bool // Row belongs to the failures section
+	suite   int  // Suite index; -1 for failure rows
+	test    *models.TestCase
+}
+
+// testRows flattens the failures section and the suites tree into selectable rows
+func (m *BuildsModel) testRows() []testRow {
+	if m.testReport == nil {
+		return nil
+	}
+	keep := func(c models.TestCase) bool {
+		return m.testStatus.matches(c) && c.Matches(m.testFilter)
+	}
+
+	var rows []testRow
+	for _, c := range m.testReport.Failures() {
+		if keep(c) {
+			c := c
+			rows = append(rows, testRow{failure: true, suite: -1, test: &c})
+		}
+	}
+
+	filtering := m.testFilter != "" || m.testStatus != testFilterAll
+	for i := range m.testReport.Suites {
+		suite := &m.testReport.Suites[i]
+		var cases []testRow
+		for j := range suite.Cases {
+			if keep(suite.Cases[j]) {
+				cases = append(cases, testRow{suite: i, test: &suite.Cases[j]})
+			}
+		}
+		if filtering && len(cases) == 0 {
+			continue
+		}
+		rows = append(rows, testRow{suite: i})
+		if filtering || m.testExpanded[suite.Name] {
+			rows = append(rows, cases...)
+		}
+	}
+	return rows
+}
+
+// openTests switches to the tests view of the current build
+func (m *BuildsModel) openTests() tea.Cmd {
+	if m.jobDetail == nil || m.buildDetail == nil {
+		return nil
+	}
+	m.mode = ModeTests
+	m.testReport = nil
+	m.testExpanded = make(map[string]bool)
+	m.testFilter = ""
+	m.testStatus = testFilterAll
+	m.selectedTest = 0
+	m.testsScroll = 0
+	return m.fetchTestReport(m.jobDetail.Name, m.buildDetail.Number)
+}
+
+// applyTestReport stores a loaded report
+func (m *BuildsModel) applyTestReport(msg TestReportMsg) {
+	m.loading = false
+	if msg.Error != nil {
+		m.lastError = msg.Error
+		return
+	}
+	if m.buildDetail == nil || m.buildDetail.Number != msg.Build {
+		return
+	}
+	m.testReport = msg.Report
+	m.selectedTest = minInt(m.selectedTest, maxInt(len(m.testRows())-1, 0))
+	m.ensureTestVisible()
+}
+
+// testListHeight returns how many test rows fit on screen
+func (m *BuildsModel) testListHeight() int {
+	return maxInt(m.height-16, 3)
+}
+
+// ensureTestVisible scrolls the tests list so the selection is on screen
+func (m *BuildsModel) ensureTestVisible() {
+	if m.selectedTest < m.testsScroll {
+		m.testsScroll = m.selectedTest
+	}
+	if m.selectedTest >= m.testsScroll+m.testListHeight() {
+		m.testsScroll = m.selectedTest - m.testListHeight() + 1
+	}
+}
+
+// moveTestSelection moves the selection by delta rows
+func (m *BuildsModel) moveTestSelection(delta int) {
+	rows := m.testRows()
+	if len(rows) == 0 {
+		return
+	}
+	m.selectedTest = maxInt(0, minInt(m.selectedTest+delta, len(rows)-1))
+	m.ensureTestVisible()
+}
+
+// activateTest expands or collapses a suite, or opens the selected test case
+func (m *BuildsModel) activateTest() {
+	rows := m.testRows()
+	if m.selectedTest >= len(rows) {
+		return
+	}
+	row := rows[m.selectedTest]
+	if row.test == nil {
+		name := m.testReport.Suites[row.suite].Name
+		m.testExpanded[name] = !m.testExpanded[name]
+		return
+	}
+	m.openTestDetail(row.test, m.buildDetail.Number, ModeTests)
+}
+
+// openTestDetail shows a test case of a build; Esc returns to the from mode
+func (m *BuildsModel) openTestDetail(c *models.TestCase, build int, from BuildsMode) {
+	m.testCase = c
+	m.testCaseBuild = build
+	m.testDetailFrom = from
+	m.testDetailScroll = 0
+	m.mode = ModeTestDetail
+}
+
+// cycleTestStatus switches to the next status filter
+func (m *BuildsModel) cycleTestStatus() {
+	m.testStatus = (m.testStatus + 1) % (testFilterPassed + 1)
+	m.selectedTest = 0
+	m.testsScroll = 0
+}
+
+// viewTests renders the test report: totals, failures and the suites tree
+func (m *BuildsModel) viewTests() string {
+	if m.buildDetail == nil {
+		return m.viewLoading()
+	}
+
+	jobName := ""
+	if m.jobDetail != nil {
+		jobName = m.jobDetail.Name
+	}
+	breadcrumb := components.NewBreadcrumb("Builds", jobName, fmt.Sprintf("#%d", m.buildDetail.Number), "Tests").Render()
+
+	width := m.width - 12
+	var rows []string
+	rows = append(rows, theme.SectionTitleStyle.Render(theme.IconLog+" Test results"))
+
+	switch {
+	case m.testReport == nil && m.loading:
+		rows = append(rows, "  "+m.spinner.View()+" Loading test report...")
+	case m.testReport == nil:
+		rows = append(rows, theme.MutedStyle.Render("  No test results for this build"))
+	default:
+		rows = append(rows, m.renderTestTotals(), "")
+
+		var filters []string
+		if m.testStatus != testFilterAll {
+			filters = append(filters, "status: "+m.testStatus.String())
+		}
+		if m.testFilter != "" {
+			filters = append(filters, "matching: "+m.testFilter)
+		}
+		if m.searching {
+			rows = append(rows, theme.SearchBarStyle.Width(width).Render(m.searchInput.View()))
+		} else if len(filters) > 0 {
+			rows = append(rows, theme.AccentStyle.Render(theme.IconFilter+" "+strings.Join(filters, " │ ")))
+		}
+
+		testRows := m.testRows()
+		if len(testRows) == 0 {
+			rows = append(rows, theme.MutedStyle.Render("  No tests match"))
+		}
+		end := minInt(m.testsScroll+m.testListHeight(), len(testRows))
+		for i := m.testsScroll; i < end; i++ {
+			row := testRows[i]
+			// Section headers before the first row of each section
+			if i == 0 && row.failure {
+				rows = append(rows, theme.ErrorStyle.Render("Failures"))
+			}
+			if !row.failure && row.test == nil && (i == 0 || testRows[i-1].failure) {
+				rows = append(rows, theme.PrimaryStyle.Bold(true).Render("Suites"))
+			}
+			rows = append(rows, m.renderTestRow(row, i == m.selectedTest, width))
+		}
+		if len(testRows) > m.testListHeight() {
+			rows = append(rows, theme.MutedStyle.Render(fmt.Sprintf("  %d-%d of %d", m.testsScroll+1, end, len(testRows))))
+		}
+	}
+
+	panel := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Border).
+		Width(m.width-6).
+		Padding(1, 2).
+		Render(strings.Join(rows, "\n"))
+
+	content := lipgloss.JoinVertical(lipgloss.Left, breadcrumb, panel, m.renderShortcuts())
+
+	return lipgloss.NewStyle().
+		Width(m.width).
+		Height(m.height-4).
+		Padding(0, 1).
+		Render(content)
+}
+
+// renderTestTotals renders the passed/failed/skipped counts of the report
+func (m *BuildsModel) renderTestTotals() string {
+	r := m.testReport
+	newFailures := 0
+	for _, c := range r.Failures() {
+		if c.IsNewFailure() {
+			newFailures++
+		}
+	}
+
+	failed := theme.MutedStyle.Render(fmt.Sprintf("%s %d failed", theme.IconFailure, r.FailCount))
+	if r.FailCount > 0 {
+		failed = theme.ErrorStyle.Render(fmt.Sprintf("%s %d failed", theme.IconFailure, r.FailCount))
+		if newFailures > 0 {
+			failed += theme.WarningStyle.Render(fmt.Sprintf(" (%d new)", newFailures))
+		}
+	}
+
+	return strings.Join([]string{
+		theme.SuccessStyle.Render(fmt.Sprintf("%s %d passed", theme.IconSuccess, r.PassCount)),
+		failed,
+		theme.MutedStyle.Render(fmt.Sprintf("%s %d skipped", theme.IconPending, r.SkipCount)),
+		theme.MutedStyle.Render(fmt.Sprintf("%d tests in %s", r.Total(), formatSeconds(r.Duration))),
+	}, "  ")
+}
+
+// renderTestRow renders a failure, suite or test case row
+func (m *BuildsModel) renderTestRow(row testRow, selected bool, width int) string {
+	prefix := "  "
+	if selected {
+		prefix = theme.PrimaryStyle.Render("> ")
+	}
+
+	var line string
+	if row.test == nil {
+		suite := m.testReport.Suites[row.suite]
+		failed, passed, skipped := suite.Counts()
+		marker := theme.IconExpand
+		if m.testExpanded[suite.Name] || m.testFilter != "" || m.testStatus != testFilterAll {
+			marker = theme.IconCollapse
+		}
+		icon := theme.SuccessStyle.Render(theme.IconSuccess)
+		if failed > 0 {
+			icon = theme.ErrorStyle.Render(theme.IconFailure)
+		}
+		counts := fmt.Sprintf("%d/%d passed", passed, failed+passed+skipped)
+		line = fmt.Sprintf("%s%s %s %-*s %s %s",
+			prefix, theme.MutedStyle.Render(marker), icon,
+			maxInt(width-34, 10), truncate(suite.Name, maxInt(width-34, 10)),
+			theme.MutedStyle.Render(fmt.Sprintf("%-16s", counts)),
+			theme.MutedStyle.Render(formatSeconds(suite.Duration)))
+	} else {
+		c := row.test
+		indent := "    "
+		name := c.Name
+		if row.failure {
+			indent = ""
+			name = c.FullName()
+		}
+		nameWidth := maxInt(width-34-len(indent), 10)
+		line = fmt.Sprintf("%s%s%s %s %-*s %s",
+			prefix, indent, testCaseIcon(*c), testAgeBadge(*c),
+			nameWidth, truncate(name, nameWidth),
+			theme.MutedStyle.Render(formatSeconds(c.Duration)))
+	}
+
+	if selected {
+		line = theme.PrimaryStyle.Bold(true).Render(line)
+	}
+	return line
+}
+
+// testCaseIcon returns the status icon of a test case
+func testCaseIcon(c models.TestCase) string {
+	switch {
+	case c.IsFailure():
+		return theme.ErrorStyle.Render(theme.IconFailure)
+	case c.IsSkipped():
+		return theme.MutedStyle.Render(theme.IconPending)
+	default:
+		return theme.SuccessStyle.Render(theme.IconSuccess)
+	}
+}
+
+// testAgeBadge marks new failures and how long existing ones have failed
+func testAgeBadge(c models.TestCase) string {
+	switch {
+	case !c.IsFailure():
+		return fmt.Sprintf("%-10s", "")
+	case c.IsNewFailure():
+		return theme.WarningStyle.Render(fmt.Sprintf("%-10s", "NEW"))
+	default:
+		return theme.MutedStyle.Render(fmt.Sprintf("%-10s", fmt.Sprintf("%d builds", c.Age)))
+	}
+}
+
+// testDetailLines renders the selected test case with its error and stack trace
+func (m *BuildsModel) testDetailLines(width int) []string {
+	c := m.testCase
+	if c == nil {
+		return nil
+	}
+
+	status := c.Status
+	switch {
+	case c.IsNewFailure():
+		status += theme.WarningStyle.Render("  new failure in this build")
+	case c.IsFailure():
+		since := ""
+		if c.FailedSince > 0 {
+			since = fmt.Sprintf(" (since #%d)", c.FailedSince)
+		}
+		status += theme.MutedStyle.Render(fmt.Sprintf("  failing for %d builds%s", c.Age, since))
+	}
+
+	lines := []string{
+		testCaseIcon(*c) + " " + lipgloss.NewStyle().Bold(true).Render(c.FullName()),
+		"",
+		theme.MutedStyle.Render("Status:   ") + status,
+		theme.MutedStyle.Render("Duration: ") + formatSeconds(c.Duration),
+	}
+
+	section := func(title, text string) {
+		if strings.TrimSpace(text) == "" {
+			return
+		}
+		lines = append(lines, "", theme.SectionTitleStyle.Render(title))
+		for _, l := range strings.Split(strings.TrimRight(text, "\n"), "\n") {
+			lines = append(lines, truncate(strings.ReplaceAll(l, "\t", "    "), width))
+		}
+	}
+	section("Error", c.ErrorDetails)
+	section("Stack trace", c.ErrorStackTrace)
+	section("Skipped", c.SkippedMessage)
+	return lines
+}
+
+// testDetailRows returns how many detail lines fit on screen
+func (m *BuildsModel) testDetailRows() int {
+	return maxInt(m.height-14, 3)
+}
+
+// scrollTestDetail moves the test detail window by delta lines
+func (m *BuildsModel) scrollTestDetail(delta int) {
+	total := len(m.testDetailLines(m.width - 12))
+	m.testDetailScroll = maxInt(0, minInt(m.testDetailScroll+delta, total-m.testDetailRows()))
+}
+
+// viewTestDetail renders one test case
+func (m *BuildsModel) viewTestDetail() string {
+	if m.testCase == nil {
+		return m.viewLoading()
+	}
+
+	jobName := ""
+	if m.jobDetail != nil {
+		jobName = m.jobDetail.Name
+	}
+	breadcrumb := components.NewBreadcrumb("Builds", jobName, fmt.Sprintf("#%d", m.testCaseBuild), "Tests", m.testCase.Name).Render()
+
+	lines := m.testDetailLines(m.width - 12)
+	end := minInt(m.testDetailScroll+m.testDetailRows(), len(lines))
+	rows := lines[minInt(m.testDetailScroll, end):end]
+	if len(lines) > m.testDetailRows() {
+		rows = append(rows, theme.MutedStyle.Render(fmt.Sprintf("Lines %d-%d of %d", m.testDetailScroll+1, end, len(lines))))
+	}
+
+	panel := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Border).
+		Width(m.width-6).
+		Padding(1, 2).
+		Render(strings.Join(rows, "\n"))
+
+	content := lipgloss.JoinVertical(lipgloss.Left, breadcrumb, panel, m.renderShortcuts())
+
+	return lipgloss.NewStyle().
+		Width(m.width).
+		Height(m.height-4).
+		Padding(0, 1).
+		Render(content)
+}
+
+// formatSeconds formats a JUnit duration given in seconds
+func formatSeconds(seconds float64) string {
+	if seconds < 1 {
+		return fmt.Sprintf("%dms", int(seconds*1000))
+	}
+	return formatDuration(time.Duration(seconds * float64(time.Second)))
+}
+
+func (m *BuildsModel) fetchTestReport(jobName string, buildNum int) tea.Cmd {
+	m.loading = true
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		report, err := m.client.GetTestReport(ctx, jobName, buildNum)
+		return TestReportMsg{Build: buildNum, Report: report, Error: err}
+	}
+}
@@ -0,0 +1,105 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/elogrono/jenkins-tui/internal/jenkins/models"
+	"github.com/elogrono/jenkins-tui/internal/ui/components"
+	"github.com/elogrono/jenkins-tui/internal/ui/theme"
+)
+
+// timelineRows returns how many stage bars fit on screen
+func (m *BuildsModel) timelineRows() int {
+	return maxInt(m.height-18, 3)
+}
+
+// buildTimeline converts the pipeline stages into timeline bars
+func (m *BuildsModel) buildTimeline(width int) (*components.Timeline, time.Duration) {
+	timeline := components.NewTimeline(width)
+	if m.pipelineRun == nil {
+		return timeline, 0
+	}
+
+	stages := m.pipelineRun.Stages
+	origin := m.pipelineRun.StartTimeMillis
+	for _, stage := range stages {
+		if stage.StartTimeMillis > 0 && (origin == 0 || stage.StartTimeMillis < origin) {
+			origin = stage.StartTimeMillis
+		}
+	}
+
+	critical := models.CriticalPath(stages)
+	var paused time.Duration
+	for _, stage := range stages {
+		start := time.Duration(0)
+		if stage.StartTimeMillis > origin {
+			start = time.Duration(stage.StartTimeMillis-origin) * time.Millisecond
+		}
+		indent := 0
+		if stage.ParentID != "" {
+			// The pause of a branch is already included in its parent stage's
+			indent = 1
+		} else {
+			paused += time.Duration(stage.PauseDurationMillis) * time.Millisecond
+		}
+		timeline.AddBar(components.TimelineBar{
+			Name:     stage.Name,
+			Status:   stageStatus(stage.Status),
+			Start:    start,
+			Duration: time.Duration(stage.DurationMillis) * time.Millisecond,
+			Pause:    time.Duration(stage.PauseDurationMillis) * time.Millisecond,
+			Critical: critical[stage.ID],
+			Indent:   indent,
+		})
+	}
+	return timeline, paused
+}
+
+// viewTimeline renders the Gantt-style timeline of the build's stages
+func (m *BuildsModel) viewTimeline() string {
+	if m.buildDetail == nil {
+		return m.viewLoading()
+	}
+
+	jobName := ""
+	if m.jobDetail != nil {
+		jobName = m.jobDetail.Name
+	}
+	breadcrumb := components.NewBreadcrumb("Builds", jobName, fmt.Sprintf("#%d", m.buildDetail.Number), "Timeline").Render()
+
+	timeline, paused := m.buildTimeline(m.width - 12)
+	timeline.SetWindow(m.timelineScroll, m.timelineRows())
+
+	rows := []string{theme.SectionTitleStyle.Render(theme.IconClock + " Timeline")}
+	if len(timeline.Bars) == 0 {
+		rows = append(rows, theme.MutedStyle.Render("  No pipeline stages for this build"))
+	} else {
+		summary := "Span " + formatDuration(timeline.Span())
+		if paused > 0 {
+			summary += " │ paused " + formatDuration(paused)
+		}
+		rows = append(rows, theme.MutedStyle.Render(summary), "", timeline.Render())
+		if len(timeline.Bars) > m.timelineRows() {
+			end := minInt(m.timelineScroll+m.timelineRows(), len(timeline.Bars))
+			rows = append(rows, theme.MutedStyle.Render(fmt.Sprintf("Stages %d-%d of %d", m.timelineScroll+1, end, len(timeline.Bars))))
+		}
+	}
+
+	panel := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Border).
+		Width(m.width-6).
+		Padding(1, 2).
+		Render(strings.Join(rows, "\n"))
+
+	content := lipgloss.JoinVertical(lipgloss.Left, breadcrumb, panel, m.renderShortcuts())
+
+	return lipgloss.NewStyle().
+		Width(m.width).
+		Height(m.height-4).
+		Padding(0, 1).
+		Render(content)
+}
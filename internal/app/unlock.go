@@ -0,0 +1,100 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/elogrono/jenkins-tui/internal/logger"
+	"github.com/elogrono/jenkins-tui/internal/ui/theme"
+)
+
+// toggleWriteLock re-locks an unlocked profile or opens the unlock prompt
+func (m *Model) toggleWriteLock() tea.Cmd {
+	if m.client == nil || !m.client.IsReadOnly() {
+		return nil
+	}
+	if m.client.WritesAllowed() {
+		m.client.SetWritesUnlocked(false)
+		return nil
+	}
+
+	input := textinput.New()
+	input.Placeholder = m.client.ProfileName()
+	input.CharLimit = 128
+	input.Width = 40
+	input.Focus()
+
+	m.unlockInput = input
+	m.unlockErr = ""
+	m.unlocking = true
+	return textinput.Blink
+}
+
+// updateUnlock handles keys while the unlock prompt is open
+func (m *Model) updateUnlock(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc", "ctrl+c":
+		m.unlocking = false
+		return nil
+	case "enter":
+		if strings.TrimSpace(m.unlockInput.Value()) != m.client.ProfileName() {
+			m.unlockErr = "Profile name does not match"
+			m.unlockInput.SetValue("")
+			return nil
+		}
+		logger.Warn("Writes unlocked for session", "profile", m.client.ProfileName())
+		m.client.SetWritesUnlocked(true)
+		m.unlocking = false
+		return nil
+	}
+
+	var cmd tea.Cmd
+	m.unlockInput, cmd = m.unlockInput.Update(msg)
+	return cmd
+}
+
+// viewUnlock renders the unlock confirmation dialog
+func (m *Model) viewUnlock() string {
+	lines := []string{
+		theme.WarningStyle.Render(theme.IconLock + " Unlock writes"),
+		"",
+		fmt.Sprintf("Profile %s is read-only.", theme.AccentStyle.Render(m.client.ProfileName())),
+		"Type the profile name to allow builds, aborts and other",
+		"changes until the application exits.",
+		"",
+		m.unlockInput.View(),
+	}
+	if m.unlockErr != "" {
+		lines = append(lines, "", theme.ErrorStyle.Render(m.unlockErr))
+	}
+	lines = append(lines, "", theme.MutedStyle.Render("Enter: Unlock | Esc: Cancel"))
+
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.Warning).
+		Padding(1, 2).
+		Width(64).
+		Render(strings.Join(lines, "\n"))
+
+	return lipgloss.Place(
+		m.width,
+		m.height,
+		lipgloss.Center,
+		lipgloss.Center,
+		box,
+	)
+}
+
+// renderWriteLock renders the lock indicator for the status bar
+func (m *Model) renderWriteLock() string {
+	if m.client == nil || !m.client.IsReadOnly() {
+		return ""
+	}
+	if m.client.WritesAllowed() {
+		return theme.ErrorStyle.Render(theme.IconUnlock + " WRITES UNLOCKED")
+	}
+	return theme.WarningStyle.Render(theme.IconLock + " READ-ONLY")
+}
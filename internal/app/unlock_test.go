@@ -0,0 +1,55 @@
+package app
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/elogrono/jenkins-tui/internal/config"
+	"github.com/elogrono/jenkins-tui/internal/jenkins"
+)
+
+func TestUnlockWritesRequiresProfileName(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Profile.BaseURL = "https://jenkins.example.com"
+	cfg.Profile.Username = "admin"
+	cfg.Profile.APIToken = "token"
+	cfg.Profile.Name = "prod"
+	cfg.Profile.Production = true
+
+	client, err := jenkins.NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	m := NewModel(cfg)
+	m.client = client
+	m.state = StateReady
+
+	if canPerform(client, jenkins.PermBuild, "app", 0) {
+		t.Fatal("expected writes to be blocked on a production profile")
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlX})
+	if !m.unlocking {
+		t.Fatal("expected unlock prompt to open")
+	}
+
+	// Wrong name keeps the lock
+	m.unlockInput.SetValue("production")
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if client.WritesAllowed() || m.unlockErr == "" {
+		t.Error("expected mismatched name to be rejected")
+	}
+
+	m.unlockInput.SetValue("prod")
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if !client.WritesAllowed() || m.unlocking {
+		t.Error("expected writes to be unlocked")
+	}
+
+	// Toggling again re-locks without a prompt
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlX})
+	if client.WritesAllowed() || m.unlocking {
+		t.Error("expected writes to be locked again")
+	}
+}
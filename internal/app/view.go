@@ -84,6 +84,9 @@ GLOBAL KEYS
   Tab/Shift+Tab    Navigate tabs
   1/2/3            Jump to tab
   ?                Toggle help
+  Ctrl+X           Unlock/lock writes
+  Ctrl+A           Activity (audit log)
+  Ctrl+P           Command palette
   q/Ctrl+C         Quit
 
 DASHBOARD
@@ -92,22 +95,58 @@ DASHBOARD
   f                Quick filters
 
 VIEWS
-  /                Search
+  /                Search (status: node: health:<50)
   Enter            Open view/job
   Backspace        Clear filter
+  E/C/X (jobs)     Enable/disable, copy, delete
+  Space/V/*        Mark job/range/all
+  b/x/E (marked)   Batch trigger/abort/toggle
   Esc              Go back
   r                Refresh
 
 BUILDS
-  /                Search
+  /                Search (status: node: health:<50)
   Enter            View details
   l                View logs
+  Enter (stage)    Stage steps
+  Enter (step)     Step log
+  t                Stage timeline
+  T                Test results
+  f (tests)        Cycle status filter
+  a                Job analytics
+  c                Mark/compare builds
+  C                Compare with last green
+  F                Flaky tests
+  d (compare)      Console log diff
+  A                Build artifacts
+  d/D (artifacts)  Download one/all
+  c (artifacts)    Cancel download
+  y (artifacts)    Copy artifact URL
+  i                Answer pending input
+  R                Replay with edited scripts
+  e                View/edit job config.xml
+  L                Lint a Jenkinsfile
+  b                Trigger build
+  x                Abort running build
+  K                Keep build forever/release
+  N                Rename build
+  D                Edit build description
+  X                Delete job/build
+  E (jobs)         Enable/disable job
+  C (jobs)         Copy job
+  Space/V/*        Mark row/range/all
+  b/x/E (marked)   Batch trigger/abort/toggle
   PgUp/PgDn        Navigate pages
   Esc              Go back
   r                Refresh
 
 LOG VIEWER
-  /                Search in log
+  /                Search in log (regex)
+  n/N              Next/prev match
+  f                Grep: show only matching lines
+  F / Esc          Toggle full log / grep
+  v                Invert grep (grep -v)
+  +/-              Context lines around grep matches
   s                Toggle follow
   g/G              Top/Bottom
   PgUp/PgDn        Scroll
@@ -185,8 +224,14 @@ func (m *Model) renderTabs() string {
 // renderStatusBar renders the status bar
 func (m *Model) renderStatusBar() string {
 	left := theme.MutedStyle.Render("Jenkins TUI")
+	if identity := m.renderIdentity(); identity != "" {
+		left += theme.MutedStyle.Render(" │ ") + identity
+	}
 
 	right := theme.MutedStyle.Render("? Help | q Quit")
+	if lock := m.renderWriteLock(); lock != "" {
+		right = lock + theme.MutedStyle.Render(" │ ") + right
+	}
 
 	// Calculate padding
 	padding := m.width - lipgloss.Width(left) - lipgloss.Width(right) - 4
@@ -198,3 +243,22 @@ func (m *Model) renderStatusBar() string {
 		Width(m.width).
 		Render(fmt.Sprintf("%s%*s%s", left, padding, "", right))
 }
+
+// renderIdentity renders the authenticated user for the status bar
+func (m *Model) renderIdentity() string {
+	if m.client == nil {
+		return ""
+	}
+	if m.client.IsAnonymous() {
+		return theme.WarningStyle.Render(theme.IconUser + " anonymous")
+	}
+	user := m.client.CurrentUser()
+	if user == nil {
+		return ""
+	}
+	name := user.DisplayName()
+	if user.ID != "" && user.ID != name {
+		name += " (" + user.ID + ")"
+	}
+	return theme.InfoStyle.Render(theme.IconUser + " " + name)
+}
@@ -51,6 +51,18 @@ type ViewsModel struct {
 	viewsScroll int
 	jobsScroll  int
 
+	// Pending confirmation and last action result
+	confirm   *confirmAction
+	notice    string
+	noticeErr bool
+
+	// Name prompt of a job copy
+	jobCopier
+
+	// Marked jobs and the batch action run on them
+	jobMarks selection
+	batcher
+
 	// State
 	loading    bool
 	lastError  error
@@ -86,6 +98,12 @@ func (m *ViewsModel) SetSize(width, height int) {
 	m.height = height
 }
 
+// InputActive reports whether a text input has the keyboard, so global
+// shortcuts must not steal its keys
+func (m *ViewsModel) InputActive() bool {
+	return m.searching || m.copyPrompt != nil
+}
+
 // LoadData fetches views data
 func (m *ViewsModel) LoadData() tea.Cmd {
 	m.loading = true
@@ -110,6 +128,12 @@ func (m *ViewsModel) Update(msg tea.Msg) tea.Cmd {
 			m.jobs = msg.Jobs
 			// Sort jobs by last build timestamp (most recent first)
 			models.SortJobsByLastBuild(m.jobs)
+			// Keep the selection in range when jobs were deleted
+			if filtered := m.getFilteredJobs(); m.selectedJob >= len(filtered) {
+				m.selectedJob = maxInt(len(filtered)-1, 0)
+				m.jobsScroll = minInt(m.jobsScroll, m.selectedJob)
+			}
+			m.jobMarks.retain(jobKeys(m.jobs))
 		}
 		if msg.JobDetail != nil {
 			m.jobDetail = msg.JobDetail
@@ -119,6 +143,29 @@ func (m *ViewsModel) Update(msg tea.Msg) tea.Cmd {
 		}
 		return nil
 
+	case ActionResultMsg:
+		m.notice = msg.Message
+		m.noticeErr = msg.Error != nil
+		if msg.Error != nil {
+			m.notice = msg.Error.Error()
+		}
+		if msg.Refresh && m.mode == ViewsModeJobs && m.selectedView < len(m.views) {
+			return m.fetchViewJobs(m.views[m.selectedView].Name)
+		}
+		return nil
+
+	case BatchStartMsg:
+		return m.runBatch(msg, &m.jobMarks)
+
+	case BatchItemMsg:
+		return m.applyBatch(msg)
+
+	case BatchDoneMsg:
+		if !m.finishBatch(msg) {
+			return nil
+		}
+		return m.reloadAfterBatch()
+
 	case spinner.TickMsg:
 		if m.loading {
 			var cmd tea.Cmd
@@ -127,6 +174,27 @@ func (m *ViewsModel) Update(msg tea.Msg) tea.Cmd {
 		}
 
 	case tea.KeyMsg:
+		// Handle pending confirmation
+		if m.confirm != nil {
+			action := m.confirm
+			m.confirm = nil
+			if msg.String() == "y" || msg.String() == "Y" {
+				return action.run
+			}
+			m.notice = "Cancelled"
+			m.noticeErr = false
+			return nil
+		}
+
+		if m.copyPrompt != nil {
+			cmd, confirm, notice := m.updateCopyPrompt(m.client, msg)
+			m.confirm = confirm
+			if notice != "" {
+				m.notice, m.noticeErr = notice, true
+			}
+			return cmd
+		}
+
 		if m.searching {
 			switch msg.String() {
 			case "esc":
@@ -155,6 +223,13 @@ func (m *ViewsModel) Update(msg tea.Msg) tea.Cmd {
 			return textinput.Blink
 
 		case "esc":
+			var marks *selection
+			if m.mode == ViewsModeJobs {
+				marks = &m.jobMarks
+			}
+			if m.dismissBatchOrMarks(marks) {
+				return nil
+			}
 			switch m.mode {
 			case ViewsModeJobDetail:
 				m.mode = ViewsModeJobs
@@ -213,6 +288,40 @@ func (m *ViewsModel) Update(msg tea.Msg) tea.Cmd {
 				return m.fetchViewJobs(m.views[m.selectedView].Name)
 			}
 
+		case "b", "x", "E":
+			m.requestJobAction(msg.String())
+			return nil
+
+		case " ":
+			m.toggleMark()
+			return nil
+
+		case "V":
+			if m.mode == ViewsModeJobs {
+				m.jobMarks.markRange(jobKeys(m.getFilteredJobs()), m.selectedJob)
+			}
+			return nil
+
+		case "*":
+			if m.mode == ViewsModeJobs {
+				m.jobMarks.toggleAll(jobKeys(m.getFilteredJobs()))
+			}
+			return nil
+
+		case "C":
+			cmd, notice := m.openCopyPrompt(m.client, m.selectedListJob())
+			if notice != "" {
+				m.notice, m.noticeErr = notice, true
+			}
+			return cmd
+
+		case "X":
+			if job := m.selectedListJob(); job != nil {
+				m.confirm, m.notice = deleteJobAction(m.client, job.Name)
+				m.noticeErr = m.confirm == nil
+			}
+			return nil
+
 		case "j", "down":
 			m.navigateDown()
 
@@ -364,12 +473,14 @@ func (m *ViewsModel) viewJobsList() string {
 	header := lipgloss.JoinHorizontal(lipgloss.Left,
 		breadcrumb,
 		strings.Repeat(" ", maxInt(0, m.width-lipgloss.Width(breadcrumb)-20)),
-		theme.MutedStyle.Render(fmt.Sprintf("%d jobs", len(m.jobs))),
+		theme.MutedStyle.Render(countLabel(len(m.jobs), "jobs", m.jobMarks)),
 	)
 
-	// Search bar
+	// Search bar, or the name of a job copy
 	var searchBar string
-	if m.searching || m.filter != "" {
+	if m.copyPrompt != nil {
+		searchBar = m.copyPrompt.view(m.width)
+	} else if m.searching || m.filter != "" {
 		searchBar = theme.SearchBarStyle.Width(m.width - 4).Render(m.searchInput.View())
 	}
 
@@ -381,9 +492,18 @@ func (m *ViewsModel) viewJobsList() string {
 
 	// Jobs list
 	filtered := m.getFilteredJobs()
+	// Progress of a batch action
+	batchPanel := ""
+	if m.batch != nil {
+		batchPanel = m.batch.render(m.width - 4)
+	}
+
 	listHeight := contentHeight - 6
 	if searchBar != "" {
-		listHeight -= 3
+		listHeight -= lipgloss.Height(searchBar)
+	}
+	if batchPanel != "" {
+		listHeight -= lipgloss.Height(batchPanel)
 	}
 
 	var rows []string
@@ -394,7 +514,7 @@ func (m *ViewsModel) viewJobsList() string {
 		job := filtered[i]
 		isSelected := i == m.selectedJob
 
-		row := m.renderJobRow(job, isSelected, m.width-6)
+		row := m.renderJobRow(job, isSelected, m.jobMarks.has(job.Name), m.width-6)
 		rows = append(rows, row)
 	}
 
@@ -414,7 +534,11 @@ func (m *ViewsModel) viewJobsList() string {
 	if searchBar != "" {
 		sections = append(sections, searchBar)
 	}
-	sections = append(sections, columnHeader, list, scrollInfo, shortcuts)
+	sections = append(sections, columnHeader, list, scrollInfo)
+	if batchPanel != "" {
+		sections = append(sections, batchPanel)
+	}
+	sections = append(sections, shortcuts)
 
 	content := lipgloss.JoinVertical(lipgloss.Left, sections...)
 
@@ -588,7 +712,7 @@ func (m *ViewsModel) renderViewRow(view models.View, selected bool, width int) s
 	return style.Render(row)
 }
 
-func (m *ViewsModel) renderJobRow(job models.Job, selected bool, width int) string {
+func (m *ViewsModel) renderJobRow(job models.Job, selected, marked bool, width int) string {
 	style := lipgloss.NewStyle().Width(width)
 	if selected {
 		style = style.Background(theme.Primary).Foreground(theme.Background).Bold(true)
@@ -597,8 +721,8 @@ func (m *ViewsModel) renderJobRow(job models.Job, selected bool, width int) stri
 	// Status icon
 	statusIcon := theme.BuildStatusIcon(job.Color)
 
-	// Job name
-	name := truncate(job.Name, 28)
+	// Job name, with the characters matching the filter highlighted
+	name := renderJobName(job.Name, m.filter, 28, 30, selected)
 
 	// Last build
 	lastBuild := "-"
@@ -625,8 +749,8 @@ func (m *ViewsModel) renderJobRow(job models.Job, selected bool, width int) stri
 		resultStr = theme.BuildResultStyle(result).Render(result)
 	}
 
-	row := fmt.Sprintf("  %s %-30s %-12s %-10s %-8s %-10s",
-		statusIcon, name, lastBuild, resultStr, health, timeAgo)
+	row := fmt.Sprintf("%s %s %s %-12s %-10s %-8s %-10s",
+		renderMark(marked, selected), statusIcon, name, lastBuild, resultStr, health, timeAgo)
 
 	return style.Render(row)
 }
@@ -634,6 +758,10 @@ func (m *ViewsModel) renderJobRow(job models.Job, selected bool, width int) stri
 func (m *ViewsModel) renderShortcuts() string {
 	bar := components.NewShortkeyBar(m.width)
 
+	if m.confirm != nil {
+		return renderConfirmPrompt(m.confirm.prompt, m.width)
+	}
+
 	switch m.mode {
 	case ViewsModeList:
 		bar.Add("/", "Search").
@@ -641,8 +769,32 @@ func (m *ViewsModel) renderShortcuts() string {
 			Add("r", "Refresh").
 			Add("g/G", "Top/Bottom")
 	case ViewsModeJobs:
+		if m.copyPrompt != nil {
+			bar.Add("Enter", "Copy").
+				Add("Esc", "Cancel")
+			break
+		}
+		if m.jobMarks.count() > 0 {
+			marked, _ := shownMarkedJobs(m.jobs, m.getFilteredJobs(), m.jobMarks)
+			bar.Add("Space/V/*", "Mark/Range/All").
+				AddIf(canPerformAny(m.client, jenkins.PermBuild, marked), "b", "Build marked").
+				AddIf(canPerformAny(m.client, jenkins.PermCancel, marked), "x", "Abort marked").
+				AddIf(canPerformAny(m.client, jenkins.PermConfigure, marked), "E", "Enable/Disable marked").
+				Add("Esc", "Clear marks")
+			break
+		}
+		jobName := ""
+		if job := m.selectedListJob(); job != nil {
+			jobName = job.Name
+		}
 		bar.Add("/", "Search").
 			Add("Enter", "Job details").
+			Add("Space", "Mark").
+			AddIf(canPerform(m.client, jenkins.PermBuild, jobName, 0), "b", "Build").
+			AddIf(canPerform(m.client, jenkins.PermCancel, jobName, 0), "x", "Abort").
+			AddIf(canPerform(m.client, jenkins.PermConfigure, jobName, 0), "E", "Enable/Disable").
+			AddIf(canPerform(m.client, jenkins.PermCreate, jobFolder(jobName), 0), "C", "Copy").
+			AddIf(canPerform(m.client, jenkins.PermDelete, jobName, 0), "X", "Delete").
 			Add("Esc", "Back").
 			Add("r", "Refresh").
 			Add("g/G", "Top/Bottom")
@@ -657,37 +809,15 @@ func (m *ViewsModel) renderShortcuts() string {
 		lastUpdate = theme.MutedStyle.Render(fmt.Sprintf(" │ Updated: %s", m.lastUpdate.Format("15:04:05")))
 	}
 
-	return bar.Render() + lastUpdate
+	return bar.Render() + lastUpdate + renderNotice(m.notice, m.noticeErr)
 }
 
 func (m *ViewsModel) getFilteredViews() []models.View {
-	if m.filter == "" {
-		return m.views
-	}
-
-	var filtered []models.View
-	filterLower := strings.ToLower(m.filter)
-	for _, view := range m.views {
-		if strings.Contains(strings.ToLower(view.Name), filterLower) {
-			filtered = append(filtered, view)
-		}
-	}
-	return filtered
+	return filterViews(m.views, m.filter)
 }
 
 func (m *ViewsModel) getFilteredJobs() []models.Job {
-	if m.filter == "" {
-		return m.jobs
-	}
-
-	var filtered []models.Job
-	filterLower := strings.ToLower(m.filter)
-	for _, job := range m.jobs {
-		if strings.Contains(strings.ToLower(job.Name), filterLower) {
-			filtered = append(filtered, job)
-		}
-	}
-	return filtered
+	return filterJobs(m.jobs, m.filter)
 }
 
 func (m *ViewsModel) navigateDown() {
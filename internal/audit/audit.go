@@ -0,0 +1,169 @@
+// Package audit records mutating Jenkins requests to an append-only JSONL file.
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/elogrono/jenkins-tui/internal/config"
+)
+
+// FileName is the name of the audit log inside the config directory
+const FileName = "audit.jsonl"
+
+// Redacted replaces the value of secret parameters
+const Redacted = "********"
+
+// secretMarkers are substrings of parameter names whose values are redacted
+var secretMarkers = []string{"password", "passwd", "secret", "token", "apikey", "api_key", "credential", "private"}
+
+// Entry is a single audited request
+type Entry struct {
+	Time       time.Time         `json:"time"`
+	Profile    string            `json:"profile"`
+	User       string            `json:"user,omitempty"`
+	Method     string            `json:"method"`
+	Action     string            `json:"action"`
+	Job        string            `json:"job,omitempty"`
+	Build      int               `json:"build,omitempty"`
+	Parameters map[string]string `json:"parameters,omitempty"`
+	Status     int               `json:"status"`
+	Error      string            `json:"error,omitempty"`
+
+	// Secrets names parameters redacted whatever their name, such as the
+	// password fields of an input step
+	Secrets []string `json:"-"`
+}
+
+// Succeeded reports whether Jenkins accepted the request
+func (e Entry) Succeeded() bool {
+	return e.Error == "" && e.Status > 0 && e.Status < 400
+}
+
+// Log is an append-only JSONL audit file
+type Log struct {
+	path string
+	mu   sync.Mutex
+}
+
+// DefaultPath returns the audit log path next to the configuration file
+func DefaultPath() (string, error) {
+	configPath, err := config.ConfigPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(configPath), FileName), nil
+}
+
+// New returns a log writing to path. The file is created on first write.
+func New(path string) *Log {
+	return &Log{path: path}
+}
+
+// Path returns the location of the log file
+func (l *Log) Path() string {
+	return l.path
+}
+
+// Record appends an entry to the log
+func (l *Log) Record(e Entry) error {
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	e.Parameters = RedactParameters(e.Parameters, e.Secrets...)
+
+	line, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("error encoding audit entry: %w", err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(l.path), 0755); err != nil {
+		return fmt.Errorf("error creating audit directory: %w", err)
+	}
+
+	f, err := os.OpenFile(l.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("error opening audit log: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("error writing audit log: %w", err)
+	}
+	return nil
+}
+
+// Recent returns up to limit entries, newest first. Malformed lines are skipped.
+func (l *Log) Recent(limit int) ([]Entry, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	f, err := os.Open(l.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error opening audit log: %w", err)
+	}
+	defer f.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			continue
+		}
+		entries = append(entries, e)
+		if limit > 0 && len(entries) > limit {
+			entries = entries[1:]
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading audit log: %w", err)
+	}
+
+	// Newest first
+	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+	return entries, nil
+}
+
+// IsSecret reports whether a parameter name looks like it holds a secret
+func IsSecret(name string) bool {
+	lower := strings.ToLower(name)
+	for _, marker := range secretMarkers {
+		if strings.Contains(lower, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// RedactParameters returns a copy of params with secret values replaced: those
+// whose name looks secret, and those named in secrets
+func RedactParameters(params map[string]string, secrets ...string) map[string]string {
+	if len(params) == 0 {
+		return nil
+	}
+	redacted := make(map[string]string, len(params))
+	for name, value := range params {
+		if IsSecret(name) || slices.Contains(secrets, name) {
+			value = Redacted
+		}
+		redacted[name] = value
+	}
+	return redacted
+}
@@ -0,0 +1,75 @@
+package audit
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecordAndRecent(t *testing.T) {
+	log := New(filepath.Join(t.TempDir(), "nested", FileName))
+
+	for i, action := range []string{"build", "stop", "doDelete"} {
+		if err := log.Record(Entry{Profile: "prod", Action: action, Build: i + 1, Status: 200}); err != nil {
+			t.Fatalf("Record failed: %v", err)
+		}
+	}
+
+	entries, err := log.Recent(2)
+	if err != nil {
+		t.Fatalf("Recent failed: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+	if entries[0].Action != "doDelete" || entries[1].Action != "stop" {
+		t.Errorf("expected newest first, got %s, %s", entries[0].Action, entries[1].Action)
+	}
+	if entries[0].Time.IsZero() {
+		t.Error("expected timestamp to be set")
+	}
+
+	data, err := os.ReadFile(log.Path())
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	if lines := strings.Count(string(data), "\n"); lines != 3 {
+		t.Errorf("expected 3 JSONL lines, got %d", lines)
+	}
+}
+
+func TestRecentMissingFile(t *testing.T) {
+	entries, err := New(filepath.Join(t.TempDir(), FileName)).Recent(10)
+	if err != nil || len(entries) != 0 {
+		t.Errorf("expected no entries and no error, got %d, %v", len(entries), err)
+	}
+}
+
+func TestRedactParameters(t *testing.T) {
+	params := map[string]string{
+		"BRANCH":         "main",
+		"DB_PASSWORD":    "hunter2",
+		"deployToken":    "abc",
+		"AWS_SECRET_KEY": "xyz",
+	}
+
+	redacted := RedactParameters(params)
+
+	if redacted["BRANCH"] != "main" {
+		t.Errorf("expected BRANCH to be kept, got %q", redacted["BRANCH"])
+	}
+	for _, name := range []string{"DB_PASSWORD", "deployToken", "AWS_SECRET_KEY"} {
+		if redacted[name] != Redacted {
+			t.Errorf("expected %s to be redacted, got %q", name, redacted[name])
+		}
+	}
+	if params["DB_PASSWORD"] != "hunter2" {
+		t.Error("input map must not be modified")
+	}
+
+	// Named secrets are redacted whatever their name
+	if redacted := RedactParameters(map[string]string{"PIN": "4321"}, "PIN"); redacted["PIN"] != Redacted {
+		t.Errorf("expected PIN to be redacted, got %q", redacted["PIN"])
+	}
+}
@@ -0,0 +1,145 @@
+// Package cache stores immutable Jenkins data, such as results of finished
+// builds, as JSON files on disk.
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/elogrono/jenkins-tui/internal/config"
+)
+
+// DirName is the name of the cache directory inside the config directory
+const DirName = "cache"
+
+// Store is a directory of JSON files addressed by slash-separated keys
+type Store struct {
+	dir string
+}
+
+// DefaultDir returns the cache directory next to the configuration file
+func DefaultDir() (string, error) {
+	configPath, err := config.ConfigPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(configPath), DirName), nil
+}
+
+// New returns a store rooted at dir. Directories are created on first write.
+func New(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// Dir returns the root directory of the store
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+// Key joins parts into a cache key. Each part is escaped reversibly into a
+// single file name, so distinct parts never share an entry.
+func Key(parts ...string) string {
+	escaped := make([]string, len(parts))
+	for i, part := range parts {
+		escaped[i] = escapePart(part)
+	}
+	return strings.Join(escaped, "/")
+}
+
+// escapePart percent-encodes every byte of part that is unsafe in a file
+// name, '%' included
+func escapePart(part string) string {
+	switch part {
+	case "":
+		return "%"
+	case ".", "..":
+		return strings.ReplaceAll(part, ".", "%2E")
+	}
+	var b strings.Builder
+	for i := 0; i < len(part); i++ {
+		switch c := part[i]; {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '-', c == '.', c == '_':
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+// path returns the file holding key
+func (s *Store) path(key string) string {
+	return filepath.Join(s.dir, filepath.FromSlash(key)+".json")
+}
+
+// Get decodes the value stored under key into v. It reports false when the
+// key is missing or the file cannot be decoded.
+func (s *Store) Get(key string, v interface{}) bool {
+	data, err := os.ReadFile(s.path(key))
+	if err != nil {
+		return false
+	}
+	return json.Unmarshal(data, v) == nil
+}
+
+// Put stores v under key, replacing any previous value
+func (s *Store) Put(key string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("error encoding cache entry: %w", err)
+	}
+
+	path := s.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("error creating cache directory: %w", err)
+	}
+
+	// Write through a temporary file so readers never see a partial entry
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("error writing cache entry: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("error writing cache entry: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("error writing cache entry: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("error writing cache entry: %w", err)
+	}
+	return nil
+}
+
+// Keys returns the keys of the entries stored directly under prefix, such as
+// the builds of a job. A missing prefix has no keys.
+func (s *Store) Keys(prefix string) []string {
+	entries, err := os.ReadDir(filepath.Join(s.dir, filepath.FromSlash(prefix)))
+	if err != nil {
+		return nil
+	}
+	var keys []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || strings.HasPrefix(name, ".tmp-") || !strings.HasSuffix(name, ".json") {
+			continue
+		}
+		keys = append(keys, prefix+"/"+strings.TrimSuffix(name, ".json"))
+	}
+	return keys
+}
+
+// Delete removes the entry stored under key; a missing entry is not an error
+func (s *Store) Delete(key string) error {
+	if err := os.Remove(s.path(key)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("error deleting cache entry: %w", err)
+	}
+	return nil
+}
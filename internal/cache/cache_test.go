@@ -0,0 +1,86 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPutAndGet(t *testing.T) {
+	store := New(filepath.Join(t.TempDir(), DirName))
+
+	type entry struct {
+		Name  string
+		Count int
+	}
+
+	var got entry
+	if store.Get("missing/key", &got) {
+		t.Fatal("expected a miss for a missing key")
+	}
+
+	key := Key("tests", "jenkins.example.com", "folder/job", "42")
+	if err := store.Put(key, entry{Name: "report", Count: 3}); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	if !store.Get(key, &got) || got.Name != "report" || got.Count != 3 {
+		t.Errorf("expected stored entry, got %+v", got)
+	}
+
+	// Corrupt entries read as misses
+	path := filepath.Join(store.Dir(), filepath.FromSlash(key)+".json")
+	if err := os.WriteFile(path, []byte("{"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if store.Get(key, &got) {
+		t.Error("expected a miss for a corrupt entry")
+	}
+}
+
+func TestKey(t *testing.T) {
+	if key := Key("tests", "host:8080", "folder/job name", "..", ""); key != "tests/host%3A8080/folder%2Fjob%20name/%2E%2E/%" {
+		t.Errorf("unexpected key %q", key)
+	}
+	if strings.Contains(Key("a/../../b"), "/") {
+		t.Error("expected parts not to add path separators")
+	}
+	// Escaping is reversible, so similar names do not collide
+	seen := map[string]string{}
+	for _, name := range []string{"team/deploy", "team_deploy", "team deploy", "team%2Fdeploy"} {
+		key := Key(name)
+		if other, ok := seen[key]; ok {
+			t.Errorf("%q and %q share the key %q", name, other, key)
+		}
+		seen[key] = name
+	}
+}
+
+func TestKeysAndDelete(t *testing.T) {
+	store := New(filepath.Join(t.TempDir(), DirName))
+	if keys := store.Keys(Key("tests", "job")); keys != nil {
+		t.Errorf("expected no keys under a missing prefix, got %v", keys)
+	}
+
+	prefix := Key("tests", "folder/job")
+	for _, build := range []string{"1", "2"} {
+		if err := store.Put(prefix+"/"+Key(build), build); err != nil {
+			t.Fatalf("Put failed: %v", err)
+		}
+	}
+	keys := store.Keys(prefix)
+	if len(keys) != 2 || keys[0] != "tests/folder%2Fjob/1" {
+		t.Fatalf("unexpected keys %v", keys)
+	}
+
+	if err := store.Delete(keys[0]); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if err := store.Delete(keys[0]); err != nil {
+		t.Errorf("expected deleting a missing entry to succeed, got %v", err)
+	}
+	var got string
+	if store.Get(keys[0], &got) || len(store.Keys(prefix)) != 1 {
+		t.Error("expected the entry deleted")
+	}
+}
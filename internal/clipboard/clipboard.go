@@ -0,0 +1,20 @@
+// Package clipboard copies text to the system clipboard.
+package clipboard
+
+import (
+	"os"
+
+	"github.com/atotto/clipboard"
+	"github.com/aymanbagabas/go-osc52/v2"
+)
+
+// Copy puts text on the system clipboard. When no clipboard utility is
+// available (e.g. over SSH) it falls back to the OSC 52 escape sequence,
+// which most terminals forward to the local clipboard.
+func Copy(text string) error {
+	if err := clipboard.WriteAll(text); err == nil {
+		return nil
+	}
+	_, err := osc52.New(text).WriteTo(os.Stderr)
+	return err
+}
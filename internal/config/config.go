@@ -3,9 +3,11 @@ package config
 
 import (
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 
 	"github.com/BurntSushi/toml"
 )
@@ -15,17 +17,70 @@ type Config struct {
 	Profile Profile `toml:"profile"`
 }
 
+// Authentication modes supported by a profile
+const (
+	AuthBasic  = "basic"  // HTTP basic auth with username and API token (default)
+	AuthBearer = "bearer" // Authorization: Bearer <api_token>
+	AuthHeader = "header" // api_token sent in the header named by auth_header
+	AuthNone   = "none"   // No credentials, e.g. behind an authenticating proxy
+)
+
 // Profile represents a Jenkins server connection profile
 type Profile struct {
+	Name                  string `toml:"name"`
 	BaseURL               string `toml:"base_url"`
 	Username              string `toml:"username"`
 	APIToken              string `toml:"api_token"`
+	AuthType              string `toml:"auth_type"`
+	AuthHeader            string `toml:"auth_header"`
 	InsecureSkipTLSVerify bool   `toml:"insecure_skip_tls_verify"`
 	TimeoutSeconds        int    `toml:"timeout_seconds"`
 	AutoRefreshSeconds    int    `toml:"auto_refresh_seconds"`
 	MaxBuildsPerJob       int    `toml:"max_builds_per_job"`
 	MaxLogBytes           int    `toml:"max_log_bytes"`
 	RateLimitRPS          int    `toml:"rate_limit_rps"`
+
+	// Safety: production profiles are read-only unless read_only = false
+	Production bool  `toml:"production"`
+	ReadOnly   *bool `toml:"read_only"`
+
+	// Network settings for controllers behind proxies or private PKI
+	ProxyURL       string `toml:"proxy_url"`
+	NoProxy        string `toml:"no_proxy"`
+	CACertFile     string `toml:"ca_cert_file"`
+	ClientCertFile string `toml:"client_cert_file"`
+	ClientKeyFile  string `toml:"client_key_file"`
+
+	// Headers added to every request (e.g. for OAuth reverse proxies)
+	ExtraHeaders map[string]string `toml:"extra_headers"`
+}
+
+// IsReadOnly reports whether write requests must be blocked for this profile.
+// An explicit read_only setting wins; otherwise production profiles are read-only.
+func (p *Profile) IsReadOnly() bool {
+	if p.ReadOnly != nil {
+		return *p.ReadOnly
+	}
+	return p.Production
+}
+
+// DisplayName returns the profile name, falling back to the Jenkins host
+func (p *Profile) DisplayName() string {
+	if p.Name != "" {
+		return p.Name
+	}
+	if u, err := url.Parse(p.BaseURL); err == nil && u.Host != "" {
+		return u.Host
+	}
+	return p.BaseURL
+}
+
+// AuthMode returns the normalized authentication mode, defaulting to basic
+func (p *Profile) AuthMode() string {
+	if p.AuthType == "" {
+		return AuthBasic
+	}
+	return strings.ToLower(p.AuthType)
 }
 
 // DefaultConfig returns a configuration with sensible defaults
@@ -122,9 +177,17 @@ func (c *Config) Save() error {
 
 // IsConfigured returns true if the essential fields are set
 func (c *Config) IsConfigured() bool {
-	return c.Profile.BaseURL != "" &&
-		c.Profile.Username != "" &&
-		c.Profile.APIToken != ""
+	if c.Profile.BaseURL == "" {
+		return false
+	}
+	switch c.Profile.AuthMode() {
+	case AuthNone:
+		return true
+	case AuthBearer, AuthHeader:
+		return c.Profile.APIToken != ""
+	default:
+		return c.Profile.Username != "" && c.Profile.APIToken != ""
+	}
 }
 
 // Validate checks if the configuration is valid
@@ -132,11 +195,8 @@ func (c *Config) Validate() error {
 	if c.Profile.BaseURL == "" {
 		return fmt.Errorf("base_url is required")
 	}
-	if c.Profile.Username == "" {
-		return fmt.Errorf("username is required")
-	}
-	if c.Profile.APIToken == "" {
-		return fmt.Errorf("api_token is required")
+	if err := c.Profile.ValidateAuth(); err != nil {
+		return err
 	}
 	if c.Profile.TimeoutSeconds <= 0 {
 		c.Profile.TimeoutSeconds = 15
@@ -153,5 +213,91 @@ func (c *Config) Validate() error {
 	if c.Profile.RateLimitRPS <= 0 {
 		c.Profile.RateLimitRPS = 5
 	}
+	return c.Profile.ValidateTransport()
+}
+
+// ValidateAuth checks that the credentials required by the auth mode are present
+func (p *Profile) ValidateAuth() error {
+	switch p.AuthMode() {
+	case AuthBasic:
+		if p.Username == "" {
+			return fmt.Errorf("username is required")
+		}
+		if p.APIToken == "" {
+			return fmt.Errorf("api_token is required")
+		}
+	case AuthBearer:
+		if p.APIToken == "" {
+			return fmt.Errorf("api_token is required for bearer authentication")
+		}
+	case AuthHeader:
+		if p.AuthHeader == "" {
+			return fmt.Errorf("auth_header is required for header authentication")
+		}
+		if p.APIToken == "" {
+			return fmt.Errorf("api_token is required for header authentication")
+		}
+	case AuthNone:
+	default:
+		return fmt.Errorf("unknown auth_type %q (expected basic, bearer, header or none)", p.AuthType)
+	}
 	return nil
 }
+
+// ValidateTransport checks the proxy and TLS settings of the profile.
+// Certificate contents are parsed later by the Jenkins client; here we only
+// make sure the values are well formed and the referenced files are readable.
+func (p *Profile) ValidateTransport() error {
+	if p.ProxyURL != "" {
+		u, err := url.Parse(p.ProxyURL)
+		if err != nil {
+			return fmt.Errorf("proxy_url is not a valid URL: %w", err)
+		}
+		switch u.Scheme {
+		case "http", "https", "socks5":
+		default:
+			return fmt.Errorf("proxy_url must use http, https or socks5 scheme, got %q", u.Scheme)
+		}
+		if u.Host == "" {
+			return fmt.Errorf("proxy_url is missing a host")
+		}
+	}
+
+	if err := checkReadable("ca_cert_file", p.CACertFile); err != nil {
+		return err
+	}
+
+	if (p.ClientCertFile == "") != (p.ClientKeyFile == "") {
+		return fmt.Errorf("client_cert_file and client_key_file must be set together")
+	}
+	if err := checkReadable("client_cert_file", p.ClientCertFile); err != nil {
+		return err
+	}
+	if err := checkReadable("client_key_file", p.ClientKeyFile); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// checkReadable verifies that an optional file setting points to a readable file
+func checkReadable(field, path string) error {
+	if path == "" {
+		return nil
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("%s: file %s does not exist", field, path)
+		}
+		return fmt.Errorf("%s: %w", field, err)
+	}
+	if info.IsDir() {
+		return fmt.Errorf("%s: %s is a directory", field, path)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("%s: %w", field, err)
+	}
+	return f.Close()
+}
@@ -61,6 +61,27 @@ func TestIsConfigured(t *testing.T) {
 			},
 			expected: true,
 		},
+		{
+			name: "bearer without username",
+			cfg: &Config{
+				Profile: Profile{
+					BaseURL:  "https://jenkins.example.com",
+					AuthType: AuthBearer,
+					APIToken: "secret-token",
+				},
+			},
+			expected: true,
+		},
+		{
+			name: "no auth",
+			cfg: &Config{
+				Profile: Profile{
+					BaseURL:  "https://jenkins.example.com",
+					AuthType: AuthNone,
+				},
+			},
+			expected: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -104,6 +125,27 @@ func TestValidate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "header auth without header name",
+			cfg: &Config{
+				Profile: Profile{
+					BaseURL:  "https://jenkins.example.com",
+					AuthType: AuthHeader,
+					APIToken: "token",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown auth type",
+			cfg: &Config{
+				Profile: Profile{
+					BaseURL:  "https://jenkins.example.com",
+					AuthType: "digest",
+				},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -182,3 +224,69 @@ rate_limit_rps = 10
 		t.Error("APIToken mismatch")
 	}
 }
+
+func TestValidateTransport(t *testing.T) {
+	tmpDir := t.TempDir()
+	certFile := filepath.Join(tmpDir, "client.crt")
+	if err := os.WriteFile(certFile, []byte("cert"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		profile Profile
+		wantErr bool
+	}{
+		{name: "no network settings", profile: Profile{}, wantErr: false},
+		{name: "valid proxy", profile: Profile{ProxyURL: "http://proxy.example.com:3128"}, wantErr: false},
+		{name: "proxy without scheme", profile: Profile{ProxyURL: "proxy.example.com:3128"}, wantErr: true},
+		{name: "unsupported proxy scheme", profile: Profile{ProxyURL: "ftp://proxy.example.com"}, wantErr: true},
+		{name: "missing CA file", profile: Profile{CACertFile: filepath.Join(tmpDir, "missing.pem")}, wantErr: true},
+		{name: "CA file is a directory", profile: Profile{CACertFile: tmpDir}, wantErr: true},
+		{name: "cert without key", profile: Profile{ClientCertFile: certFile}, wantErr: true},
+		{name: "cert and key", profile: Profile{ClientCertFile: certFile, ClientKeyFile: certFile}, wantErr: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.profile.ValidateTransport()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateTransport() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestIsReadOnly(t *testing.T) {
+	yes, no := true, false
+
+	tests := []struct {
+		name     string
+		profile  Profile
+		expected bool
+	}{
+		{name: "default", profile: Profile{}, expected: false},
+		{name: "production defaults to read-only", profile: Profile{Production: true}, expected: true},
+		{name: "production explicitly writable", profile: Profile{Production: true, ReadOnly: &no}, expected: false},
+		{name: "explicit read-only", profile: Profile{ReadOnly: &yes}, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.profile.IsReadOnly(); got != tt.expected {
+				t.Errorf("IsReadOnly() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestProfileDisplayName(t *testing.T) {
+	p := Profile{BaseURL: "https://jenkins.example.com/"}
+	if got := p.DisplayName(); got != "jenkins.example.com" {
+		t.Errorf("expected host as display name, got %q", got)
+	}
+	p.Name = "production"
+	if got := p.DisplayName(); got != "production" {
+		t.Errorf("expected profile name, got %q", got)
+	}
+}
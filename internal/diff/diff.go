@@ -0,0 +1,225 @@
+// Package diff computes line diffs and renders them as unified hunks.
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Kind is the role of a line in a diff
+type Kind int
+
+const (
+	Equal Kind = iota
+	Delete
+	Insert
+)
+
+// maxEdits bounds the Myers search; beyond it the differing middle is
+// reported as a plain delete followed by an insert
+const maxEdits = 1000
+
+// Line is one line of a diff
+type Line struct {
+	Kind Kind
+	Text string
+}
+
+// Hunk is a run of changes with surrounding context lines
+type Hunk struct {
+	AStart, ALen int // 1-based start and length in the old text
+	BStart, BLen int // 1-based start and length in the new text
+	Lines        []Line
+}
+
+// Header returns the "@@ -a,n +b,m @@" header of the hunk
+func (h Hunk) Header() string {
+	return fmt.Sprintf("@@ -%d,%d +%d,%d @@", h.AStart, h.ALen, h.BStart, h.BLen)
+}
+
+// Lines returns the line diff turning a into b
+func Lines(a, b []string) []Line {
+	// Common prefix and suffix never take part in the edit script
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	var out []Line
+	for _, s := range a[:prefix] {
+		out = append(out, Line{Equal, s})
+	}
+	out = append(out, myers(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	for _, s := range a[len(a)-suffix:] {
+		out = append(out, Line{Equal, s})
+	}
+	return out
+}
+
+// myers computes a shortest edit script with the O(ND) algorithm
+func myers(a, b []string) []Line {
+	n, m := len(a), len(b)
+	if n == 0 || m == 0 {
+		return replace(a, b)
+	}
+
+	limit := min(n+m, maxEdits)
+	offset := limit + 1
+	v := make([]int, 2*limit+2)
+	var trace [][]int
+
+	for d := 0; d <= limit; d++ {
+		trace = append(trace, append([]int(nil), v...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				return backtrack(a, b, trace, offset, d)
+			}
+		}
+	}
+	return replace(a, b)
+}
+
+// backtrack walks the saved frontiers back from (n, m) to build the script
+func backtrack(a, b []string, trace [][]int, offset, d int) []Line {
+	x, y := len(a), len(b)
+	var rev []Line
+	for ; d > 0; d-- {
+		v := trace[d]
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := v[offset+prevK]
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			x--
+			y--
+			rev = append(rev, Line{Equal, a[x]})
+		}
+		if x == prevX {
+			y--
+			rev = append(rev, Line{Insert, b[y]})
+		} else {
+			x--
+			rev = append(rev, Line{Delete, a[x]})
+		}
+	}
+	for x > 0 && y > 0 {
+		x--
+		y--
+		rev = append(rev, Line{Equal, a[x]})
+	}
+
+	out := make([]Line, len(rev))
+	for i, l := range rev {
+		out[len(rev)-1-i] = l
+	}
+	return out
+}
+
+// replace reports a as deleted and b as inserted
+func replace(a, b []string) []Line {
+	out := make([]Line, 0, len(a)+len(b))
+	for _, s := range a {
+		out = append(out, Line{Delete, s})
+	}
+	for _, s := range b {
+		out = append(out, Line{Insert, s})
+	}
+	return out
+}
+
+// Hunks groups a diff into hunks with up to context unchanged lines around
+// each change. Changes closer than 2*context lines share a hunk.
+func Hunks(lines []Line, context int) []Hunk {
+	// 1-based line numbers in a and b at each diff position
+	aPos := make([]int, len(lines)+1)
+	bPos := make([]int, len(lines)+1)
+	aPos[0], bPos[0] = 1, 1
+	for i, l := range lines {
+		aPos[i+1], bPos[i+1] = aPos[i], bPos[i]
+		if l.Kind != Insert {
+			aPos[i+1]++
+		}
+		if l.Kind != Delete {
+			bPos[i+1]++
+		}
+	}
+
+	var hunks []Hunk
+	from, to := -1, -1 // Diff range of the hunk being built
+	flush := func() {
+		if from < 0 {
+			return
+		}
+		h := Hunk{
+			AStart: aPos[from], ALen: aPos[to] - aPos[from],
+			BStart: bPos[from], BLen: bPos[to] - bPos[from],
+			Lines: lines[from:to],
+		}
+		hunks = append(hunks, h)
+	}
+
+	for i, l := range lines {
+		if l.Kind == Equal {
+			continue
+		}
+		start := max(i-context, 0)
+		end := min(i+1+context, len(lines))
+		if from >= 0 && start <= to {
+			to = end
+			continue
+		}
+		flush()
+		from, to = start, end
+	}
+	flush()
+	return hunks
+}
+
+// Unified renders the diff of a and b in unified format
+func Unified(aName, bName string, a, b []string, context int) string {
+	hunks := Hunks(Lines(a, b), context)
+	if len(hunks) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- %s\n+++ %s\n", aName, bName)
+	for _, h := range hunks {
+		sb.WriteString(h.Header())
+		sb.WriteByte('\n')
+		for _, l := range h.Lines {
+			switch l.Kind {
+			case Equal:
+				sb.WriteByte(' ')
+			case Delete:
+				sb.WriteByte('-')
+			case Insert:
+				sb.WriteByte('+')
+			}
+			sb.WriteString(l.Text)
+			sb.WriteByte('\n')
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,67 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLines(t *testing.T) {
+	a := []string{"a", "b", "c", "d"}
+	b := []string{"a", "c", "d", "e"}
+
+	var got []string
+	for _, l := range Lines(a, b) {
+		got = append(got, [...]string{" ", "-", "+"}[l.Kind]+l.Text)
+	}
+	want := []string{" a", "-b", " c", " d", "+e"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestLinesIdentical(t *testing.T) {
+	for _, l := range Lines([]string{"x", "y"}, []string{"x", "y"}) {
+		if l.Kind != Equal {
+			t.Errorf("expected only equal lines, got %+v", l)
+		}
+	}
+	if out := Unified("a", "b", []string{"x"}, []string{"x"}, 3); out != "" {
+		t.Errorf("expected no diff, got %q", out)
+	}
+}
+
+func TestHunksSplitsDistantChanges(t *testing.T) {
+	var a, b []string
+	for i := 0; i < 20; i++ {
+		line := string(rune('a' + i))
+		a = append(a, line)
+		b = append(b, line)
+	}
+	b[2] = "X"
+	b[17] = "Y"
+
+	hunks := Hunks(Lines(a, b), 2)
+	if len(hunks) != 2 {
+		t.Fatalf("expected 2 hunks, got %d", len(hunks))
+	}
+	if h := hunks[0].Header(); h != "@@ -1,5 +1,5 @@" {
+		t.Errorf("unexpected first header %q", h)
+	}
+	if h := hunks[1].Header(); h != "@@ -16,5 +16,5 @@" {
+		t.Errorf("unexpected second header %q", h)
+	}
+
+	// Close changes share a hunk
+	b[5] = "Z"
+	if hunks := Hunks(Lines(a, b), 2); len(hunks) != 2 || hunks[0].ALen != 8 {
+		t.Errorf("expected changes at 3 and 6 merged, got %+v", hunks)
+	}
+}
+
+func TestUnified(t *testing.T) {
+	out := Unified("#1", "#2", []string{"start", "ok", "end"}, []string{"start", "failed", "end"}, 1)
+	want := "--- #1\n+++ #2\n@@ -1,3 +1,3 @@\n start\n-ok\n+failed\n end\n"
+	if out != want {
+		t.Errorf("unexpected diff:\n%s", out)
+	}
+}
@@ -0,0 +1,142 @@
+// Package fuzzy scores how well a typed pattern matches a name, for pickers
+// and list filters that should forgive skipped characters.
+package fuzzy
+
+import (
+	"time"
+	"unicode"
+)
+
+// Scoring weights. A matched character is worth more at the start of a word
+// and right after the previous match; every skipped character costs a point.
+const (
+	scoreMatch       = 16
+	bonusFirst       = 10 // First character of the text
+	bonusBoundary    = 8  // After a separator such as / - _ . or a space
+	bonusCamel       = 7  // Upper case letter following a lower case one
+	bonusConsecutive = 8
+	penaltyGap       = 1
+)
+
+// noScore marks a pattern prefix that cannot end at a position
+const noScore = -1 << 30
+
+// Result is a successful match
+type Result struct {
+	Score     int
+	Positions []int // Rune indexes of the matched characters in the text
+}
+
+// Match reports whether pattern is a case-insensitive subsequence of text and
+// scores the best alignment. An empty pattern matches everything with score 0.
+func Match(pattern, text string) (Result, bool) {
+	p := []rune(pattern)
+	t := []rune(text)
+	if len(p) == 0 {
+		return Result{}, true
+	}
+	if len(p) > len(t) {
+		return Result{}, false
+	}
+	for i := range p {
+		p[i] = unicode.ToLower(p[i])
+	}
+
+	// scores[i][j] is the best score of p[:i+1] with p[i] matched at t[j];
+	// from[i][j] is where p[i-1] was matched on that alignment
+	scores := make([][]int, len(p))
+	from := make([][]int, len(p))
+	for i := range p {
+		scores[i] = make([]int, len(t))
+		from[i] = make([]int, len(t))
+		// The best p[i-1] match before j, with the gap in between paid
+		run, runAt := noScore, -1
+		for j := range t {
+			scores[i][j] = noScore
+			if unicode.ToLower(t[j]) == p[i] {
+				bonus := boundaryBonus(t, j)
+				switch {
+				case i == 0:
+					// The first character decides where the match starts
+					scores[i][j] = scoreMatch + 2*bonus - j*penaltyGap
+				default:
+					if j > 0 && scores[i-1][j-1] != noScore {
+						scores[i][j] = scores[i-1][j-1] + scoreMatch + maxInt(bonus, bonusConsecutive)
+						from[i][j] = j - 1
+					}
+					if run != noScore && run+scoreMatch+bonus > scores[i][j] {
+						scores[i][j] = run + scoreMatch + bonus
+						from[i][j] = runAt
+					}
+				}
+			}
+			if i > 0 {
+				if run != noScore {
+					run -= penaltyGap
+				}
+				if scores[i-1][j] > run {
+					run, runAt = scores[i-1][j], j
+				}
+			}
+		}
+	}
+
+	last := len(p) - 1
+	best, end := noScore, -1
+	for j, score := range scores[last] {
+		if score > best {
+			best, end = score, j
+		}
+	}
+	if end < 0 {
+		return Result{}, false
+	}
+
+	positions := make([]int, len(p))
+	for i, j := last, end; i >= 0; i-- {
+		positions[i] = j
+		j = from[i][j]
+	}
+	return Result{Score: best, Positions: positions}, true
+}
+
+// boundaryBonus rewards matching the first letter of a word in t at j
+func boundaryBonus(t []rune, j int) int {
+	if j == 0 {
+		return bonusFirst
+	}
+	prev, cur := t[j-1], t[j]
+	switch {
+	case prev == '/' || prev == '-' || prev == '_' || prev == '.' || prev == ' ' || prev == '#' || prev == ':':
+		return bonusBoundary
+	case unicode.IsLower(prev) && unicode.IsUpper(cur):
+		return bonusCamel
+	case !unicode.IsDigit(prev) && unicode.IsDigit(cur):
+		return bonusBoundary
+	}
+	return 0
+}
+
+// Recency returns a bonus for something last active at t, worth about one
+// matched character within the hour and fading out over a week
+func Recency(t, now time.Time) int {
+	if t.IsZero() {
+		return 0
+	}
+	switch age := now.Sub(t); {
+	case age < time.Hour:
+		return 24
+	case age < 24*time.Hour:
+		return 16
+	case age < 7*24*time.Hour:
+		return 8
+	}
+	return 0
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,59 @@
+package fuzzy
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestMatch(t *testing.T) {
+	r, ok := Match("dpl", "team/Deploy")
+	if !ok {
+		t.Fatal("expected a subsequence match")
+	}
+	if fmt.Sprint(r.Positions) != "[5 7 8]" {
+		t.Errorf("unexpected positions %v", r.Positions)
+	}
+
+	if _, ok := Match("xyz", "team/deploy"); ok {
+		t.Error("expected no match")
+	}
+	if _, ok := Match("", "anything"); !ok {
+		t.Error("expected an empty pattern to match")
+	}
+}
+
+func TestMatchPrefersWordStarts(t *testing.T) {
+	// "ad" matches both "api/deploy" (two word starts) and "broadcast"
+	words, _ := Match("ad", "api/deploy")
+	inside, _ := Match("ad", "broadcast")
+	if words.Score <= inside.Score {
+		t.Errorf("expected word starts to win: %d vs %d", words.Score, inside.Score)
+	}
+	if fmt.Sprint(words.Positions) != "[0 4]" {
+		t.Errorf("expected the word starts matched, got %v", words.Positions)
+	}
+
+	// Consecutive characters beat scattered ones
+	run, _ := Match("web", "web-frontend")
+	scattered, _ := Match("web", "w-e-b-frontend")
+	if run.Score <= scattered.Score {
+		t.Errorf("expected consecutive matches to win: %d vs %d", run.Score, scattered.Score)
+	}
+
+	// The short job name after the folder is found at its word start
+	short, _ := Match("api", "team/backend-api")
+	if fmt.Sprint(short.Positions) != "[13 14 15]" {
+		t.Errorf("expected the trailing word matched, got %v", short.Positions)
+	}
+}
+
+func TestRecency(t *testing.T) {
+	now := time.Now()
+	if Recency(now.Add(-time.Minute), now) <= Recency(now.Add(-2*time.Hour), now) {
+		t.Error("expected recent activity to score higher")
+	}
+	if Recency(time.Time{}, now) != 0 || Recency(now.Add(-30*24*time.Hour), now) != 0 {
+		t.Error("expected no bonus for unknown or old activity")
+	}
+}
@@ -0,0 +1,190 @@
+package jenkins
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/elogrono/jenkins-tui/internal/logger"
+)
+
+// ArchiveName is the artifact path Jenkins serves all artifacts of a build from, zipped
+const ArchiveName = "*zip*/archive.zip"
+
+// ErrBinaryArtifact is returned when previewing an artifact that is not text
+var ErrBinaryArtifact = errors.New("artifact is not a text file")
+
+// ArtifactPreview is the beginning of a text artifact
+type ArtifactPreview struct {
+	Content   string
+	Truncated bool // The artifact is larger than the requested size
+}
+
+// artifactPath returns the request path of an artifact of a build
+func artifactPath(jobName string, buildNumber int, relativePath string) string {
+	prefix := "/job/" + encodeJobPath(jobName) + "/" + itoa(buildNumber) + "/artifact/"
+	// The archive is a Jenkins route rather than a file; keep its asterisks
+	if relativePath == ArchiveName {
+		return prefix + ArchiveName
+	}
+	parts := strings.Split(relativePath, "/")
+	for i, part := range parts {
+		parts[i] = url.PathEscape(part)
+	}
+	return prefix + strings.Join(parts, "/")
+}
+
+// ArtifactURL returns the absolute URL of an artifact of a build
+func (c *Client) ArtifactURL(jobName string, buildNumber int, relativePath string) string {
+	return c.baseURL + artifactPath(jobName, buildNumber, relativePath)
+}
+
+// GetArtifact fetches up to maxBytes of a text artifact for previewing. It
+// returns ErrBinaryArtifact when the content does not look like text.
+func (c *Client) GetArtifact(ctx context.Context, jobName string, buildNumber int, relativePath string, maxBytes int) (*ArtifactPreview, error) {
+	resp, err := c.doRequest(ctx, http.MethodGet, artifactPath(jobName, buildNumber, relativePath), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return nil, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, string(body))
+	}
+
+	// Read one extra byte to tell whether the artifact was cut
+	body, err := io.ReadAll(io.LimitReader(resp.Body, int64(maxBytes)+1))
+	if err != nil {
+		return nil, fmt.Errorf("error reading response: %w", err)
+	}
+	preview := &ArtifactPreview{}
+	if len(body) > maxBytes {
+		body = body[:maxBytes]
+		preview.Truncated = true
+	}
+	if isBinary(body, preview.Truncated) {
+		return nil, ErrBinaryArtifact
+	}
+	preview.Content = string(body)
+	return preview, nil
+}
+
+// isBinary reports whether data looks like binary content. A truncated
+// buffer may end in the middle of a UTF-8 sequence, which is tolerated.
+func isBinary(data []byte, truncated bool) bool {
+	if bytes.IndexByte(data, 0) >= 0 {
+		return true
+	}
+	if truncated {
+		for i := 0; i < utf8.UTFMax && len(data) > 0 && !utf8.Valid(data); i++ {
+			data = data[:len(data)-1]
+		}
+	}
+	return !utf8.Valid(data)
+}
+
+// DownloadProgress reports the bytes written so far; total is -1 when unknown
+type DownloadProgress func(written, total int64)
+
+// DownloadArtifact saves an artifact into dir under its file name and
+// returns the path of the written file. An existing file is never replaced:
+// the download gets a free name such as "report (1).txt" instead. Pass
+// ArchiveName to download all artifacts of the build as a zip file. The
+// profile timeout does not apply to reading the file; bound the download
+// with ctx instead.
+func (c *Client) DownloadArtifact(ctx context.Context, jobName string, buildNumber int, relativePath, dir string, progress DownloadProgress) (string, error) {
+	name := filepath.Base(relativePath)
+	if relativePath == ArchiveName {
+		name = strings.ReplaceAll(jobName, "/", "_") + "-" + itoa(buildNumber) + "-artifacts.zip"
+	}
+	resp, err := c.doRequest(ctx, http.MethodGet, artifactPath(jobName, buildNumber, relativePath), nil, streaming)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return "", ErrNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("error creating download directory: %w", err)
+	}
+	// Write next to the destination so a failed download leaves no partial file
+	tmp, err := os.CreateTemp(dir, "."+name+".*.part")
+	if err != nil {
+		return "", fmt.Errorf("error creating file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	w := &progressWriter{w: tmp, total: resp.ContentLength, report: progress}
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		tmp.Close()
+		return "", fmt.Errorf("error downloading %s: %w", relativePath, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return "", fmt.Errorf("error writing file: %w", err)
+	}
+	dest, err := freeFileName(dir, name)
+	if err != nil {
+		return "", fmt.Errorf("error writing file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), dest); err != nil {
+		return "", fmt.Errorf("error writing file: %w", err)
+	}
+
+	logger.Info("Artifact downloaded", "job", jobName, "build", buildNumber, "artifact", relativePath, "path", dest, "bytes", w.written)
+	return dest, nil
+}
+
+// freeFileName returns the path of name in dir, numbered like "name (1).ext"
+// when a file of that name exists already
+func freeFileName(dir, name string) (string, error) {
+	ext := filepath.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+	for i := 0; i < 1000; i++ {
+		candidate := name
+		if i > 0 {
+			candidate = fmt.Sprintf("%s (%d)%s", base, i, ext)
+		}
+		path := filepath.Join(dir, candidate)
+		if _, err := os.Lstat(path); errors.Is(err, os.ErrNotExist) {
+			return path, nil
+		} else if err != nil {
+			return "", err
+		}
+	}
+	return "", fmt.Errorf("no free name for %s in %s", name, dir)
+}
+
+// progressWriter counts the bytes written through it
+type progressWriter struct {
+	w       io.Writer
+	written int64
+	total   int64
+	report  DownloadProgress
+}
+
+func (p *progressWriter) Write(b []byte) (int, error) {
+	n, err := p.w.Write(b)
+	p.written += int64(n)
+	if p.report != nil {
+		p.report(p.written, p.total)
+	}
+	return n, err
+}
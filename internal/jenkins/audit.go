@@ -0,0 +1,175 @@
+package jenkins
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/elogrono/jenkins-tui/internal/audit"
+	"github.com/elogrono/jenkins-tui/internal/logger"
+)
+
+// auditValueLimit bounds a parameter value in the audit log; longer values,
+// such as replayed scripts, are recorded by size only
+const auditValueLimit = 256
+
+// recordAudit appends a mutating request to the audit log, with the
+// parameters of its query string and form-encoded body; secrets are redacted
+// along with the parameters whose name looks secret. Failures to write are
+// logged and never fail the request itself.
+func (c *Client) recordAudit(method, path, contentType string, payload []byte, secrets []string, resp *http.Response, reqErr error) {
+	if c.auditLog == nil {
+		return
+	}
+
+	job, build, action, params := describeRequest(path)
+	if strings.HasPrefix(contentType, formContentType) {
+		for name, value := range formParameters(payload) {
+			if params == nil {
+				params = make(map[string]string)
+			}
+			params[name] = value
+		}
+	}
+	for name, value := range params {
+		if len(value) > auditValueLimit {
+			params[name] = fmt.Sprintf("(%d bytes)", len(value))
+		}
+	}
+	entry := audit.Entry{
+		Profile:    c.profileName,
+		User:       c.auditUser(),
+		Method:     method,
+		Action:     action,
+		Job:        job,
+		Build:      build,
+		Parameters: params,
+		Secrets:    secrets,
+	}
+
+	switch {
+	case resp != nil:
+		entry.Status = resp.StatusCode
+	case errors.Is(reqErr, ErrForbidden):
+		entry.Status = http.StatusForbidden
+	case errors.Is(reqErr, ErrUnauthorized):
+		entry.Status = http.StatusUnauthorized
+	}
+	if reqErr != nil {
+		entry.Error = reqErr.Error()
+	}
+
+	if err := c.auditLog.Record(entry); err != nil {
+		logger.Warn("Failed to write audit entry", "error", err, "action", action)
+	}
+}
+
+// auditUser returns the best known identity of the caller
+func (c *Client) auditUser() string {
+	if user := c.CurrentUser(); user != nil && user.ID != "" {
+		return user.ID
+	}
+	return c.username
+}
+
+// formParameters returns the fields of a form-encoded body. The "json" field
+// of Jenkins forms is expanded into its fields, and a "parameter" list of
+// name/value pairs, as input steps submit, into one parameter per name, so
+// that secrets among them are redacted by name like any other parameter.
+func formParameters(payload []byte) map[string]string {
+	values, err := url.ParseQuery(string(payload))
+	if err != nil || len(values) == 0 {
+		return nil
+	}
+	params := make(map[string]string, len(values))
+	for name, v := range values {
+		if name == "json" && len(v) == 1 && expandJSONForm(v[0], params) {
+			continue
+		}
+		params[name] = strings.Join(v, ",")
+	}
+	return params
+}
+
+// expandJSONForm adds the fields of a structured form submission to params;
+// it reports false when raw is not a JSON object
+func expandJSONForm(raw string, params map[string]string) bool {
+	var fields map[string]interface{}
+	if err := json.Unmarshal([]byte(raw), &fields); err != nil {
+		return false
+	}
+	for name, value := range fields {
+		if name != "parameter" {
+			params[name] = auditValue(value)
+			continue
+		}
+		// A single parameter is submitted as an object rather than a list
+		list, ok := value.([]interface{})
+		if !ok {
+			list = []interface{}{value}
+		}
+		for _, item := range list {
+			p, ok := item.(map[string]interface{})
+			if pName, named := p["name"].(string); ok && named {
+				params[pName] = auditValue(p["value"])
+			}
+		}
+	}
+	return true
+}
+
+// auditValue renders a JSON form value as text
+func auditValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(encoded)
+}
+
+// describeRequest splits a Jenkins path such as /job/a/job/b/12/stop?x=1
+// into the job name (a/b), build number (12), action (stop) and query parameters
+func describeRequest(path string) (job string, build int, action string, params map[string]string) {
+	rawPath, rawQuery, _ := strings.Cut(path, "?")
+
+	var jobParts, actionParts []string
+	segments := strings.Split(strings.Trim(rawPath, "/"), "/")
+	for i := 0; i < len(segments); i++ {
+		segment := segments[i]
+		if segment == "job" && i+1 < len(segments) && len(actionParts) == 0 && build == 0 {
+			name, err := url.PathUnescape(segments[i+1])
+			if err != nil {
+				name = segments[i+1]
+			}
+			jobParts = append(jobParts, name)
+			i++
+			continue
+		}
+		if n, err := strconv.Atoi(segment); err == nil && len(jobParts) > 0 && build == 0 && len(actionParts) == 0 {
+			build = n
+			continue
+		}
+		if segment != "" {
+			actionParts = append(actionParts, segment)
+		}
+	}
+
+	if values, err := url.ParseQuery(rawQuery); err == nil && len(values) > 0 {
+		params = make(map[string]string, len(values))
+		for name, v := range values {
+			params[name] = strings.Join(v, ",")
+		}
+	}
+
+	return strings.Join(jobParts, "/"), build, strings.Join(actionParts, "/"), params
+}
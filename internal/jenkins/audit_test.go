@@ -0,0 +1,146 @@
+package jenkins
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/elogrono/jenkins-tui/internal/audit"
+	"github.com/elogrono/jenkins-tui/internal/jenkins/models"
+)
+
+func TestDescribeRequest(t *testing.T) {
+	tests := []struct {
+		path   string
+		job    string
+		build  int
+		action string
+	}{
+		{"/job/app/build", "app", 0, "build"},
+		{"/job/team/job/my%20app/42/stop", "team/my app", 42, "stop"},
+		{"/job/app/buildWithParameters?BRANCH=main", "app", 0, "buildWithParameters"},
+		{"/queue/cancelItem?id=7", "", 0, "queue/cancelItem"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			job, build, action, _ := describeRequest(tt.path)
+			if job != tt.job || build != tt.build || action != tt.action {
+				t.Errorf("got (%q, %d, %q), want (%q, %d, %q)", job, build, action, tt.job, tt.build, tt.action)
+			}
+		})
+	}
+
+	_, _, _, params := describeRequest("/job/app/buildWithParameters?BRANCH=main&API_TOKEN=s3cret")
+	if params["BRANCH"] != "main" || params["API_TOKEN"] != "s3cret" {
+		t.Errorf("unexpected parameters: %v", params)
+	}
+}
+
+func TestClientAuditsInputValues(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(testConfig(server.URL))
+	log := audit.New(filepath.Join(t.TempDir(), audit.FileName))
+	client.SetAuditLog(log)
+
+	input := models.PendingInput{ID: "Deploy", Inputs: []models.InputParam{
+		{Type: "StringParameterDefinition", Name: "TARGET"},
+		{Type: "PasswordParameterDefinition", Name: "DEPLOY_PASSWORD"},
+		// A password whose name does not look secret is redacted by its kind
+		{Type: "PasswordParameterDefinition", Name: "PIN"},
+	}}
+	values := map[string]string{"TARGET": "prod", "DEPLOY_PASSWORD": "hunter2", "PIN": "4321"}
+	if err := client.ProceedInput(context.Background(), "app", 7, input, values); err != nil {
+		t.Fatalf("ProceedInput failed: %v", err)
+	}
+
+	entries, _ := log.Recent(0)
+	if len(entries) != 1 {
+		t.Fatalf("expected the approval audited, got %d entries", len(entries))
+	}
+	params := entries[0].Parameters
+	if params["TARGET"] != "prod" || params["DEPLOY_PASSWORD"] != audit.Redacted || params["PIN"] != audit.Redacted || params["inputId"] != "Deploy" {
+		t.Errorf("expected the submitted values with secrets redacted, got %v", params)
+	}
+	if data, _ := os.ReadFile(log.Path()); strings.Contains(string(data), "4321") || strings.Contains(string(data), "hunter2") {
+		t.Errorf("expected no password on disk, got %s", data)
+	}
+}
+
+func TestFormParameters(t *testing.T) {
+	form := url.Values{
+		"json":   {`{"parameter":[{"name":"TARGET","value":"prod"},{"name":"DRY_RUN","value":false}],"description":"ok"}`},
+		"Submit": {"Proceed"},
+	}
+	params := formParameters([]byte(form.Encode()))
+	want := map[string]string{"TARGET": "prod", "DRY_RUN": "false", "description": "ok", "Submit": "Proceed"}
+	if !reflect.DeepEqual(params, want) {
+		t.Errorf("expected %v, got %v", want, params)
+	}
+
+	// A single parameter is an object, and a json field that is not an object stays as is
+	params = formParameters([]byte(url.Values{"json": {`{"parameter":{"name":"PASSWORD","value":"hunter2"}}`}}.Encode()))
+	if params["PASSWORD"] != "hunter2" {
+		t.Errorf("expected the single parameter expanded, got %v", params)
+	}
+	if params = formParameters([]byte("json=%5B1%5D")); params["json"] != "[1]" {
+		t.Errorf("expected the raw field kept, got %v", params)
+	}
+}
+
+func TestClientAuditsWrites(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/job/app/7/stop" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	cfg := testConfig(server.URL)
+	cfg.Profile.Name = "staging"
+	client, _ := NewClient(cfg)
+	log := audit.New(filepath.Join(t.TempDir(), audit.FileName))
+	client.SetAuditLog(log)
+
+	ctx := context.Background()
+	_ = client.TriggerBuild(ctx, "app")
+	_ = client.AbortBuild(ctx, "app", 7)
+	_, _ = client.GetJob(ctx, "app")
+	_ = client.SetBuildDescription(ctx, "app", 7, strings.Repeat("x", 300))
+	_ = client.UpdateJobConfig(ctx, "app", "<project/>")
+
+	entries, err := log.Recent(0)
+	if err != nil {
+		t.Fatalf("Recent failed: %v", err)
+	}
+	if len(entries) != 4 {
+		t.Fatalf("expected 4 audited writes, got %d", len(entries))
+	}
+
+	// Form bodies are recorded, long values by size; other bodies are not
+	config, describe, abort, trigger := entries[0], entries[1], entries[2], entries[3]
+	if describe.Action != "submitDescription" || describe.Parameters["description"] != "(300 bytes)" {
+		t.Errorf("unexpected description entry: %+v", describe)
+	}
+	if config.Action != "config.xml" || config.Parameters != nil {
+		t.Errorf("unexpected config entry: %+v", config)
+	}
+	if trigger.Action != "build" || trigger.Status != http.StatusCreated || trigger.Profile != "staging" || trigger.User != "testuser" {
+		t.Errorf("unexpected trigger entry: %+v", trigger)
+	}
+	if abort.Action != "stop" || abort.Build != 7 || abort.Status != http.StatusForbidden || abort.Succeeded() {
+		t.Errorf("unexpected abort entry: %+v", abort)
+	}
+}
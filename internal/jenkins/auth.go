@@ -0,0 +1,70 @@
+package jenkins
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/elogrono/jenkins-tui/internal/config"
+)
+
+// Authenticator applies credentials to outgoing requests
+type Authenticator interface {
+	Authenticate(req *http.Request)
+}
+
+// BasicAuth authenticates with a username and API token
+type BasicAuth struct {
+	Username string
+	Token    string
+}
+
+// Authenticate implements Authenticator
+func (a BasicAuth) Authenticate(req *http.Request) {
+	req.SetBasicAuth(a.Username, a.Token)
+}
+
+// BearerAuth sends the token as an OAuth bearer token
+type BearerAuth struct {
+	Token string
+}
+
+// Authenticate implements Authenticator
+func (a BearerAuth) Authenticate(req *http.Request) {
+	req.Header.Set("Authorization", "Bearer "+a.Token)
+}
+
+// HeaderAuth sends the token in a custom header
+type HeaderAuth struct {
+	Header string
+	Value  string
+}
+
+// Authenticate implements Authenticator
+func (a HeaderAuth) Authenticate(req *http.Request) {
+	req.Header.Set(a.Header, a.Value)
+}
+
+// NoAuth sends requests without credentials
+type NoAuth struct{}
+
+// Authenticate implements Authenticator
+func (NoAuth) Authenticate(*http.Request) {}
+
+// NewAuthenticator returns the authenticator for the profile's auth_type
+func NewAuthenticator(p *config.Profile) (Authenticator, error) {
+	switch p.AuthMode() {
+	case config.AuthBasic:
+		return BasicAuth{Username: p.Username, Token: p.APIToken}, nil
+	case config.AuthBearer:
+		return BearerAuth{Token: p.APIToken}, nil
+	case config.AuthHeader:
+		if p.AuthHeader == "" {
+			return nil, fmt.Errorf("auth_header is required for header authentication")
+		}
+		return HeaderAuth{Header: p.AuthHeader, Value: p.APIToken}, nil
+	case config.AuthNone:
+		return NoAuth{}, nil
+	default:
+		return nil, fmt.Errorf("unknown auth_type %q", p.AuthType)
+	}
+}
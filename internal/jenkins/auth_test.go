@@ -0,0 +1,106 @@
+package jenkins
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/elogrono/jenkins-tui/internal/config"
+)
+
+func TestNewAuthenticator(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile config.Profile
+		header  string
+		value   string
+		wantErr bool
+	}{
+		{
+			name:    "default is basic",
+			profile: config.Profile{Username: "user", APIToken: "token"},
+			header:  "Authorization",
+			value:   "Basic dXNlcjp0b2tlbg==",
+		},
+		{
+			name:    "bearer",
+			profile: config.Profile{AuthType: "bearer", APIToken: "abc"},
+			header:  "Authorization",
+			value:   "Bearer abc",
+		},
+		{
+			name:    "custom header",
+			profile: config.Profile{AuthType: "header", AuthHeader: "X-Auth-Token", APIToken: "abc"},
+			header:  "X-Auth-Token",
+			value:   "abc",
+		},
+		{
+			name:    "none",
+			profile: config.Profile{AuthType: "none", Username: "user", APIToken: "token"},
+			header:  "Authorization",
+			value:   "",
+		},
+		{
+			name:    "header without name",
+			profile: config.Profile{AuthType: "header", APIToken: "abc"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown",
+			profile: config.Profile{AuthType: "kerberos"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auth, err := NewAuthenticator(&tt.profile)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewAuthenticator() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			req, _ := http.NewRequest(http.MethodGet, "https://jenkins.example.com/api/json", nil)
+			auth.Authenticate(req)
+			if got := req.Header.Get(tt.header); got != tt.value {
+				t.Errorf("expected %s header %q, got %q", tt.header, tt.value, got)
+			}
+		})
+	}
+}
+
+func TestBearerAuthWithExtraHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.Header.Get("X-Forwarded-User") != "ci-bot" {
+			t.Errorf("expected extra header X-Forwarded-User, got %q", r.Header.Get("X-Forwarded-User"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"mode":"NORMAL"}`))
+	}))
+	defer server.Close()
+
+	cfg := testConfig(server.URL)
+	cfg.Profile.AuthType = config.AuthBearer
+	cfg.Profile.APIToken = "secret"
+	cfg.Profile.ExtraHeaders = map[string]string{"X-Forwarded-User": "ci-bot"}
+
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := client.GetRootInfo(ctx); err != nil {
+		t.Fatalf("GetRootInfo failed: %v", err)
+	}
+}
@@ -2,35 +2,83 @@
 package jenkins
 
 import (
+	"bytes"
 	"context"
-	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/elogrono/jenkins-tui/internal/audit"
+	"github.com/elogrono/jenkins-tui/internal/cache"
 	"github.com/elogrono/jenkins-tui/internal/config"
 	"github.com/elogrono/jenkins-tui/internal/logger"
 	"golang.org/x/time/rate"
 )
 
+// ErrUnauthorized is returned when Jenkins rejects the credentials with 401
+var ErrUnauthorized = errors.New("authentication failed: invalid credentials")
+
+// ErrForbidden is returned when Jenkins rejects a request with 403
+var ErrForbidden = errors.New("access forbidden: check permissions")
+
+// ErrNotFound is returned when a GET request answers 404
+var ErrNotFound = errors.New("not found")
+
+// ErrReadOnly matches errors returned for writes on a read-only profile
+var ErrReadOnly = errors.New("profile is read-only")
+
+// ReadOnlyError is returned when a non-GET request is attempted while the
+// profile is read-only and writes have not been unlocked for the session
+type ReadOnlyError struct {
+	Method string
+	Path   string
+}
+
+func (e *ReadOnlyError) Error() string {
+	return fmt.Sprintf("profile is read-only: refusing %s %s", e.Method, e.Path)
+}
+
+// Is makes errors.Is(err, ErrReadOnly) match
+func (e *ReadOnlyError) Is(target error) bool {
+	return target == ErrReadOnly
+}
+
 // Client is the Jenkins API client
 type Client struct {
-	baseURL    string
-	username   string
-	apiToken   string
-	httpClient *http.Client
-	limiter    *rate.Limiter
+	baseURL      string
+	username     string
+	auth         Authenticator
+	extraHeaders map[string]string
+	httpClient   *http.Client
+	streamClient *http.Client // No overall timeout; bounded by the request context
+	limiter      *rate.Limiter
 
 	// Crumb for CSRF protection
 	crumb       string
 	crumbField  string
 	crumbMu     sync.RWMutex
 	crumbTested bool
+
+	// Authenticated user and known permission denials
+	identity identity
+
+	// Read-only guard; writes can be unlocked for the session
+	profileName    string
+	readOnly       bool
+	writesUnlocked atomic.Bool
+
+	// Audit log of mutating requests; nil disables auditing
+	auditLog *audit.Log
+
+	// On-disk cache for results of finished builds; nil disables caching
+	cache *cache.Store
 }
 
 // NewClient creates a new Jenkins client
@@ -44,19 +92,26 @@ func NewClient(cfg *config.Config) (*Client, error) {
 	logger.Debug("Creating Jenkins client",
 		"baseURL", baseURL,
 		"username", cfg.Profile.Username,
+		"authType", cfg.Profile.AuthMode(),
 		"timeout", cfg.Profile.TimeoutSeconds,
 		"rateLimit", cfg.Profile.RateLimitRPS,
 		"insecureTLS", cfg.Profile.InsecureSkipTLSVerify,
+		"proxy", cfg.Profile.ProxyURL != "",
+		"customCA", cfg.Profile.CACertFile != "",
+		"clientCert", cfg.Profile.ClientCertFile != "",
+		"readOnly", cfg.Profile.IsReadOnly(),
 	)
 
-	transport := &http.Transport{
-		TLSClientConfig: &tls.Config{
-			InsecureSkipVerify: cfg.Profile.InsecureSkipTLSVerify,
-		},
-		MaxIdleConns:        10,
-		IdleConnTimeout:     30 * time.Second,
-		DisableCompression:  false,
-		MaxIdleConnsPerHost: 5,
+	auth, err := NewAuthenticator(&cfg.Profile)
+	if err != nil {
+		logger.Error("Error configuring authentication", "error", err)
+		return nil, err
+	}
+
+	transport, err := newTransport(&cfg.Profile)
+	if err != nil {
+		logger.Error("Error configuring HTTP transport", "error", err)
+		return nil, err
 	}
 
 	httpClient := &http.Client{
@@ -69,14 +124,55 @@ func NewClient(cfg *config.Config) (*Client, error) {
 	logger.Info("Jenkins client created successfully")
 
 	return &Client{
-		baseURL:    baseURL,
-		username:   cfg.Profile.Username,
-		apiToken:   cfg.Profile.APIToken,
-		httpClient: httpClient,
-		limiter:    limiter,
+		baseURL:      baseURL,
+		username:     cfg.Profile.Username,
+		auth:         auth,
+		extraHeaders: cfg.Profile.ExtraHeaders,
+		httpClient:   httpClient,
+		streamClient: &http.Client{Transport: transport},
+		limiter:      limiter,
+		profileName:  cfg.Profile.DisplayName(),
+		readOnly:     cfg.Profile.IsReadOnly(),
 	}, nil
 }
 
+// ProfileName returns the name of the profile the client was created from
+func (c *Client) ProfileName() string {
+	return c.profileName
+}
+
+// IsReadOnly reports whether the profile is configured as read-only
+func (c *Client) IsReadOnly() bool {
+	return c.readOnly
+}
+
+// WritesAllowed reports whether non-GET requests may be sent
+func (c *Client) WritesAllowed() bool {
+	return !c.readOnly || c.writesUnlocked.Load()
+}
+
+// SetWritesUnlocked enables or disables writes on a read-only profile for
+// the lifetime of this client. It has no effect on writable profiles.
+func (c *Client) SetWritesUnlocked(unlocked bool) {
+	c.writesUnlocked.Store(unlocked)
+	logger.Warn("Write lock changed", "profile", c.profileName, "unlocked", unlocked)
+}
+
+// SetAuditLog enables recording of every non-GET request to log
+func (c *Client) SetAuditLog(log *audit.Log) {
+	c.auditLog = log
+}
+
+// AuditLog returns the audit log, or nil if auditing is disabled
+func (c *Client) AuditLog() *audit.Log {
+	return c.auditLog
+}
+
+// SetCache enables caching of finished build results in store
+func (c *Client) SetCache(store *cache.Store) {
+	c.cache = store
+}
+
 // TestConnection tests the connection to Jenkins
 func (c *Client) TestConnection() error {
 	logger.Info("Testing connection to Jenkins", "baseURL", c.baseURL)
@@ -106,13 +202,97 @@ func (c *Client) TestConnection() error {
 	return nil
 }
 
-// doRequest performs an HTTP request with authentication and rate limiting
-func (c *Client) doRequest(ctx context.Context, method, path string, body io.Reader) (*http.Response, error) {
+// requestOptions are the per-request settings of doRequest
+type requestOptions struct {
+	readOnlySafe bool
+	streaming    bool
+	contentType  string
+	secrets      []string
+}
+
+// requestOption changes how doRequest treats a request
+type requestOption func(*requestOptions)
+
+// readOnlySafe marks a POST that changes nothing on the controller, such as
+// linting. It is allowed on read-only profiles and audited all the same.
+var readOnlySafe requestOption = func(o *requestOptions) { o.readOnlySafe = true }
+
+// streaming marks a request whose response body is read without the client
+// timeout, e.g. for downloads
+var streaming requestOption = func(o *requestOptions) { o.streaming = true }
+
+// withContentType sets the Content-Type of a request body that is not form
+// data, e.g. a job's config.xml
+func withContentType(contentType string) requestOption {
+	return func(o *requestOptions) { o.contentType = contentType }
+}
+
+// withSecrets names body parameters that the audit log redacts whatever
+// their name, such as the password fields of an input step
+func withSecrets(names ...string) requestOption {
+	return func(o *requestOptions) { o.secrets = append(o.secrets, names...) }
+}
+
+// applyOptions returns the settings of a request
+func applyOptions(opts []requestOption) requestOptions {
+	var o requestOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// doRequest performs an HTTP request with authentication and rate limiting.
+// Mutating requests are refused on read-only profiles unless marked
+// readOnlySafe, and all of them are recorded in the audit log, including
+// refused ones.
+func (c *Client) doRequest(ctx context.Context, method, path string, body io.Reader, opts ...requestOption) (*http.Response, error) {
 	// Ensure context is not nil
 	if ctx == nil {
 		ctx = context.Background()
 	}
 
+	o := applyOptions(opts)
+	if method == http.MethodGet || method == http.MethodHead {
+		return c.send(ctx, method, path, nil, o)
+	}
+
+	// Buffer the body so the crumb retry can resend it
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = io.ReadAll(body); err != nil {
+			return nil, fmt.Errorf("error reading request body: %w", err)
+		}
+	}
+
+	var resp *http.Response
+	var err error
+	if c.WritesAllowed() || o.readOnlySafe {
+		resp, err = c.send(ctx, method, path, payload, o)
+	} else {
+		// Enforce read-only profiles before anything reaches the network
+		logger.Warn("Blocked write on read-only profile", "method", method, "path", path)
+		err = &ReadOnlyError{Method: method, Path: path}
+	}
+	c.recordAudit(method, path, o.bodyContentType(), payload, o.secrets, resp, err)
+	return resp, err
+}
+
+// formContentType is the Content-Type of request bodies unless
+// withContentType sets another one
+const formContentType = "application/x-www-form-urlencoded"
+
+// bodyContentType returns the Content-Type of the request body
+func (o requestOptions) bodyContentType() string {
+	if o.contentType != "" {
+		return o.contentType
+	}
+	return formContentType
+}
+
+// send performs a single HTTP request; the crumb retry re-enters here
+func (c *Client) send(ctx context.Context, method, path string, payload []byte, o requestOptions) (*http.Response, error) {
 	fullURL := c.baseURL + path
 
 	logger.Debug("Making HTTP request",
@@ -131,14 +311,27 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body io.Rea
 		logger.Debug("Rate limiter wait", "duration", waitTime)
 	}
 
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+
 	req, err := http.NewRequestWithContext(ctx, method, fullURL, body)
 	if err != nil {
 		logger.Error("Error creating request", "error", err)
 		return nil, fmt.Errorf("error creating request: %w", err)
 	}
 
-	// Set basic auth
-	req.SetBasicAuth(c.username, c.apiToken)
+	req.Header.Set("Accept", "application/json")
+	if len(payload) > 0 {
+		req.Header.Set("Content-Type", o.bodyContentType())
+	}
+	for name, value := range c.extraHeaders {
+		req.Header.Set(name, value)
+	}
+
+	// Apply credentials
+	c.auth.Authenticate(req)
 
 	// Add crumb if available (for POST requests)
 	if method != http.MethodGet {
@@ -150,10 +343,14 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body io.Rea
 		c.crumbMu.RUnlock()
 	}
 
-	req.Header.Set("Accept", "application/json")
+	// Downloads may take longer to read than the profile timeout allows
+	httpClient := c.httpClient
+	if o.streaming {
+		httpClient = c.streamClient
+	}
 
 	reqStart := time.Now()
-	resp, err := c.httpClient.Do(req)
+	resp, err := httpClient.Do(req)
 	reqElapsed := time.Since(reqStart)
 
 	if err != nil {
@@ -175,7 +372,7 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body io.Rea
 	if resp.StatusCode == http.StatusUnauthorized {
 		resp.Body.Close()
 		logger.Error("Authentication failed", "status", resp.StatusCode)
-		return nil, fmt.Errorf("authentication failed: invalid credentials")
+		return nil, ErrUnauthorized
 	}
 
 	if resp.StatusCode == http.StatusForbidden {
@@ -187,10 +384,10 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body io.Rea
 			if err := c.fetchCrumb(ctx); err == nil {
 				logger.Info("Crumb fetched, retrying request")
 				// Retry the request with crumb
-				return c.doRequest(ctx, method, path, body)
+				return c.send(ctx, method, path, payload, o)
 			}
 		}
-		return nil, fmt.Errorf("access forbidden: check permissions")
+		return nil, ErrForbidden
 	}
 
 	return resp, nil
@@ -205,7 +402,7 @@ func (c *Client) fetchCrumb(ctx context.Context) error {
 
 	logger.Debug("Fetching CSRF crumb")
 
-	resp, err := c.doRequest(ctx, http.MethodGet, "/crumbIssuer/api/json", nil)
+	resp, err := c.send(ctx, http.MethodGet, "/crumbIssuer/api/json", nil, requestOptions{})
 	if err != nil {
 		logger.Warn("Failed to fetch crumb", "error", err)
 		return err
@@ -243,6 +440,9 @@ func (c *Client) getJSON(ctx context.Context, path string, v interface{}) error
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
 		logger.Error("Unexpected status code",
@@ -3,6 +3,7 @@ package jenkins
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -385,3 +386,38 @@ func TestItoa(t *testing.T) {
 		}
 	}
 }
+
+func TestReadOnlyProfileBlocksWrites(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	readOnly := true
+	cfg := testConfig(server.URL)
+	cfg.Profile.Name = "prod"
+	cfg.Profile.ReadOnly = &readOnly
+	client, _ := NewClient(cfg)
+
+	err := client.TriggerBuild(context.Background(), "deploy")
+	if !errors.Is(err, ErrReadOnly) {
+		t.Fatalf("expected ErrReadOnly, got %v", err)
+	}
+	var roErr *ReadOnlyError
+	if !errors.As(err, &roErr) || roErr.Method != http.MethodPost {
+		t.Errorf("expected ReadOnlyError for POST, got %v", err)
+	}
+	if requests != 0 {
+		t.Errorf("expected no request to reach the server, got %d", requests)
+	}
+
+	client.SetWritesUnlocked(true)
+	if err := client.TriggerBuild(context.Background(), "deploy"); err != nil {
+		t.Fatalf("expected write to succeed after unlock, got %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("expected 1 request after unlock, got %d", requests)
+	}
+}
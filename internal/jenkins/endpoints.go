@@ -2,12 +2,23 @@ package jenkins
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"html"
 	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/elogrono/jenkins-tui/internal/jenkins/models"
+	"github.com/elogrono/jenkins-tui/internal/logger"
 )
 
+// stageWorkers bounds concurrent stage description requests
+const stageWorkers = 4
+
 // GetRootInfo fetches basic Jenkins server information
 func (c *Client) GetRootInfo(ctx context.Context) (*models.RootInfo, error) {
 	var info models.RootInfo
@@ -36,7 +47,7 @@ func (c *Client) GetViews(ctx context.Context) ([]models.View, error) {
 // GetViewJobs fetches jobs for a specific view
 func (c *Client) GetViewJobs(ctx context.Context, viewName string) ([]models.Job, error) {
 	path := "/view/" + encodeJobPath(viewName) + "/api/json?" + buildTreeParam(
-		"jobs[name,url,color,lastBuild[number,result,timestamp,duration],healthReport[description,score]]",
+		"jobs[name,url,color,lastBuild[number,result,timestamp,duration,builtOn],healthReport[description,score]]",
 	)
 
 	var resp struct {
@@ -52,11 +63,55 @@ func (c *Client) GetAllJobs(ctx context.Context) ([]models.Job, error) {
 		Jobs []models.Job `json:"jobs"`
 	}
 	err := c.getJSON(ctx, "/api/json?"+buildTreeParam(
-		"jobs[name,url,color,lastBuild[number,result,timestamp,duration],healthReport[description,score]]",
+		"jobs[name,url,color,lastBuild[number,result,timestamp,duration,builtOn],healthReport[description,score]]",
 	), &resp)
 	return resp.Jobs, err
 }
 
+// jobTreeDepth is how many folder levels GetJobTree descends into
+const jobTreeDepth = 4
+
+// GetJobTree fetches the jobs of the controller including those in folders
+// and multibranch projects, with FullName set to the path through them.
+// Folders themselves are left out.
+func (c *Client) GetJobTree(ctx context.Context) ([]models.Job, error) {
+	fields := "name,fullName,url,color,lastBuild[number,result,timestamp,duration,builtOn],healthReport[description,score]"
+	tree := "jobs[" + fields + "]"
+	for i := 0; i < jobTreeDepth; i++ {
+		tree = "jobs[" + fields + "," + tree + "]"
+	}
+
+	// Folders list a (possibly empty) jobs array, other items none
+	type item struct {
+		models.Job
+		Jobs []item `json:"jobs"`
+	}
+	var resp struct {
+		Jobs []item `json:"jobs"`
+	}
+	if err := c.getJSON(ctx, "/api/json?"+buildTreeParam(tree), &resp); err != nil {
+		return nil, err
+	}
+
+	var jobs []models.Job
+	var walk func(items []item, parent string)
+	walk = func(items []item, parent string) {
+		for _, it := range items {
+			job := it.Job
+			if job.FullName == "" {
+				job.FullName = parent + job.Name
+			}
+			if it.Jobs != nil {
+				walk(it.Jobs, job.FullName+"/")
+				continue
+			}
+			jobs = append(jobs, job)
+		}
+	}
+	walk(resp.Jobs, "")
+	return jobs, nil
+}
+
 // GetJob fetches details for a specific job
 func (c *Client) GetJob(ctx context.Context, jobName string) (*models.JobDetail, error) {
 	path := "/job/" + encodeJobPath(jobName) + "/api/json?" + buildTreeParam(
@@ -104,10 +159,11 @@ func (c *Client) GetBuild(ctx context.Context, jobName string, buildNumber int)
 		"building",
 		"displayName",
 		"description",
+		"keepLog",
 		"executor[currentExecutable[url]]",
 		"artifacts[fileName,relativePath]",
 		"changeSets[items[msg,author[fullName],commitId,timestamp]]",
-		"causes[shortDescription,userName,userId]",
+		"actions[causes[shortDescription,userName,userId,upstreamProject,upstreamBuild],parameters[name,value]]",
 	)
 
 	var build models.Build
@@ -120,6 +176,9 @@ func (c *Client) TriggerBuild(ctx context.Context, jobName string) error {
 	path := "/job/" + encodeJobPath(jobName) + "/build"
 	resp, err := c.doRequest(ctx, http.MethodPost, path, nil)
 	if err != nil {
+		if errors.Is(err, ErrForbidden) {
+			c.denyPermission(PermBuild, jobName, 0)
+		}
 		return err
 	}
 	defer resp.Body.Close()
@@ -130,6 +189,49 @@ func (c *Client) TriggerBuild(ctx context.Context, jobName string) error {
 	return nil
 }
 
+// AbortBuild stops a running build
+func (c *Client) AbortBuild(ctx context.Context, jobName string, buildNumber int) error {
+	path := "/job/" + encodeJobPath(jobName) + "/" + itoa(buildNumber) + "/stop"
+	resp, err := c.doRequest(ctx, http.MethodPost, path, nil)
+	if err != nil {
+		if errors.Is(err, ErrForbidden) {
+			c.denyPermission(PermCancel, jobName, buildNumber)
+		}
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Jenkins answers /stop with a redirect back to the build page
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("unexpected status %d aborting build", resp.StatusCode)
+	}
+	return nil
+}
+
+// GetTestReport fetches the JUnit test report of a build. It returns nil
+// without error when the build has no test results.
+func (c *Client) GetTestReport(ctx context.Context, jobName string, buildNumber int) (*models.TestReport, error) {
+	suites := "suites[name,duration,cases[className,name,status,duration,age,failedSince,errorDetails,errorStackTrace,skipped,skippedMessage]]"
+	path := "/job/" + encodeJobPath(jobName) + "/" + itoa(buildNumber) + "/testReport/api/json?" + buildTreeParam(
+		"duration",
+		"failCount",
+		"passCount",
+		"skipCount",
+		suites,
+		"childReports[result[duration,failCount,passCount,skipCount,"+suites+"]]",
+	)
+
+	var report models.TestReport
+	if err := c.getJSON(ctx, path, &report); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	report.Flatten()
+	return &report, nil
+}
+
 // GetBuildLog fetches the console output for a build
 func (c *Client) GetBuildLog(ctx context.Context, jobName string, buildNumber int, maxBytes int) (string, error) {
 	path := "/job/" + encodeJobPath(jobName) + "/" + itoa(buildNumber) + "/consoleText"
@@ -142,7 +244,7 @@ func (c *Client) GetStageLog(ctx context.Context, jobName string, buildNumber in
 	path := "/job/" + encodeJobPath(jobName) + "/" + itoa(buildNumber) + "/execution/node/" + stageID + "/wfapi/log"
 	log, err := c.getText(ctx, path, 500000)
 	if err == nil {
-		return log, nil
+		return decodeNodeLog(log), nil
 	}
 
 	// Fallback to Blue Ocean API
@@ -150,6 +252,71 @@ func (c *Client) GetStageLog(ctx context.Context, jobName string, buildNumber in
 	return c.getText(ctx, path, 500000)
 }
 
+// GetStageSteps fetches the steps (sh, checkout, input...) executed inside a stage
+// Endpoint: /job/{jobName}/{build}/execution/node/{stageID}/wfapi/describe
+func (c *Client) GetStageSteps(ctx context.Context, jobName string, buildNumber int, stageID string) ([]models.WFAPIFlowNode, error) {
+	path := "/job/" + encodeJobPath(jobName) + "/" + itoa(buildNumber) + "/execution/node/" + stageID + "/wfapi/describe"
+
+	var stage models.WFAPIStage
+	err := c.getJSON(ctx, path, &stage)
+	if err == nil {
+		return stage.StageFlowNodes, nil
+	}
+
+	// Fallback to Blue Ocean API
+	path = "/blue/rest/organizations/jenkins/pipelines/" + encodeJobPath(jobName) + "/runs/" + itoa(buildNumber) + "/nodes/" + stageID + "/steps/"
+	var steps []models.BlueOceanStep
+	if boErr := c.getJSON(ctx, path, &steps); boErr != nil {
+		return nil, err
+	}
+
+	nodes := make([]models.WFAPIFlowNode, 0, len(steps))
+	for _, step := range steps {
+		node := models.WFAPIFlowNode{
+			ID:                   step.ID,
+			Name:                 step.DisplayName,
+			Status:               blueOceanStatus(step.State, step.Result),
+			ParameterDescription: step.DisplayDescription,
+			DurationMillis:       step.DurationInMillis,
+		}
+		if t, err := time.Parse("2006-01-02T15:04:05.000-0700", step.StartTime); err == nil {
+			node.StartTimeMillis = t.UnixMilli()
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
+
+// GetStepLog fetches the log of a single step within a stage
+func (c *Client) GetStepLog(ctx context.Context, jobName string, buildNumber int, stageID, stepID string) (string, error) {
+	path := "/job/" + encodeJobPath(jobName) + "/" + itoa(buildNumber) + "/execution/node/" + stepID + "/wfapi/log"
+	log, err := c.getText(ctx, path, 500000)
+	if err == nil {
+		return decodeNodeLog(log), nil
+	}
+
+	// Fallback to Blue Ocean API
+	path = "/blue/rest/organizations/jenkins/pipelines/" + encodeJobPath(jobName) + "/runs/" + itoa(buildNumber) + "/nodes/" + stageID + "/steps/" + stepID + "/log/"
+	return c.getText(ctx, path, 500000)
+}
+
+// decodeNodeLog extracts the text from a wfapi log response. wfapi wraps the
+// log in JSON with console notes rendered as HTML; plain text is returned as is.
+func decodeNodeLog(raw string) string {
+	trimmed := strings.TrimSpace(raw)
+	if !strings.HasPrefix(trimmed, "{") {
+		return raw
+	}
+	var log models.StageLog
+	if err := json.Unmarshal([]byte(trimmed), &log); err != nil {
+		return raw
+	}
+	return html.UnescapeString(htmlTagPattern.ReplaceAllString(log.Text, ""))
+}
+
+// htmlTagPattern matches the markup wfapi adds around console notes
+var htmlTagPattern = regexp.MustCompile(`<[^>]+>`)
+
 // GetQueue fetches the build queue
 func (c *Client) GetQueue(ctx context.Context) (*models.Queue, error) {
 	var queue models.Queue
@@ -165,7 +332,7 @@ func (c *Client) GetNodes(ctx context.Context) ([]models.Node, error) {
 		Computer []models.Node `json:"computer"`
 	}
 	err := c.getJSON(ctx, "/computer/api/json?"+buildTreeParam(
-		"computer[displayName,offline,temporarilyOffline,numExecutors,executors[currentExecutable[url,number,displayName,fullDisplayName,timestamp,estimatedDuration],idle,likelyStuck,number,progress],assignedLabels[name],offlineCauseReason,idle,monitorData[*]]",
+		"computer[displayName,offline,temporarilyOffline,numExecutors,executors[currentExecutable[url,number,displayName,fullDisplayName,timestamp,estimatedDuration],idle,likelyStuck,number,progress],oneOffExecutors[currentExecutable[url,number,displayName,fullDisplayName,timestamp,estimatedDuration],progress],assignedLabels[name],offlineCauseReason,idle,monitorData[*]]",
 	), &resp)
 	return resp.Computer, err
 }
@@ -207,8 +374,31 @@ func (c *Client) GetPipelineRun(ctx context.Context, jobName string, buildNumber
 	return c.getPipelineRunFromBlueOcean(ctx, jobName, buildNumber)
 }
 
+// GetPipelineGraph fetches a pipeline run like GetPipelineRun, with the
+// stages of parallel blocks linked to the stage that forks them. wfapi only
+// reports the flow node edges per stage, so this costs a request per stage.
+func (c *Client) GetPipelineGraph(ctx context.Context, jobName string, buildNumber int) (*models.PipelineRun, error) {
+	wfRun, err := c.describeWFAPIRun(ctx, jobName, buildNumber)
+	if err != nil {
+		return c.getPipelineRunFromBlueOcean(ctx, jobName, buildNumber)
+	}
+	if len(wfRun.Stages) > 1 {
+		c.describeStages(ctx, jobName, buildNumber, wfRun.Stages)
+	}
+	return wfapiPipelineRun(wfRun), nil
+}
+
 // getPipelineRunFromWFAPI fetches stages from Jenkins Workflow API
 func (c *Client) getPipelineRunFromWFAPI(ctx context.Context, jobName string, buildNumber int) (*models.PipelineRun, error) {
+	wfRun, err := c.describeWFAPIRun(ctx, jobName, buildNumber)
+	if err != nil {
+		return nil, err
+	}
+	return wfapiPipelineRun(wfRun), nil
+}
+
+// describeWFAPIRun fetches the wfapi description of a run
+func (c *Client) describeWFAPIRun(ctx context.Context, jobName string, buildNumber int) (*models.WFAPIRun, error) {
 	path := "/job/" + encodeJobPath(jobName) + "/" + itoa(buildNumber) + "/wfapi/describe"
 
 	var wfRun models.WFAPIRun
@@ -216,6 +406,43 @@ func (c *Client) getPipelineRunFromWFAPI(ctx context.Context, jobName string, bu
 	if err != nil {
 		return nil, err
 	}
+	return &wfRun, nil
+}
+
+// describeStages fills in the flow nodes and parent edges of the stages,
+// which the run description leaves out. Stages that cannot be described
+// keep none and are left unlinked.
+func (c *Client) describeStages(ctx context.Context, jobName string, buildNumber int, stages []models.WFAPIStage) {
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < stageWorkers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				path := "/job/" + encodeJobPath(jobName) + "/" + itoa(buildNumber) + "/execution/node/" + stages[i].ID + "/wfapi/describe"
+				var stage models.WFAPIStage
+				if err := c.getJSON(ctx, path, &stage); err != nil {
+					logger.Debug("Failed to describe stage", "job", jobName, "build", buildNumber, "stage", stages[i].ID, "error", err)
+					continue
+				}
+				stages[i].ParentNodes = stage.ParentNodes
+				stages[i].StageFlowNodes = stage.StageFlowNodes
+			}
+		}()
+	}
+	for i := range stages {
+		if len(stages[i].StageFlowNodes) == 0 {
+			indexes <- i
+		}
+	}
+	close(indexes)
+	wg.Wait()
+}
+
+// wfapiPipelineRun converts a wfapi run description to a pipeline run
+func wfapiPipelineRun(wfRun *models.WFAPIRun) *models.PipelineRun {
+	parents := wfapiParents(wfRun.Stages)
 
 	// Convert WFAPIStage to Stage
 	stages := make([]models.Stage, 0, len(wfRun.Stages))
@@ -229,11 +456,12 @@ func (c *Client) getPipelineRunFromWFAPI(ctx context.Context, jobName string, bu
 			DurationMillis:      wfStage.DurationMillis,
 			PauseDurationMillis: wfStage.PauseDurationMillis,
 			ExecNode:            wfStage.ExecNode,
+			ParentID:            parents[wfStage.ID],
 		}
 		stages = append(stages, stage)
 	}
 
-	run := &models.PipelineRun{
+	return &models.PipelineRun{
 		ID:              wfRun.ID,
 		Name:            wfRun.Name,
 		Status:          wfRun.Status,
@@ -243,8 +471,48 @@ func (c *Client) getPipelineRunFromWFAPI(ctx context.Context, jobName string, bu
 		DurationMillis:  wfRun.DurationMillis,
 		Stages:          stages,
 	}
+}
 
-	return run, nil
+// wfapiParents finds the stage whose parallel block contains each stage
+// from the flow node edges. A stage is entered through the parents of its
+// start node and flow nodes that lie outside it; a stage whose nodes lead
+// into several other stages forks them, as in blueOceanStages. A stage
+// entered from one stage only follows it in sequence.
+func wfapiParents(stages []models.WFAPIStage) map[string]string {
+	owner := make(map[string]string)
+	for _, stage := range stages {
+		owner[stage.ID] = stage.ID
+		for _, node := range stage.StageFlowNodes {
+			owner[node.ID] = stage.ID
+		}
+	}
+
+	// Stages entered from the nodes of each stage
+	entered := make(map[string][]string)
+	for _, stage := range stages {
+		edges := append([]string{}, stage.ParentNodes...)
+		for _, node := range stage.StageFlowNodes {
+			edges = append(edges, node.ParentNodes...)
+		}
+		from := make(map[string]bool)
+		for _, edge := range edges {
+			if src, ok := owner[edge]; ok && src != stage.ID && !from[src] {
+				from[src] = true
+				entered[src] = append(entered[src], stage.ID)
+			}
+		}
+	}
+
+	parents := make(map[string]string)
+	for src, children := range entered {
+		if len(children) < 2 {
+			continue
+		}
+		for _, child := range children {
+			parents[child] = src
+		}
+	}
+	return parents
 }
 
 // getPipelineRunFromBlueOcean fetches pipeline run details from Blue Ocean API (fallback)
@@ -252,13 +520,15 @@ func (c *Client) getPipelineRunFromBlueOcean(ctx context.Context, jobName string
 	// Blue Ocean API path for pipeline stages
 	path := "/blue/rest/organizations/jenkins/pipelines/" + encodeJobPath(jobName) + "/runs/" + itoa(buildNumber) + "/nodes/"
 
-	var stages []models.Stage
-	err := c.getJSON(ctx, path, &stages)
+	var nodes []models.BlueOceanNode
+	err := c.getJSON(ctx, path, &nodes)
 	if err != nil {
 		// Blue Ocean API might not be available, return nil without error
 		return nil, nil
 	}
 
+	stages := blueOceanStages(nodes)
+
 	// Sort stages by start time
 	models.SortStagesByStartTime(stages)
 
@@ -297,6 +567,59 @@ func (c *Client) getPipelineRunFromBlueOcean(ctx context.Context, jobName string
 	return run, nil
 }
 
+// blueOceanStages converts Blue Ocean nodes to stages, using the node edges
+// to attach parallel branches to the stage that forks them
+func blueOceanStages(nodes []models.BlueOceanNode) []models.Stage {
+	forkedBy := make(map[string]string)
+	for _, node := range nodes {
+		if len(node.Edges) > 1 {
+			for _, edge := range node.Edges {
+				forkedBy[edge.ID] = node.ID
+			}
+		}
+	}
+
+	stages := make([]models.Stage, 0, len(nodes))
+	for _, node := range nodes {
+		stage := models.Stage{
+			ID:             node.ID,
+			Name:           node.DisplayName,
+			Status:         blueOceanStatus(node.State, node.Result),
+			Result:         node.Result,
+			State:          node.State,
+			DurationMillis: node.DurationInMillis,
+			Type:           node.Type,
+			ParentID:       forkedBy[node.ID],
+		}
+		if stage.ParentID == "" && node.Type == "PARALLEL" {
+			stage.ParentID = node.FirstParent
+		}
+		if t, err := time.Parse("2006-01-02T15:04:05.000-0700", node.StartTime); err == nil {
+			stage.StartTimeMillis = t.UnixMilli()
+		}
+		stages = append(stages, stage)
+	}
+	return stages
+}
+
+// blueOceanStatus maps Blue Ocean state/result pairs onto wfapi status names
+func blueOceanStatus(state, result string) string {
+	switch state {
+	case "RUNNING":
+		return "IN_PROGRESS"
+	case "PAUSED":
+		return "PAUSED_PENDING_INPUT"
+	case "SKIPPED":
+		return "SKIPPED"
+	case "QUEUED", "NOT_BUILT", "":
+		return "NOT_EXECUTED"
+	}
+	if result == "FAILURE" {
+		return "FAILED"
+	}
+	return result
+}
+
 // itoa converts an int to string (simple helper)
 func itoa(n int) string {
 	if n == 0 {
@@ -3,10 +3,19 @@ package jenkins
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
 
+	"github.com/elogrono/jenkins-tui/internal/audit"
+	"github.com/elogrono/jenkins-tui/internal/cache"
 	"github.com/elogrono/jenkins-tui/internal/config"
 	"github.com/elogrono/jenkins-tui/internal/jenkins/models"
 )
@@ -223,3 +232,757 @@ func TestGetJob_WithStages(t *testing.T) {
 		t.Errorf("Expected build #1 to have 1 stage, got %d", len(job.Builds[0].Stages))
 	}
 }
+
+func TestGetPipelineGraph(t *testing.T) {
+	// Integration runs within Unit's time window, but the edges show they are siblings
+	run := models.WFAPIRun{ID: "5", Status: "FAILED", Stages: []models.WFAPIStage{
+		{ID: "6", Name: "Build", StartTimeMillis: 0, DurationMillis: 100},
+		{ID: "12", Name: "Tests", StartTimeMillis: 100, DurationMillis: 500},
+		{ID: "15", Name: "Unit", StartTimeMillis: 120, DurationMillis: 400},
+		{ID: "16", Name: "Integration", StartTimeMillis: 130, DurationMillis: 200},
+		{ID: "40", Name: "Deploy", StartTimeMillis: 600, DurationMillis: 50},
+	}}
+	stages := map[string]models.WFAPIStage{
+		"6":  {ID: "6", ParentNodes: []string{"3"}, StageFlowNodes: []models.WFAPIFlowNode{{ID: "8", ParentNodes: []string{"7"}}}},
+		"12": {ID: "12", ParentNodes: []string{"10"}, StageFlowNodes: []models.WFAPIFlowNode{{ID: "13", ParentNodes: []string{"12"}}, {ID: "14", ParentNodes: []string{"12"}}}},
+		"15": {ID: "15", ParentNodes: []string{"13"}, StageFlowNodes: []models.WFAPIFlowNode{{ID: "20", ParentNodes: []string{"17"}}}},
+		"16": {ID: "16", ParentNodes: []string{"14"}, StageFlowNodes: []models.WFAPIFlowNode{{ID: "21", ParentNodes: []string{"18"}}}},
+		"40": {ID: "40", ParentNodes: []string{"38"}, StageFlowNodes: []models.WFAPIFlowNode{{ID: "42", ParentNodes: []string{"41"}}}},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/job/test-job/5/wfapi/describe" {
+			json.NewEncoder(w).Encode(run)
+			return
+		}
+		id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/job/test-job/5/execution/node/"), "/wfapi/describe")
+		if stage, ok := stages[id]; ok {
+			json.NewEncoder(w).Encode(stage)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(testConfig(server.URL))
+
+	// The plain run has no edges, so nothing is nested
+	flat, err := client.GetPipelineRun(context.Background(), "test-job", 5)
+	if err != nil {
+		t.Fatalf("GetPipelineRun failed: %v", err)
+	}
+	for _, stage := range flat.Stages {
+		if stage.ParentID != "" {
+			t.Errorf("%s: expected no parent without edges, got %q", stage.Name, stage.ParentID)
+		}
+	}
+
+	graph, err := client.GetPipelineGraph(context.Background(), "test-job", 5)
+	if err != nil {
+		t.Fatalf("GetPipelineGraph failed: %v", err)
+	}
+	expected := []string{"", "", "12", "12", ""}
+	for i, stage := range graph.Stages {
+		if stage.ParentID != expected[i] {
+			t.Errorf("%s: expected parent %q, got %q", stage.Name, expected[i], stage.ParentID)
+		}
+	}
+}
+
+func TestGetPipelineRunFromBlueOceanEdges(t *testing.T) {
+	nodes := []models.BlueOceanNode{
+		{ID: "6", DisplayName: "Build", Type: "STAGE", State: "FINISHED", Result: "SUCCESS",
+			StartTime: "2024-01-01T10:00:00.000+0000", Edges: []models.BlueOceanEdge{{ID: "12"}}},
+		{ID: "12", DisplayName: "Tests", Type: "STAGE", State: "FINISHED", Result: "FAILURE",
+			StartTime: "2024-01-01T10:01:00.000+0000", Edges: []models.BlueOceanEdge{{ID: "15"}, {ID: "16"}}},
+		{ID: "15", DisplayName: "Unit", Type: "PARALLEL", State: "FINISHED", Result: "SUCCESS", FirstParent: "12",
+			StartTime: "2024-01-01T10:01:01.000+0000", Edges: []models.BlueOceanEdge{{ID: "40"}}},
+		{ID: "16", DisplayName: "Integration", Type: "PARALLEL", State: "FINISHED", Result: "FAILURE", FirstParent: "12",
+			StartTime: "2024-01-01T10:01:02.000+0000", Edges: []models.BlueOceanEdge{{ID: "40"}}},
+		{ID: "40", DisplayName: "Deploy", Type: "STAGE", State: "NOT_BUILT",
+			StartTime: "2024-01-01T10:05:00.000+0000"},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/blue/rest/organizations/jenkins/pipelines/test-job/runs/5/nodes/" {
+			json.NewEncoder(w).Encode(nodes)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(testConfig(server.URL))
+	run, err := client.GetPipelineRun(context.Background(), "test-job", 5)
+	if err != nil || run == nil {
+		t.Fatalf("GetPipelineRun failed: %v", err)
+	}
+
+	if len(run.Stages) != 5 {
+		t.Fatalf("expected 5 stages, got %d", len(run.Stages))
+	}
+	byName := map[string]models.Stage{}
+	for _, stage := range run.Stages {
+		byName[stage.Name] = stage
+	}
+	if byName["Unit"].ParentID != "12" || byName["Integration"].ParentID != "12" {
+		t.Errorf("expected branches to be attached to Tests, got %+v", run.Stages)
+	}
+	if byName["Deploy"].ParentID != "" || byName["Deploy"].Status != "NOT_EXECUTED" {
+		t.Errorf("unexpected Deploy stage: %+v", byName["Deploy"])
+	}
+	if byName["Integration"].Status != "FAILED" || run.Status != "FAILED" {
+		t.Errorf("expected failed status, got stage %q run %q", byName["Integration"].Status, run.Status)
+	}
+	if byName["Build"].StartTimeMillis == 0 {
+		t.Error("expected start time to be parsed")
+	}
+}
+
+func TestGetStageStepsAndStepLog(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/job/test-job/221/execution/node/12/wfapi/describe":
+			json.NewEncoder(w).Encode(models.WFAPIStage{
+				ID:   "12",
+				Name: "Test",
+				StageFlowNodes: []models.WFAPIFlowNode{
+					{ID: "13", Name: "Shell Script", Status: "FAILED", ParameterDescription: "make test", ParentNodes: []string{"12"}},
+				},
+			})
+		case "/job/test-job/221/execution/node/13/wfapi/log":
+			json.NewEncoder(w).Encode(models.StageLog{
+				NodeID: "13",
+				Text:   "+ make test\n<span class=\"pipeline-node-13\">FAIL: a &lt; b</span>\n",
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(testConfig(server.URL))
+	ctx := context.Background()
+
+	steps, err := client.GetStageSteps(ctx, "test-job", 221, "12")
+	if err != nil {
+		t.Fatalf("GetStageSteps failed: %v", err)
+	}
+	if len(steps) != 1 || steps[0].ParameterDescription != "make test" {
+		t.Fatalf("unexpected steps: %+v", steps)
+	}
+
+	log, err := client.GetStepLog(ctx, "test-job", 221, "12", "13")
+	if err != nil {
+		t.Fatalf("GetStepLog failed: %v", err)
+	}
+	if log != "+ make test\nFAIL: a < b\n" {
+		t.Errorf("expected decoded step log, got %q", log)
+	}
+}
+
+func TestGetTestReport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/job/test-job/5/testReport/api/json":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{
+				"failCount": 2, "passCount": 1, "skipCount": 1, "duration": 2,
+				"childReports": [
+					{"result": {"failCount": 1, "passCount": 0, "skipCount": 1, "duration": 0.5,
+						"suites": [{"name": "module.Suite", "cases": [
+							{"className": "module.Suite", "name": "testSkip", "status": "SKIPPED", "skipped": true},
+							{"className": "module.Suite", "name": "testOld", "status": "FAILED", "age": 4}
+						]}]}},
+					{"result": {"failCount": 1, "passCount": 1, "skipCount": 0, "duration": 1.5,
+						"suites": [{"name": "app.Suite", "duration": 1.5, "cases": [
+							{"className": "app.Suite", "name": "testOk", "status": "PASSED"},
+							{"className": "app.Suite", "name": "testNew", "status": "REGRESSION", "age": 1,
+							 "errorDetails": "expected 1", "errorStackTrace": "at app.Suite.testNew"}
+						]}]}}
+				]
+			}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(testConfig(server.URL))
+
+	report, err := client.GetTestReport(context.Background(), "test-job", 5)
+	if err != nil {
+		t.Fatalf("GetTestReport failed: %v", err)
+	}
+	if report.Total() != 4 || report.FailCount != 2 || len(report.Suites) != 2 || report.ChildReports != nil {
+		t.Fatalf("expected child reports merged, got %+v", report)
+	}
+
+	failures := report.Failures()
+	if len(failures) != 2 || failures[0].Name != "testNew" || !failures[0].IsNewFailure() || failures[1].IsNewFailure() {
+		t.Errorf("expected the new failure first, got %+v", failures)
+	}
+	if failures[0].ErrorStackTrace == "" {
+		t.Error("expected stack trace to be decoded")
+	}
+
+	// Builds without test results have no report
+	report, err = client.GetTestReport(context.Background(), "test-job", 6)
+	if err != nil || report != nil {
+		t.Errorf("expected no report and no error, got %+v, %v", report, err)
+	}
+}
+
+func TestGetTestHistoryUsesCache(t *testing.T) {
+	var mu sync.Mutex
+	requests := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests[r.URL.Path]++
+		mu.Unlock()
+		switch r.URL.Path {
+		case "/job/test-job/1/testReport/api/json":
+			w.Write([]byte(`{"failCount": 1, "suites": [{"name": "s", "cases": [{"className": "s", "name": "t", "status": "FAILED"}]}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(testConfig(server.URL))
+	client.SetCache(cache.New(t.TempDir()))
+
+	builds := []models.BuildRef{
+		{Number: 3, Building: true, Timestamp: 3000},
+		{Number: 2, Result: "SUCCESS", Timestamp: 2000},
+		{Number: 1, Result: "FAILURE", Timestamp: 1000},
+	}
+
+	for i := 0; i < 2; i++ {
+		history, err := client.GetTestHistory(context.Background(), "test-job", builds)
+		if err != nil {
+			t.Fatalf("GetTestHistory failed: %v", err)
+		}
+		if len(history) != 2 {
+			t.Fatalf("expected the two finished builds, got %d", len(history))
+		}
+		for _, h := range history {
+			if (h.Build == 1) != (h.Report != nil) {
+				t.Errorf("unexpected report for build %d: %+v", h.Build, h.Report)
+			}
+		}
+	}
+
+	// Finished builds are fetched once, including those without results
+	if requests["/job/test-job/1/testReport/api/json"] != 1 || requests["/job/test-job/2/testReport/api/json"] != 1 {
+		t.Errorf("expected one request per finished build, got %v", requests)
+	}
+	if requests["/job/test-job/3/testReport/api/json"] != 0 {
+		t.Error("expected running builds to be skipped")
+	}
+
+	// A recreated job numbers its builds from 1 again
+	builds[2].Timestamp = 5000
+	if _, err := client.GetTestHistory(context.Background(), "test-job", builds[2:]); err != nil {
+		t.Fatalf("GetTestHistory failed: %v", err)
+	}
+	if requests["/job/test-job/1/testReport/api/json"] != 2 {
+		t.Error("expected the stale entry of build 1 to be fetched again")
+	}
+}
+
+func TestGetTestHistoryPrunesCache(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"failCount": 0}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(testConfig(server.URL))
+	store := cache.New(t.TempDir())
+	client.SetCache(store)
+
+	// Older builds cached by earlier sessions
+	prefix := client.testReportPrefix("test-job")
+	for n := 1; n <= testReportCacheBuilds+10; n++ {
+		store.Put(prefix+"/"+cache.Key(itoa(n)), cachedTestReport{Timestamp: int64(n)})
+	}
+	builds := []models.BuildRef{{Number: testReportCacheBuilds + 11, Result: "SUCCESS", Timestamp: 1}}
+	if _, err := client.GetTestHistory(context.Background(), "test-job", builds); err != nil {
+		t.Fatalf("GetTestHistory failed: %v", err)
+	}
+
+	if keys := store.Keys(prefix); len(keys) != testReportCacheBuilds {
+		t.Fatalf("expected %d cached builds, got %d", testReportCacheBuilds, len(keys))
+	}
+	var entry cachedTestReport
+	if store.Get(prefix+"/"+cache.Key("11"), &entry) {
+		t.Error("expected the oldest builds pruned")
+	}
+	if !store.Get(prefix+"/"+cache.Key("12"), &entry) || !store.Get(prefix+"/"+cache.Key(itoa(testReportCacheBuilds+11)), &entry) {
+		t.Error("expected the newest builds kept")
+	}
+}
+
+func TestGetArtifactAndDownload(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/job/test-job/7/artifact/reports/summary%20v2.json":
+			w.Write([]byte(`{"passed": 12}`))
+		case "/job/test-job/7/artifact/bin/app":
+			w.Write([]byte{0x7f, 'E', 'L', 'F', 0, 1})
+		case "/job/test-job/7/artifact/*zip*/archive.zip":
+			w.Header().Set("Content-Length", "9")
+			w.Write([]byte("PK-zipped"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(testConfig(server.URL))
+	ctx := context.Background()
+
+	if url := client.ArtifactURL("test-job", 7, "reports/summary v2.json"); url != server.URL+"/job/test-job/7/artifact/reports/summary%20v2.json" {
+		t.Errorf("unexpected artifact URL %q", url)
+	}
+
+	preview, err := client.GetArtifact(ctx, "test-job", 7, "reports/summary v2.json", 10)
+	if err != nil {
+		t.Fatalf("GetArtifact failed: %v", err)
+	}
+	if preview.Content != `{"passed":` || !preview.Truncated {
+		t.Errorf("expected a truncated preview, got %+v", preview)
+	}
+	if _, err := client.GetArtifact(ctx, "test-job", 7, "bin/app", 1024); !errors.Is(err, ErrBinaryArtifact) {
+		t.Errorf("expected ErrBinaryArtifact, got %v", err)
+	}
+	if _, err := client.GetArtifact(ctx, "test-job", 7, "missing.txt", 1024); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+
+	dir := t.TempDir()
+	var written, total int64
+	file, err := client.DownloadArtifact(ctx, "test-job", 7, ArchiveName, dir, func(w, t int64) {
+		written, total = w, t
+	})
+	if err != nil {
+		t.Fatalf("DownloadArtifact failed: %v", err)
+	}
+	if file != filepath.Join(dir, "test-job-7-artifacts.zip") || written != 9 || total != 9 {
+		t.Errorf("unexpected download %q (%d/%d bytes)", file, written, total)
+	}
+	if data, _ := os.ReadFile(file); string(data) != "PK-zipped" {
+		t.Errorf("unexpected file content %q", data)
+	}
+
+	// Failed downloads leave nothing behind
+	if _, err := client.DownloadArtifact(ctx, "test-job", 7, "missing.txt", dir, nil); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("expected only the zip in the directory, got %d entries", len(entries))
+	}
+
+	// Downloading again keeps the first file
+	file, err = client.DownloadArtifact(ctx, "test-job", 7, ArchiveName, dir, nil)
+	if err != nil || file != filepath.Join(dir, "test-job-7-artifacts (1).zip") {
+		t.Errorf("expected a numbered name, got %q, %v", file, err)
+	}
+}
+
+func TestPendingInputActions(t *testing.T) {
+	var mu sync.Mutex
+	var posts []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/job/deploy/12/wfapi/pendingInputActions":
+			w.Write([]byte(`[{"id": "Approve", "message": "Deploy?", "inputs": [
+				{"type": "BooleanParameterDefinition", "name": "DRY_RUN"},
+				{"type": "StringParameterDefinition", "name": "TAG"}
+			]}]`))
+		case r.Method == http.MethodPost:
+			r.ParseForm()
+			mu.Lock()
+			posts = append(posts, r.URL.RequestURI()+" "+r.PostForm.Get("json"))
+			mu.Unlock()
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(testConfig(server.URL))
+	ctx := context.Background()
+
+	inputs, err := client.GetPendingInputs(ctx, "deploy", 12)
+	if err != nil || len(inputs) != 1 || len(inputs[0].Inputs) != 2 {
+		t.Fatalf("expected one pending input with two parameters, got %+v, %v", inputs, err)
+	}
+	if none, err := client.GetPendingInputs(ctx, "freestyle", 3); err != nil || none != nil {
+		t.Errorf("expected no inputs for non-pipelines, got %+v, %v", none, err)
+	}
+
+	if err := client.ProceedInput(ctx, "deploy", 12, inputs[0], map[string]string{"DRY_RUN": "true", "TAG": "v1.2"}); err != nil {
+		t.Fatalf("ProceedInput failed: %v", err)
+	}
+	if err := client.ProceedInput(ctx, "deploy", 12, models.PendingInput{ID: "Gate"}, nil); err != nil {
+		t.Fatalf("ProceedInput without parameters failed: %v", err)
+	}
+	if err := client.AbortInput(ctx, "deploy", 12, "Approve"); err != nil {
+		t.Fatalf("AbortInput failed: %v", err)
+	}
+
+	expected := []string{
+		`/job/deploy/12/wfapi/inputSubmit?inputId=Approve {"parameter":[{"name":"DRY_RUN","value":true},{"name":"TAG","value":"v1.2"}]}`,
+		`/job/deploy/12/input/Gate/proceedEmpty `,
+		`/job/deploy/12/input/Approve/abort `,
+	}
+	if strings.Join(posts, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("unexpected requests:\n%s", strings.Join(posts, "\n"))
+	}
+}
+
+func TestParseBuildURL(t *testing.T) {
+	client, _ := NewClient(testConfig("https://ci.example.com/jenkins/"))
+	tests := []struct {
+		url   string
+		job   string
+		build int
+	}{
+		{"https://ci.example.com/jenkins/job/team/job/deploy%20app/42/", "team/deploy app", 42},
+		{"https://ci.example.com/jenkins/job/app/7", "app", 7},
+		{"https://ci.example.com/jenkins/job/app/", "", 0},
+		{"https://ci.example.com/jenkins/job/app/7/console", "", 0},
+	}
+	for _, tt := range tests {
+		if job, build := client.ParseBuildURL(tt.url); job != tt.job || build != tt.build {
+			t.Errorf("ParseBuildURL(%q) = %q, %d; want %q, %d", tt.url, job, build, tt.job, tt.build)
+		}
+	}
+}
+
+func TestReplay(t *testing.T) {
+	var submitted string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/job/app/7/replay/":
+			w.Write([]byte(`<form method="post" action="run" name="config">
+<textarea name="mainScript" class="ace-editor">
+pipeline { agent any; stages { stage('a') { steps { echo "a &amp;&amp; b" } } } }</textarea>
+<textarea name="Script1" class="ace-editor">
+def call() { 1 &lt; 2 }</textarea>
+</form>`))
+		case r.URL.Path == "/job/app/api/json":
+			w.Write([]byte(`{"nextBuildNumber": 9}`))
+		case r.URL.Path == "/job/app/7/replay/run" && r.Method == http.MethodPost:
+			r.ParseForm()
+			submitted = r.PostForm.Get("json")
+			w.WriteHeader(http.StatusOK)
+		case r.URL.Path == "/job/app/6/replay/run":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(testConfig(server.URL))
+	ctx := context.Background()
+
+	scripts, err := client.GetReplayScripts(ctx, "app", 7)
+	if err != nil {
+		t.Fatalf("GetReplayScripts failed: %v", err)
+	}
+	if !strings.HasPrefix(scripts.Main, "pipeline {") || !strings.Contains(scripts.Main, `"a && b"`) {
+		t.Errorf("unexpected main script %q", scripts.Main)
+	}
+	if len(scripts.Loaded) != 1 || scripts.Loaded[0].Name != "Script1" || scripts.Loaded[0].Script != "def call() { 1 < 2 }" {
+		t.Errorf("unexpected loaded scripts %+v", scripts.Loaded)
+	}
+	if _, err := client.GetReplayScripts(ctx, "freestyle", 3); !errors.Is(err, ErrReplayUnavailable) {
+		t.Errorf("expected ErrReplayUnavailable, got %v", err)
+	}
+
+	scripts.Main = "echo 'edited'"
+	next, err := client.Replay(ctx, "app", 7, *scripts)
+	if err != nil || next != 9 {
+		t.Fatalf("expected the replay queued as #9, got %d, %v", next, err)
+	}
+	var form map[string]string
+	if err := json.Unmarshal([]byte(submitted), &form); err != nil {
+		t.Fatalf("replay form is not JSON: %q", submitted)
+	}
+	if form["mainScript"] != "echo 'edited'" || form["Script1"] != "def call() { 1 < 2 }" {
+		t.Errorf("unexpected replay form %v", form)
+	}
+
+	// A refused replay revokes Run/Replay on that build, not Job/Configure
+	if _, err := client.Replay(ctx, "app", 6, *scripts); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("expected ErrForbidden, got %v", err)
+	}
+	if client.Can(PermReplay, "app", 6) || !client.Can(PermReplay, "app", 7) || !client.Can(PermConfigure, "app", 0) {
+		t.Error("expected only Run/Replay on #6 revoked")
+	}
+}
+
+func TestLint(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/pipeline-model-converter/validate" || r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		r.ParseForm()
+		if strings.Contains(r.PostForm.Get("jenkinsfile"), "agent any") {
+			w.Write([]byte("Jenkinsfile successfully validated.\n"))
+			return
+		}
+		w.Write([]byte(`Errors encountered validating Jenkinsfile:
+WorkflowScript: 2: Missing required section "agent" @ line 2, column 1.
+   pipeline {
+   ^
+
+WorkflowScript: 4: Unknown stage section "step". @ line 4, column 9.
+           stage('build') {
+           ^
+
+2 errors
+`))
+	}))
+	defer server.Close()
+
+	// Linting changes nothing: allowed on read-only profiles, but audited
+	cfg := testConfig(server.URL)
+	readOnly := true
+	cfg.Profile.ReadOnly = &readOnly
+	client, _ := NewClient(cfg)
+	log := audit.New(filepath.Join(t.TempDir(), audit.FileName))
+	client.SetAuditLog(log)
+	ctx := context.Background()
+
+	result, err := client.Lint(ctx, "pipeline { agent any }")
+	if err != nil || !result.Valid {
+		t.Fatalf("expected a valid Jenkinsfile, got %+v, %v", result, err)
+	}
+
+	result, err = client.Lint(ctx, "pipeline { }")
+	if err != nil || result.Valid {
+		t.Fatalf("expected lint errors, got %+v, %v", result, err)
+	}
+	expected := []models.LintError{
+		{Line: 2, Column: 1, Message: `Missing required section "agent"`},
+		{Line: 4, Column: 9, Message: `Unknown stage section "step".`},
+	}
+	if len(result.Errors) != len(expected) || result.Errors[0] != expected[0] || result.Errors[1] != expected[1] {
+		t.Errorf("unexpected errors %+v", result.Errors)
+	}
+	entries, _ := log.Recent(0)
+	if len(entries) != 2 || entries[0].Action != "pipeline-model-converter/validate" || entries[0].Parameters["jenkinsfile"] != "pipeline { }" {
+		t.Errorf("expected both lints audited, got %+v", entries)
+	}
+
+	if got := parseLintOutput("Errors encountered validating Jenkinsfile:\nJenkinsfile content 'echo 1' did not contain the 'pipeline' step\n"); len(got.Errors) != 1 || got.Errors[0].Line != 0 {
+		t.Errorf("expected one unplaced error, got %+v", got.Errors)
+	}
+}
+
+func TestJobConfig(t *testing.T) {
+	var contentType, saved string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/job/team/job/app/config.xml" && r.Method == http.MethodGet:
+			w.Write([]byte("<project><description>v1</description></project>"))
+		case r.URL.Path == "/job/team/job/app/config.xml" && r.Method == http.MethodPost:
+			contentType = r.Header.Get("Content-Type")
+			body, _ := io.ReadAll(r.Body)
+			saved = string(body)
+			if !strings.HasPrefix(saved, "<project>") {
+				w.WriteHeader(http.StatusInternalServerError)
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(testConfig(server.URL))
+	ctx := context.Background()
+
+	xml, err := client.GetJobConfig(ctx, "team/app")
+	if err != nil || xml != "<project><description>v1</description></project>" {
+		t.Fatalf("unexpected config %q, %v", xml, err)
+	}
+	if _, err := client.GetJobConfig(ctx, "missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+
+	if err := client.UpdateJobConfig(ctx, "team/app", "<project><description>v2</description></project>"); err != nil {
+		t.Fatalf("UpdateJobConfig failed: %v", err)
+	}
+	if !strings.HasPrefix(contentType, "application/xml") || !strings.Contains(saved, "v2") {
+		t.Errorf("expected the XML posted as application/xml, got %q %q", contentType, saved)
+	}
+	if err := client.UpdateJobConfig(ctx, "team/app", "not xml"); err == nil || !strings.Contains(err.Error(), "rejected") {
+		t.Errorf("expected a rejected configuration, got %v", err)
+	}
+}
+
+func TestJobLifecycle(t *testing.T) {
+	var mu sync.Mutex
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests = append(requests, r.Method+" "+r.URL.RequestURI())
+		mu.Unlock()
+		switch {
+		case r.URL.Path == "/job/team/createItem" && r.URL.Query().Get("name") == "taken":
+			w.WriteHeader(http.StatusBadRequest)
+		case r.URL.Path == "/job/team/job/app-copy/config.xml":
+			w.Write([]byte("<project/>"))
+		case r.URL.Path == "/job/gone/doDelete":
+			w.WriteHeader(http.StatusNotFound)
+		case r.URL.Path == "/job/locked/disable":
+			w.WriteHeader(http.StatusForbidden)
+		}
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(testConfig(server.URL))
+	ctx := context.Background()
+
+	if err := client.DisableJob(ctx, "team/app"); err != nil {
+		t.Fatalf("DisableJob failed: %v", err)
+	}
+	if err := client.EnableJob(ctx, "team/app"); err != nil {
+		t.Fatalf("EnableJob failed: %v", err)
+	}
+	if err := client.CopyJob(ctx, "team/app", "app-copy"); err != nil {
+		t.Fatalf("CopyJob failed: %v", err)
+	}
+	if err := client.DeleteJob(ctx, "team/app"); err != nil {
+		t.Fatalf("DeleteJob failed: %v", err)
+	}
+
+	want := []string{
+		"POST /job/team/job/app/disable",
+		"POST /job/team/job/app/enable",
+		"POST /job/team/createItem?from=app&mode=copy&name=app-copy",
+		"GET /job/team/job/app-copy/config.xml",
+		"POST /job/team/job/app-copy/config.xml",
+		"POST /job/team/job/app/doDelete",
+	}
+	mu.Lock()
+	for i, r := range want {
+		if i >= len(requests) || !strings.HasPrefix(requests[i], r) {
+			t.Errorf("request %d: expected %q, got %v", i, r, requests)
+			break
+		}
+	}
+	mu.Unlock()
+
+	if err := client.CopyJob(ctx, "team/app", "taken"); err == nil || !strings.Contains(err.Error(), "already exist") {
+		t.Errorf("expected a refused name, got %v", err)
+	}
+	if err := client.DeleteJob(ctx, "gone"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+	if err := client.DisableJob(ctx, "locked"); !errors.Is(err, ErrForbidden) || client.Can(PermConfigure, "locked", 0) {
+		t.Errorf("expected the configure permission revoked, got %v", err)
+	}
+}
+
+func TestBuildHousekeeping(t *testing.T) {
+	var mu sync.Mutex
+	forms := map[string]url.Values{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		mu.Lock()
+		forms[r.URL.Path] = r.PostForm
+		mu.Unlock()
+		if r.URL.Path == "/job/app/8/doDelete" {
+			w.WriteHeader(http.StatusForbidden)
+		}
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(testConfig(server.URL))
+	log := audit.New(filepath.Join(t.TempDir(), audit.FileName))
+	client.SetAuditLog(log)
+	ctx := context.Background()
+
+	if err := client.ToggleKeepBuild(ctx, "app", 7); err != nil {
+		t.Fatalf("ToggleKeepBuild failed: %v", err)
+	}
+	if err := client.SetBuildDescription(ctx, "app", 7, "Release <b>1.2</b>"); err != nil {
+		t.Fatalf("SetBuildDescription failed: %v", err)
+	}
+	if err := client.SetBuildDisplayName(ctx, "app", 7, "v1.2", "Release <b>1.2</b>"); err != nil {
+		t.Fatalf("SetBuildDisplayName failed: %v", err)
+	}
+	if err := client.DeleteBuild(ctx, "app", 7); err != nil {
+		t.Fatalf("DeleteBuild failed: %v", err)
+	}
+
+	mu.Lock()
+	if _, ok := forms["/job/app/7/toggleLogKeep"]; !ok {
+		t.Error("expected a POST to toggleLogKeep")
+	}
+	if got := forms["/job/app/7/submitDescription"].Get("description"); got != "Release <b>1.2</b>" {
+		t.Errorf("unexpected description %q", got)
+	}
+	var submitted map[string]string
+	json.Unmarshal([]byte(forms["/job/app/7/configSubmit"].Get("json")), &submitted)
+	if submitted["displayName"] != "v1.2" || submitted["description"] != "Release <b>1.2</b>" {
+		t.Errorf("expected the name submitted with the description kept, got %v", submitted)
+	}
+	if _, ok := forms["/job/app/7/doDelete"]; !ok {
+		t.Error("expected a POST to doDelete")
+	}
+	mu.Unlock()
+
+	if err := client.DeleteBuild(ctx, "app", 8); !errors.Is(err, ErrForbidden) || client.Can(PermDeleteBuild, "app", 8) {
+		t.Errorf("expected Run/Delete revoked, got %v", err)
+	}
+	if !client.Can(PermUpdateBuild, "app", 8) || !client.Can(PermDeleteBuild, "app", 7) {
+		t.Error("expected Run/Update and other builds unaffected")
+	}
+
+	entries, _ := log.Recent(0)
+	var actions []string
+	for _, e := range entries {
+		actions = append(actions, e.Action)
+	}
+	if strings.Join(actions, ",") != "doDelete,doDelete,configSubmit,submitDescription,toggleLogKeep" || entries[1].Build != 7 {
+		t.Errorf("expected every housekeeping action audited, got %v", actions)
+	}
+}
+
+func TestGetJobTree(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.URL.Query().Get("tree"), "jobs[name,fullName") {
+			t.Errorf("unexpected tree %q", r.URL.Query().Get("tree"))
+		}
+		w.Write([]byte(`{"jobs":[
+			{"name":"app","fullName":"app","color":"blue"},
+			{"name":"team","fullName":"team","jobs":[
+				{"name":"api","fullName":"team/api","color":"red"},
+				{"name":"empty","fullName":"team/empty","jobs":[]},
+				{"name":"repo","jobs":[{"name":"main","color":"blue_anime"}]}
+			]}
+		]}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(testConfig(server.URL))
+	jobs, err := client.GetJobTree(context.Background())
+	if err != nil {
+		t.Fatalf("GetJobTree failed: %v", err)
+	}
+	var names []string
+	for _, job := range jobs {
+		names = append(names, job.FullName)
+	}
+	if strings.Join(names, ",") != "app,team/api,team/repo/main" {
+		t.Errorf("unexpected jobs %v", names)
+	}
+}
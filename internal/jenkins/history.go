@@ -0,0 +1,149 @@
+package jenkins
+
+import (
+	"context"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/elogrono/jenkins-tui/internal/cache"
+	"github.com/elogrono/jenkins-tui/internal/jenkins/models"
+	"github.com/elogrono/jenkins-tui/internal/logger"
+)
+
+// historyWorkers bounds concurrent test report requests
+const historyWorkers = 4
+
+// testReportCacheBuilds is the number of newest builds per job whose test
+// reports stay cached; reports carry stack traces and add up quickly
+const testReportCacheBuilds = 100
+
+// cachedTestReport wraps a report so builds without results are cached too.
+// The build's start time tells it apart from a build with the same number
+// of a job that was deleted and created again.
+type cachedTestReport struct {
+	Report    *models.TestReport `json:"report"`
+	Timestamp int64              `json:"timestamp"`
+}
+
+// GetTestHistory fetches the test reports of the given builds. Running builds
+// are skipped; reports of finished builds are served from the cache when
+// possible. Builds whose report cannot be fetched are left out.
+func (c *Client) GetTestHistory(ctx context.Context, jobName string, builds []models.BuildRef) ([]models.BuildTestReport, error) {
+	var finished []models.BuildRef
+	for _, b := range builds {
+		if !b.Building && b.Result != "" {
+			finished = append(finished, b)
+		}
+	}
+
+	results := make([]*models.BuildTestReport, len(finished))
+	errs := make([]error, len(finished))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < historyWorkers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				b := finished[i]
+				report, err := c.cachedTestReport(ctx, jobName, b)
+				if err != nil {
+					errs[i] = err
+					continue
+				}
+				results[i] = &models.BuildTestReport{Build: b.Number, Result: b.Result, Report: report}
+			}
+		}()
+	}
+	for i := range finished {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	var history []models.BuildTestReport
+	var firstErr error
+	for i, r := range results {
+		if r != nil {
+			history = append(history, *r)
+		} else if firstErr == nil {
+			firstErr = errs[i]
+		}
+	}
+	if len(history) == 0 && firstErr != nil {
+		return nil, firstErr
+	}
+	if firstErr != nil {
+		logger.Warn("Some test reports could not be fetched", "job", jobName, "error", firstErr)
+	}
+	c.pruneTestReports(jobName)
+	return history, nil
+}
+
+// testReportPrefix returns the cache prefix of the test reports of a job
+func (c *Client) testReportPrefix(jobName string) string {
+	host := c.baseURL
+	if u, err := url.Parse(c.baseURL); err == nil {
+		host = u.Host + u.Path
+	}
+	return cache.Key("testreports", host, jobName)
+}
+
+// pruneTestReports drops the cached test reports of a job beyond its
+// testReportCacheBuilds newest builds
+func (c *Client) pruneTestReports(jobName string) {
+	if c.cache == nil {
+		return
+	}
+	type cached struct {
+		key   string
+		build int
+	}
+	var entries []cached
+	for _, key := range c.cache.Keys(c.testReportPrefix(jobName)) {
+		if n, err := strconv.Atoi(path.Base(key)); err == nil {
+			entries = append(entries, cached{key: key, build: n})
+		}
+	}
+	if len(entries) <= testReportCacheBuilds {
+		return
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].build > entries[j].build })
+	for _, e := range entries[testReportCacheBuilds:] {
+		if err := c.cache.Delete(e.key); err != nil {
+			logger.Warn("Failed to prune test report", "job", jobName, "build", e.build, "error", err)
+		}
+	}
+}
+
+// cachedTestReport returns the test report of a finished build, using the
+// cache when the build's start time is known
+func (c *Client) cachedTestReport(ctx context.Context, jobName string, build models.BuildRef) (*models.TestReport, error) {
+	buildNumber := build.Number
+	useCache := c.cache != nil && build.Timestamp != 0
+	var key string
+	if useCache {
+		key = c.testReportPrefix(jobName) + "/" + cache.Key(itoa(buildNumber))
+
+		// Entries of another build with the same number are stale
+		var entry cachedTestReport
+		if c.cache.Get(key, &entry) && entry.Timestamp == build.Timestamp {
+			return entry.Report, nil
+		}
+	}
+
+	report, err := c.GetTestReport(ctx, jobName, buildNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	if useCache {
+		if err := c.cache.Put(key, cachedTestReport{Report: report, Timestamp: build.Timestamp}); err != nil {
+			logger.Warn("Failed to cache test report", "job", jobName, "build", buildNumber, "error", err)
+		}
+	}
+	return report, nil
+}
@@ -0,0 +1,68 @@
+package jenkins
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// ToggleKeepBuild marks a build to be kept forever, or releases it so the
+// job's log rotation may discard it again
+func (c *Client) ToggleKeepBuild(ctx context.Context, jobName string, buildNumber int) error {
+	return c.postBuildForm(ctx, jobName, buildNumber, "toggleLogKeep", nil, PermUpdateBuild)
+}
+
+// SetBuildDescription replaces the description of a build
+func (c *Client) SetBuildDescription(ctx context.Context, jobName string, buildNumber int, description string) error {
+	form := url.Values{"description": {description}}
+	return c.postBuildForm(ctx, jobName, buildNumber, "submitDescription", form, PermUpdateBuild)
+}
+
+// SetBuildDisplayName renames a build; an empty name restores the default
+// "#N". The build configuration form carries the description too, so the
+// current one must be passed to keep it.
+func (c *Client) SetBuildDisplayName(ctx context.Context, jobName string, buildNumber int, displayName, description string) error {
+	payload, err := json.Marshal(map[string]string{"displayName": displayName, "description": description})
+	if err != nil {
+		return fmt.Errorf("error encoding build settings: %w", err)
+	}
+	form := url.Values{"json": {string(payload)}}
+	return c.postBuildForm(ctx, jobName, buildNumber, "configSubmit", form, PermUpdateBuild)
+}
+
+// DeleteBuild deletes a build with its log and artifacts. Jenkins refuses to
+// delete builds that are kept forever.
+func (c *Client) DeleteBuild(ctx context.Context, jobName string, buildNumber int) error {
+	return c.postBuildForm(ctx, jobName, buildNumber, "doDelete", nil, PermDeleteBuild)
+}
+
+// postBuildForm posts a form to an action URL of a build; Jenkins answers
+// these with a redirect to the build page
+func (c *Client) postBuildForm(ctx context.Context, jobName string, buildNumber int, action string, form url.Values, perm Permission) error {
+	path := "/job/" + encodeJobPath(jobName) + "/" + itoa(buildNumber) + "/" + action
+	var body io.Reader
+	if form != nil {
+		body = strings.NewReader(form.Encode())
+	}
+	resp, err := c.doRequest(ctx, http.MethodPost, path, body)
+	if err != nil {
+		if errors.Is(err, ErrForbidden) {
+			c.denyPermission(perm, jobName, buildNumber)
+		}
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("build #%d %w", buildNumber, ErrNotFound)
+	}
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("unexpected status %d on %s", resp.StatusCode, action)
+	}
+	return nil
+}
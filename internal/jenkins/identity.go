@@ -0,0 +1,168 @@
+package jenkins
+
+import (
+	"context"
+	"sync"
+
+	"github.com/elogrono/jenkins-tui/internal/jenkins/models"
+	"github.com/elogrono/jenkins-tui/internal/logger"
+)
+
+// Permission identifies a mutating action offered by the UI
+type Permission int
+
+const (
+	PermBuild Permission = iota
+	PermCancel
+	PermConfigure
+	PermDelete
+	PermCreate
+	PermUpdateBuild
+	PermDeleteBuild
+	PermReplay
+)
+
+// String returns the Jenkins name of the permission
+func (p Permission) String() string {
+	switch p {
+	case PermBuild:
+		return "Job/Build"
+	case PermCancel:
+		return "Job/Cancel"
+	case PermConfigure:
+		return "Job/Configure"
+	case PermDelete:
+		return "Job/Delete"
+	case PermCreate:
+		return "Job/Create"
+	case PermUpdateBuild:
+		return "Run/Update"
+	case PermDeleteBuild:
+		return "Run/Delete"
+	case PermReplay:
+		return "Run/Replay"
+	default:
+		return "Unknown"
+	}
+}
+
+// onBuild reports whether Jenkins checks the permission on a build rather
+// than on its job
+func (p Permission) onBuild() bool {
+	return p == PermUpdateBuild || p == PermDeleteBuild || p == PermReplay
+}
+
+// denial is a permission Jenkins refused on one job, or on one build for
+// Run/* permissions. Job/Create is refused on the folder the item goes into,
+// "" being the root.
+type denial struct {
+	perm  Permission
+	job   string
+	build int
+}
+
+// newDenial returns the key of p on the job or build
+func newDenial(p Permission, job string, build int) denial {
+	if !p.onBuild() {
+		build = 0
+	}
+	return denial{perm: p, job: job, build: build}
+}
+
+// identity holds what we know about the authenticated user
+type identity struct {
+	mu     sync.RWMutex
+	user   *models.User
+	whoAmI *models.WhoAmI
+	denied map[denial]bool
+}
+
+// LoadIdentity fetches the current user from /me and /whoAmI.
+// Failures are logged but not fatal: the UI then assumes full permissions
+// and learns denials from 403 responses.
+func (c *Client) LoadIdentity(ctx context.Context) error {
+	var who models.WhoAmI
+	whoErr := c.getJSON(ctx, "/whoAmI/api/json", &who)
+	if whoErr != nil {
+		logger.Warn("Could not fetch whoAmI", "error", whoErr)
+	}
+
+	var user models.User
+	userErr := c.getJSON(ctx, "/me/api/json?"+buildTreeParam("id", "fullName", "absoluteUrl", "description"), &user)
+	if userErr != nil {
+		logger.Warn("Could not fetch current user", "error", userErr)
+	}
+
+	c.identity.mu.Lock()
+	defer c.identity.mu.Unlock()
+	if whoErr == nil {
+		c.identity.whoAmI = &who
+	}
+	if userErr == nil {
+		c.identity.user = &user
+	}
+
+	logger.Info("Identity loaded",
+		"user", user.ID,
+		"anonymous", who.Anonymous,
+		"authorities", len(who.Authorities),
+	)
+
+	if whoErr != nil {
+		return whoErr
+	}
+	return userErr
+}
+
+// CurrentUser returns the authenticated user, or nil if unknown
+func (c *Client) CurrentUser() *models.User {
+	c.identity.mu.RLock()
+	defer c.identity.mu.RUnlock()
+	if c.identity.user == nil && c.identity.whoAmI != nil && !c.identity.whoAmI.Anonymous {
+		return &models.User{ID: c.identity.whoAmI.Name}
+	}
+	return c.identity.user
+}
+
+// Authorities returns the granted authorities (groups) of the current user
+func (c *Client) Authorities() []string {
+	c.identity.mu.RLock()
+	defer c.identity.mu.RUnlock()
+	if c.identity.whoAmI == nil {
+		return nil
+	}
+	return c.identity.whoAmI.Authorities
+}
+
+// IsAnonymous reports whether Jenkins treats the session as anonymous
+func (c *Client) IsAnonymous() bool {
+	c.identity.mu.RLock()
+	defer c.identity.mu.RUnlock()
+	return c.identity.whoAmI != nil && (c.identity.whoAmI.Anonymous || !c.identity.whoAmI.Authenticated)
+}
+
+// Can reports whether the current user is believed to hold the permission on
+// a job, or on one of its builds for Run/* permissions (build is ignored
+// otherwise). Jenkins does not expose effective permissions over the REST API
+// and grants them per item, so this combines the anonymous flag with the
+// denials observed on that item during the session.
+func (c *Client) Can(p Permission, job string, build int) bool {
+	if c.IsAnonymous() {
+		return false
+	}
+	c.identity.mu.RLock()
+	defer c.identity.mu.RUnlock()
+	return !c.identity.denied[newDenial(p, job, build)]
+}
+
+// denyPermission records that Jenkins rejected an action on a job or build
+// with 403
+func (c *Client) denyPermission(p Permission, job string, build int) {
+	c.identity.mu.Lock()
+	defer c.identity.mu.Unlock()
+	if c.identity.denied == nil {
+		c.identity.denied = make(map[denial]bool)
+	}
+	c.identity.denied[newDenial(p, job, build)] = true
+	logger.Warn("Permission denied by Jenkins", "permission", p.String(), "job", job, "build", build)
+}
@@ -0,0 +1,115 @@
+package jenkins
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestLoadIdentity(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/whoAmI/api/json":
+			w.Write([]byte(`{"name":"jdoe","anonymous":false,"authenticated":true,"authorities":["authenticated","devs"]}`))
+		case "/me/api/json":
+			w.Write([]byte(`{"id":"jdoe","fullName":"John Doe"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(testConfig(server.URL))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := client.LoadIdentity(ctx); err != nil {
+		t.Fatalf("LoadIdentity failed: %v", err)
+	}
+
+	user := client.CurrentUser()
+	if user == nil || user.ID != "jdoe" || user.DisplayName() != "John Doe" {
+		t.Errorf("unexpected user: %+v", user)
+	}
+	if len(client.Authorities()) != 2 {
+		t.Errorf("expected 2 authorities, got %v", client.Authorities())
+	}
+	if client.IsAnonymous() {
+		t.Error("expected authenticated session")
+	}
+	if !client.Can(PermBuild, "test-job", 0) || !client.Can(PermCancel, "test-job", 0) {
+		t.Error("expected permissions to be granted by default")
+	}
+}
+
+func TestAnonymousIdentityHasNoPermissions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/whoAmI/api/json" {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"name":"anonymous","anonymous":true,"authenticated":false}`))
+			return
+		}
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(testConfig(server.URL))
+	_ = client.LoadIdentity(context.Background())
+
+	if !client.IsAnonymous() {
+		t.Error("expected anonymous session")
+	}
+	if client.Can(PermBuild, "test-job", 0) {
+		t.Error("anonymous user should not be able to build")
+	}
+	if client.CurrentUser() != nil {
+		t.Error("expected no current user for anonymous session")
+	}
+}
+
+func TestForbiddenActionRevokesPermission(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(testConfig(server.URL))
+
+	err := client.AbortBuild(context.Background(), "test-job", 5)
+	if !errors.Is(err, ErrForbidden) {
+		t.Fatalf("expected ErrForbidden, got %v", err)
+	}
+	if client.Can(PermCancel, "test-job", 0) {
+		t.Error("expected cancel permission to be revoked after 403")
+	}
+	if !client.Can(PermBuild, "test-job", 0) {
+		t.Error("build permission should be unaffected")
+	}
+	if !client.Can(PermCancel, "other-job", 0) {
+		t.Error("cancel permission on other jobs should be unaffected")
+	}
+}
+
+func TestForbiddenBuildActionRevokesPermissionOnThatBuild(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(testConfig(server.URL))
+
+	if err := client.DeleteBuild(context.Background(), "test-job", 5); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("expected ErrForbidden, got %v", err)
+	}
+	if client.Can(PermDeleteBuild, "test-job", 5) {
+		t.Error("expected delete permission on #5 to be revoked after 403")
+	}
+	if !client.Can(PermDeleteBuild, "test-job", 6) {
+		t.Error("delete permission on other builds should be unaffected")
+	}
+}
@@ -0,0 +1,108 @@
+package jenkins
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/elogrono/jenkins-tui/internal/jenkins/models"
+)
+
+// GetPendingInputs fetches the input steps a pipeline build is paused on. It
+// returns nil without error for builds that are not pipelines.
+func (c *Client) GetPendingInputs(ctx context.Context, jobName string, buildNumber int) ([]models.PendingInput, error) {
+	path := "/job/" + encodeJobPath(jobName) + "/" + itoa(buildNumber) + "/wfapi/pendingInputActions"
+	var inputs []models.PendingInput
+	if err := c.getJSON(ctx, path, &inputs); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return inputs, nil
+}
+
+// ProceedInput approves an input step with the given parameter values. Values
+// of boolean parameters must be "true" or "false".
+func (c *Client) ProceedInput(ctx context.Context, jobName string, buildNumber int, input models.PendingInput, values map[string]string) error {
+	base := "/job/" + encodeJobPath(jobName) + "/" + itoa(buildNumber)
+	if len(input.Inputs) == 0 {
+		return c.postInput(ctx, base+"/input/"+url.PathEscape(input.ID)+"/proceedEmpty", nil)
+	}
+
+	type parameter struct {
+		Name  string      `json:"name"`
+		Value interface{} `json:"value"`
+	}
+	var submitted struct {
+		Parameter []parameter `json:"parameter"`
+	}
+	var secrets []string
+	for _, p := range input.Inputs {
+		var value interface{} = values[p.Name]
+		switch p.Kind() {
+		case models.InputBoolean:
+			value = values[p.Name] == "true"
+		case models.InputPassword:
+			secrets = append(secrets, p.Name)
+		}
+		submitted.Parameter = append(submitted.Parameter, parameter{Name: p.Name, Value: value})
+	}
+	payload, err := json.Marshal(submitted)
+	if err != nil {
+		return fmt.Errorf("error encoding input values: %w", err)
+	}
+
+	// The audit log records the values from the form body, passwords redacted
+	form := url.Values{"json": {string(payload)}}
+	return c.postInput(ctx, base+"/wfapi/inputSubmit?inputId="+url.QueryEscape(input.ID), form, withSecrets(secrets...))
+}
+
+// AbortInput rejects an input step, which aborts the build
+func (c *Client) AbortInput(ctx context.Context, jobName string, buildNumber int, inputID string) error {
+	path := "/job/" + encodeJobPath(jobName) + "/" + itoa(buildNumber) + "/input/" + url.PathEscape(inputID) + "/abort"
+	return c.postInput(ctx, path, nil)
+}
+
+// postInput posts an input decision. A 403 usually means the user is not
+// among the step's submitters, so no permission is marked as denied.
+func (c *Client) postInput(ctx context.Context, path string, form url.Values, opts ...requestOption) error {
+	var body io.Reader
+	if form != nil {
+		body = strings.NewReader(form.Encode())
+	}
+	resp, err := c.doRequest(ctx, http.MethodPost, path, body, opts...)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Jenkins answers with a redirect back to the build page
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("unexpected status %d answering input", resp.StatusCode)
+	}
+	return nil
+}
+
+// ParseBuildURL returns the job name and build number of a build URL on
+// this server, or an empty name when the URL is not a build
+func (c *Client) ParseBuildURL(rawURL string) (string, int) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", 0
+	}
+	path := u.Path
+	if base, err := url.Parse(c.baseURL); err == nil {
+		path = strings.TrimPrefix(path, strings.TrimSuffix(base.Path, "/"))
+	}
+	job, build, action, _ := describeRequest(path)
+	if action != "" || build == 0 {
+		return "", 0
+	}
+	return job, build
+}
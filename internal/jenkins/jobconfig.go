@@ -0,0 +1,59 @@
+package jenkins
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// jobConfigLimit bounds the size of a job's config.xml
+const jobConfigLimit = 10 * 1024 * 1024
+
+// GetJobConfig fetches the config.xml of a job. Reading it requires the
+// Job/ExtendedRead or Job/Configure permission.
+func (c *Client) GetJobConfig(ctx context.Context, jobName string) (string, error) {
+	resp, err := c.doRequest(ctx, http.MethodGet, "/job/"+encodeJobPath(jobName)+"/config.xml", nil)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return "", ErrNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return "", fmt.Errorf("unexpected status %d: %s", resp.StatusCode, string(body))
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, jobConfigLimit))
+	if err != nil {
+		return "", fmt.Errorf("error reading response: %w", err)
+	}
+	return string(body), nil
+}
+
+// UpdateJobConfig replaces the config.xml of a job. Jenkins validates the XML
+// and answers 500 when it cannot be loaded, leaving the job unchanged.
+func (c *Client) UpdateJobConfig(ctx context.Context, jobName, configXML string) error {
+	resp, err := c.doRequest(ctx, http.MethodPost, "/job/"+encodeJobPath(jobName)+"/config.xml", strings.NewReader(configXML), withContentType("application/xml; charset=utf-8"))
+	if err != nil {
+		if errors.Is(err, ErrForbidden) {
+			c.denyPermission(PermConfigure, jobName, 0)
+		}
+		return err
+	}
+	defer resp.Body.Close()
+
+	// The error page of a rejected configuration is HTML with a stack trace
+	if resp.StatusCode == http.StatusInternalServerError {
+		return fmt.Errorf("jenkins rejected the configuration (status %d): check the XML", resp.StatusCode)
+	}
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("unexpected status %d saving config.xml", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package jenkins
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// EnableJob allows a disabled job to build again
+func (c *Client) EnableJob(ctx context.Context, jobName string) error {
+	return c.postJobAction(ctx, jobName, "enable", PermConfigure)
+}
+
+// DisableJob stops a job from building until it is enabled again
+func (c *Client) DisableJob(ctx context.Context, jobName string) error {
+	return c.postJobAction(ctx, jobName, "disable", PermConfigure)
+}
+
+// DeleteJob deletes a job with all its builds
+func (c *Client) DeleteJob(ctx context.Context, jobName string) error {
+	return c.postJobAction(ctx, jobName, "doDelete", PermDelete)
+}
+
+// CopyJob creates newName next to jobName with a copy of its configuration.
+// Jenkins holds off builds of a copied job until its configuration is saved
+// once, so the copied config.xml is posted back to make it buildable.
+func (c *Client) CopyJob(ctx context.Context, jobName, newName string) error {
+	parent, source := splitJobName(jobName)
+	path := "/createItem?"
+	if parent != "" {
+		path = "/job/" + encodeJobPath(parent) + path
+	}
+	query := url.Values{"name": {newName}, "mode": {"copy"}, "from": {source}}
+	resp, err := c.doRequest(ctx, http.MethodPost, path+query.Encode(), nil)
+	if err != nil {
+		if errors.Is(err, ErrForbidden) {
+			c.denyPermission(PermCreate, parent, 0)
+		}
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Jenkins answers 400 when the name is taken or invalid
+	if resp.StatusCode == http.StatusBadRequest {
+		return fmt.Errorf("jenkins refused the name %q: it may already exist", newName)
+	}
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("unexpected status %d copying job", resp.StatusCode)
+	}
+
+	copied := newName
+	if parent != "" {
+		copied = parent + "/" + newName
+	}
+	configXML, err := c.GetJobConfig(ctx, copied)
+	if err == nil {
+		err = c.UpdateJobConfig(ctx, copied, configXML)
+	}
+	if err != nil {
+		return fmt.Errorf("job copied, but it may not build until saved once: %w", err)
+	}
+	return nil
+}
+
+// postJobAction posts to an action URL of a job; Jenkins answers these with
+// a redirect
+func (c *Client) postJobAction(ctx context.Context, jobName, action string, perm Permission) error {
+	resp, err := c.doRequest(ctx, http.MethodPost, "/job/"+encodeJobPath(jobName)+"/"+action, nil)
+	if err != nil {
+		if errors.Is(err, ErrForbidden) {
+			c.denyPermission(perm, jobName, 0)
+		}
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("job %s %w", jobName, ErrNotFound)
+	}
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("unexpected status %d on %s", resp.StatusCode, action)
+	}
+	return nil
+}
+
+// splitJobName splits a full job name into its parent folder and short name
+func splitJobName(jobName string) (parent, name string) {
+	i := strings.LastIndex(jobName, "/")
+	if i < 0 {
+		return "", jobName
+	}
+	return jobName[:i], jobName[i+1:]
+}
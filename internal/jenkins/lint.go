@@ -0,0 +1,80 @@
+package jenkins
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/elogrono/jenkins-tui/internal/jenkins/models"
+)
+
+// Lines of the linter output: a positioned compilation error and the count
+var (
+	lintErrorPattern = regexp.MustCompile(`^WorkflowScript: \d+: (.*) @ line (\d+), column (\d+)\.$`)
+	lintCountPattern = regexp.MustCompile(`^\d+ errors?$`)
+)
+
+// Lint validates a declarative Jenkinsfile with the controller's Pipeline
+// Model Definition plugin. Linting changes nothing, so it is allowed on
+// read-only profiles; like every POST, it is audited.
+func (c *Client) Lint(ctx context.Context, jenkinsfile string) (*models.LintResult, error) {
+	form := url.Values{"jenkinsfile": {jenkinsfile}}
+	resp, err := c.doRequest(ctx, http.MethodPost, "/pipeline-model-converter/validate", strings.NewReader(form.Encode()), readOnlySafe)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("linter not available: is the Pipeline Model Definition plugin installed?")
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1024*1024))
+	if err != nil {
+		return nil, fmt.Errorf("error reading response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, string(body))
+	}
+	return parseLintOutput(string(body)), nil
+}
+
+// parseLintOutput turns the linter's text answer into a result. Errors
+// without a position, e.g. a missing pipeline block, keep Line at zero.
+func parseLintOutput(output string) *models.LintResult {
+	result := &models.LintResult{Output: output}
+	if strings.Contains(output, "successfully validated") {
+		result.Valid = true
+		return result
+	}
+
+	var unplaced []models.LintError
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if m := lintErrorPattern.FindStringSubmatch(line); m != nil {
+			ln, _ := strconv.Atoi(m[2])
+			col, _ := strconv.Atoi(m[3])
+			result.Errors = append(result.Errors, models.LintError{Line: ln, Column: col, Message: m[1]})
+			continue
+		}
+		// Anything else is either a source excerpt, a caret, the header or
+		// the error count, unless it is a bare message
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(line, " ") || strings.HasPrefix(trimmed, "Errors encountered") ||
+			lintCountPattern.MatchString(trimmed) {
+			continue
+		}
+		unplaced = append(unplaced, models.LintError{Message: trimmed})
+	}
+	if len(result.Errors) == 0 {
+		result.Errors = unplaced
+	}
+	if len(result.Errors) == 0 {
+		result.Errors = []models.LintError{{Message: "linter returned no verdict"}}
+	}
+	return result
+}
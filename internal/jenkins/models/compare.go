@@ -0,0 +1,208 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// BUILD COMPARISON
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// BuildComparison describes what changed between a base build and a target build
+type BuildComparison struct {
+	Base   *Build
+	Target *Build
+
+	Parameters   []ParameterChange // Only parameters whose value differs
+	BaseCauses   []string
+	TargetCauses []string
+	Commits      []ChangeItem  // Commits after Base up to and including Target, oldest first
+	Stages       []StageChange // Stages in target order, then base-only stages
+	Artifacts    []ArtifactChange
+}
+
+// ParameterChange is a parameter whose value differs between the builds
+type ParameterChange struct {
+	Name     string
+	Base     string
+	Target   string
+	InBase   bool
+	InTarget bool
+}
+
+// StageChange pairs a stage of the base build with the same stage of the target
+type StageChange struct {
+	Name           string
+	BaseStatus     string // Empty when the stage did not run in the base build
+	TargetStatus   string // Empty when the stage did not run in the target build
+	BaseDuration   int64  // Milliseconds
+	TargetDuration int64
+}
+
+// Changed reports whether the stage result differs between the builds
+func (s StageChange) Changed() bool {
+	return s.BaseStatus != s.TargetStatus
+}
+
+// ArtifactChange is an artifact path present in one or both builds
+type ArtifactChange struct {
+	Path     string
+	InBase   bool
+	InTarget bool
+}
+
+// CompareBuilds compares base with target. between holds the builds after
+// base up to target (in any order) and supplies the commits in between;
+// target itself is included if missing. Stage runs may be nil.
+func CompareBuilds(base, target *Build, baseRun, targetRun *PipelineRun, between []Build) BuildComparison {
+	c := BuildComparison{Base: base, Target: target}
+
+	c.Parameters = compareParameters(base.GetParameters(), target.GetParameters())
+	c.BaseCauses = causeTexts(base.GetCauses())
+	c.TargetCauses = causeTexts(target.GetCauses())
+	c.Commits = commitsBetween(base, target, between)
+	c.Stages = compareStages(baseRun, targetRun)
+	c.Artifacts = compareArtifacts(base.Artifacts, target.Artifacts)
+	return c
+}
+
+// compareParameters returns the parameters whose value or presence differs
+func compareParameters(base, target []Parameter) []ParameterChange {
+	values := func(params []Parameter) map[string]string {
+		m := make(map[string]string, len(params))
+		for _, p := range params {
+			m[p.Name] = fmt.Sprintf("%v", p.Value)
+		}
+		return m
+	}
+	baseValues, targetValues := values(base), values(target)
+
+	names := make(map[string]bool)
+	for name := range baseValues {
+		names[name] = true
+	}
+	for name := range targetValues {
+		names[name] = true
+	}
+
+	var changes []ParameterChange
+	for name := range names {
+		b, inBase := baseValues[name]
+		t, inTarget := targetValues[name]
+		if inBase == inTarget && b == t {
+			continue
+		}
+		changes = append(changes, ParameterChange{Name: name, Base: b, Target: t, InBase: inBase, InTarget: inTarget})
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Name < changes[j].Name })
+	return changes
+}
+
+// causeTexts renders build causes as one line each
+func causeTexts(causes []BuildCause) []string {
+	var texts []string
+	for _, cause := range causes {
+		text := cause.ShortDescription
+		if cause.UserName != "" && cause.UserName != text {
+			text = cause.UserName + " - " + text
+		}
+		texts = append(texts, text)
+	}
+	return texts
+}
+
+// commitsBetween collects the change sets of the builds after base up to target
+func commitsBetween(base, target *Build, between []Build) []ChangeItem {
+	builds := append([]Build(nil), between...)
+	found := false
+	for _, b := range builds {
+		if b.Number == target.Number {
+			found = true
+			break
+		}
+	}
+	if !found {
+		builds = append(builds, *target)
+	}
+	sort.Slice(builds, func(i, j int) bool { return builds[i].Number < builds[j].Number })
+
+	var commits []ChangeItem
+	seen := make(map[string]bool)
+	for _, b := range builds {
+		if b.Number <= base.Number || b.Number > target.Number {
+			continue
+		}
+		for _, cs := range b.ChangeSets {
+			for _, item := range cs.Items {
+				if item.CommitID != "" {
+					if seen[item.CommitID] {
+						continue
+					}
+					seen[item.CommitID] = true
+				}
+				commits = append(commits, item)
+			}
+		}
+	}
+	return commits
+}
+
+// compareStages pairs stages by name
+func compareStages(baseRun, targetRun *PipelineRun) []StageChange {
+	var baseStages, targetStages []Stage
+	if baseRun != nil {
+		baseStages = baseRun.Stages
+	}
+	if targetRun != nil {
+		targetStages = targetRun.Stages
+	}
+
+	index := make(map[string]int)
+	var changes []StageChange
+	for _, s := range targetStages {
+		index[s.Name] = len(changes)
+		changes = append(changes, StageChange{Name: s.Name, TargetStatus: s.Status, TargetDuration: s.DurationMillis})
+	}
+	for _, s := range baseStages {
+		if i, ok := index[s.Name]; ok {
+			changes[i].BaseStatus = s.Status
+			changes[i].BaseDuration = s.DurationMillis
+			continue
+		}
+		changes = append(changes, StageChange{Name: s.Name, BaseStatus: s.Status, BaseDuration: s.DurationMillis})
+	}
+	return changes
+}
+
+// compareArtifacts lists the artifact paths of both builds, sorted
+func compareArtifacts(base, target []Artifact) []ArtifactChange {
+	byPath := make(map[string]*ArtifactChange)
+	var changes []*ArtifactChange
+	get := func(a Artifact) *ArtifactChange {
+		path := a.RelativePath
+		if path == "" {
+			path = a.FileName
+		}
+		if c, ok := byPath[path]; ok {
+			return c
+		}
+		c := &ArtifactChange{Path: path}
+		byPath[path] = c
+		changes = append(changes, c)
+		return c
+	}
+	for _, a := range base {
+		get(a).InBase = true
+	}
+	for _, a := range target {
+		get(a).InTarget = true
+	}
+
+	out := make([]ArtifactChange, len(changes))
+	for i, c := range changes {
+		out[i] = *c
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Path < out[j].Path })
+	return out
+}
@@ -0,0 +1,117 @@
+package models
+
+import "sort"
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// FLAKY TEST DETECTION
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// BuildTestReport is the test report of one build of a job
+type BuildTestReport struct {
+	Build  int
+	Result string
+	Report *TestReport // nil when the build has no test results
+}
+
+// TestOutcome is the result of a test in one build
+type TestOutcome struct {
+	Build  int
+	Failed bool
+}
+
+// FlakyTest summarises how often a test alternated between passing and failing
+type FlakyTest struct {
+	ClassName string
+	Name      string
+	Runs      int           // Builds in which the test ran (skips excluded)
+	Failures  int           // Builds in which the test failed
+	Flips     int           // Pass/fail transitions between consecutive runs
+	FlipRate  float64       // Flips / (Runs - 1)
+	History   []TestOutcome // Oldest first
+}
+
+// FullName returns the class and test name
+func (f FlakyTest) FullName() string {
+	return TestCase{ClassName: f.ClassName, Name: f.Name}.FullName()
+}
+
+// FailedBuilds returns the builds in which the test failed, newest first
+func (f FlakyTest) FailedBuilds() []int {
+	var builds []int
+	for i := len(f.History) - 1; i >= 0; i-- {
+		if f.History[i].Failed {
+			builds = append(builds, f.History[i].Build)
+		}
+	}
+	return builds
+}
+
+// DetectFlakyTests ranks tests that both passed and failed across the given
+// reports by flip rate, then by number of failures. Reports may be in any order.
+func DetectFlakyTests(reports []BuildTestReport) []FlakyTest {
+	sorted := append([]BuildTestReport(nil), reports...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Build < sorted[j].Build })
+
+	byName := make(map[string]*FlakyTest)
+	var order []string
+	for _, r := range sorted {
+		if r.Report == nil {
+			continue
+		}
+		for _, suite := range r.Report.Suites {
+			for _, c := range suite.Cases {
+				if c.IsSkipped() {
+					continue
+				}
+				key := c.FullName()
+				t, ok := byName[key]
+				if !ok {
+					t = &FlakyTest{ClassName: c.ClassName, Name: c.Name}
+					byName[key] = t
+					order = append(order, key)
+				}
+				failed := c.IsFailure()
+				if n := len(t.History); n > 0 && t.History[n-1].Failed != failed {
+					t.Flips++
+				}
+				t.History = append(t.History, TestOutcome{Build: r.Build, Failed: failed})
+				t.Runs++
+				if failed {
+					t.Failures++
+				}
+			}
+		}
+	}
+
+	var flaky []FlakyTest
+	for _, key := range order {
+		t := byName[key]
+		if t.Failures == 0 || t.Failures == t.Runs {
+			continue
+		}
+		t.FlipRate = float64(t.Flips) / float64(t.Runs-1)
+		flaky = append(flaky, *t)
+	}
+	sort.SliceStable(flaky, func(i, j int) bool {
+		if flaky[i].FlipRate != flaky[j].FlipRate {
+			return flaky[i].FlipRate > flaky[j].FlipRate
+		}
+		if flaky[i].Failures != flaky[j].Failures {
+			return flaky[i].Failures > flaky[j].Failures
+		}
+		return flaky[i].FullName() < flaky[j].FullName()
+	})
+	return flaky
+}
+
+// FindTestCase returns the case with the given full name, or nil
+func (r *TestReport) FindTestCase(fullName string) *TestCase {
+	for i := range r.Suites {
+		for j := range r.Suites[i].Cases {
+			if r.Suites[i].Cases[j].FullName() == fullName {
+				return &r.Suites[i].Cases[j]
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// PIPELINE INPUT STEPS
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// PendingInput is an input step a pipeline is paused on
+// Endpoint: /job/{jobName}/{build}/wfapi/pendingInputActions
+type PendingInput struct {
+	ID          string       `json:"id"`
+	Message     string       `json:"message"`
+	ProceedText string       `json:"proceedText"`
+	ProceedURL  string       `json:"proceedUrl,omitempty"`
+	AbortURL    string       `json:"abortUrl,omitempty"`
+	Inputs      []InputParam `json:"inputs,omitempty"`
+}
+
+// InputParam is a parameter the input step asks for
+type InputParam struct {
+	Type        string                 `json:"type"` // e.g. BooleanParameterDefinition
+	Name        string                 `json:"name"`
+	Description string                 `json:"description,omitempty"`
+	Definition  map[string]interface{} `json:"definition,omitempty"`
+}
+
+// Input parameter kinds, derived from the parameter definition type
+const (
+	InputString   = "string"
+	InputText     = "text"
+	InputPassword = "password"
+	InputBoolean  = "boolean"
+	InputChoice   = "choice"
+)
+
+// Kind returns how the parameter is edited; unknown types are edited as strings
+func (p InputParam) Kind() string {
+	t := strings.ToLower(p.Type)
+	switch {
+	case strings.Contains(t, "boolean"):
+		return InputBoolean
+	case strings.Contains(t, "choice"):
+		return InputChoice
+	case strings.Contains(t, "password"):
+		return InputPassword
+	case strings.HasPrefix(t, "text"):
+		return InputText
+	}
+	return InputString
+}
+
+// Choices returns the options of a choice parameter
+func (p InputParam) Choices() []string {
+	raw, _ := p.Definition["choices"].([]interface{})
+	choices := make([]string, 0, len(raw))
+	for _, c := range raw {
+		choices = append(choices, fmt.Sprint(c))
+	}
+	return choices
+}
+
+// Default returns the default value of the parameter as text. Choice
+// parameters default to their first option.
+func (p InputParam) Default() string {
+	var value interface{}
+	if def, ok := p.Definition["defaultParameterValue"].(map[string]interface{}); ok {
+		value = def["value"]
+	}
+	if value == nil {
+		value = p.Definition["defaultVal"]
+	}
+	if value == nil && p.Kind() == InputChoice {
+		if choices := p.Choices(); len(choices) > 0 {
+			return choices[0]
+		}
+	}
+	if value == nil {
+		if p.Kind() == InputBoolean {
+			return "false"
+		}
+		return ""
+	}
+	return fmt.Sprint(value)
+}
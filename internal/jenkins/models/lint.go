@@ -0,0 +1,31 @@
+package models
+
+import "fmt"
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// DECLARATIVE PIPELINE LINTER
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// LintResult is the verdict of the declarative Pipeline linter
+// Endpoint: /pipeline-model-converter/validate (plain text)
+type LintResult struct {
+	Valid  bool
+	Errors []LintError
+	Output string // Linter response as returned by Jenkins
+}
+
+// LintError is a problem found in a Jenkinsfile. Line and Column are 1-based
+// and zero when the linter gave no position.
+type LintError struct {
+	Line    int
+	Column  int
+	Message string
+}
+
+// Position returns "line:column", or an empty string when unknown
+func (e LintError) Position() string {
+	if e.Line == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d:%d", e.Line, e.Column)
+}
@@ -23,6 +23,34 @@ type RootInfo struct {
 	URL             string `json:"url,omitempty"`
 }
 
+// ═══════════════════════════════════════════════════════════════════════════════
+// IDENTITY
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// User represents the authenticated user from /me/api/json
+type User struct {
+	ID          string `json:"id"`
+	FullName    string `json:"fullName"`
+	AbsoluteURL string `json:"absoluteUrl,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// WhoAmI represents the response of /whoAmI/api/json
+type WhoAmI struct {
+	Name          string   `json:"name"`
+	Anonymous     bool     `json:"anonymous"`
+	Authenticated bool     `json:"authenticated"`
+	Authorities   []string `json:"authorities"`
+}
+
+// DisplayName returns the best available name for the user
+func (u *User) DisplayName() string {
+	if u.FullName != "" {
+		return u.FullName
+	}
+	return u.ID
+}
+
 // ═══════════════════════════════════════════════════════════════════════════════
 // VIEWS
 // ═══════════════════════════════════════════════════════════════════════════════
@@ -114,6 +142,7 @@ type BuildRef struct {
 	Duration  int64   `json:"duration,omitempty"`
 	URL       string  `json:"url,omitempty"`
 	Building  bool    `json:"building,omitempty"`
+	BuiltOn   string  `json:"builtOn,omitempty"` // Agent the build ran on, empty for the built-in node and pipelines
 	Stages    []Stage `json:"stages,omitempty"`
 }
 
@@ -234,6 +263,7 @@ type WFAPIStage struct {
 	StartTimeMillis     int64                `json:"startTimeMillis"`
 	DurationMillis      int64                `json:"durationMillis"`
 	PauseDurationMillis int64                `json:"pauseDurationMillis"`
+	ParentNodes         []string             `json:"parentNodes"` // Only in the stage's own description
 	StageFlowNodes      []WFAPIFlowNode      `json:"stageFlowNodes"`
 }
 
@@ -282,6 +312,41 @@ type Stage struct {
 	PauseDurationMillis int64  `json:"pauseDurationMillis,omitempty"`
 	ExecNode            string `json:"execNode,omitempty"`
 	Type                string `json:"type,omitempty"`
+	ParentID            string `json:"parentId,omitempty"` // Stage whose parallel block contains this one
+}
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// PIPELINE NODES (Blue Ocean)
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// BlueOceanNode represents a stage or parallel branch from the Blue Ocean API
+type BlueOceanNode struct {
+	ID               string          `json:"id"`
+	DisplayName      string          `json:"displayName"`
+	Type             string          `json:"type"` // STAGE or PARALLEL
+	Result           string          `json:"result"`
+	State            string          `json:"state"`
+	StartTime        string          `json:"startTime"`
+	DurationInMillis int64           `json:"durationInMillis"`
+	FirstParent      string          `json:"firstParent"`
+	Edges            []BlueOceanEdge `json:"edges"`
+}
+
+// BlueOceanEdge links a node to the node(s) that follow it
+type BlueOceanEdge struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+}
+
+// BlueOceanStep represents a step inside a Blue Ocean node
+type BlueOceanStep struct {
+	ID                 string `json:"id"`
+	DisplayName        string `json:"displayName"`
+	DisplayDescription string `json:"displayDescription"`
+	Result             string `json:"result"`
+	State              string `json:"state"`
+	StartTime          string `json:"startTime"`
+	DurationInMillis   int64  `json:"durationInMillis"`
 }
 
 // StageLog represents the log for a stage
@@ -352,6 +417,7 @@ type Node struct {
 	TemporarilyOffline  bool                   `json:"temporarilyOffline"`
 	NumExecutors        int                    `json:"numExecutors"`
 	Executors           []Executor             `json:"executors"`
+	OneOffExecutors     []Executor             `json:"oneOffExecutors,omitempty"` // Flyweight executors running pipelines
 	AssignedLabels      []Label                `json:"assignedLabels"`
 	OfflineCauseReason  string                 `json:"offlineCauseReason"`
 	Idle                bool                   `json:"idle"`
@@ -544,3 +610,70 @@ func SortStagesByStartTime(stages []Stage) {
 		return stages[i].StartTimeMillis < stages[j].StartTimeMillis
 	})
 }
+
+// StageColumns lays stages out as graph columns. A top-level stage gets a
+// column of its own, followed by a column with the branches of its parallel
+// block (if any). Deeper nested stages are folded into their top-level
+// ancestor's branch column.
+func StageColumns(stages []Stage) [][]Stage {
+	byID := make(map[string]int, len(stages))
+	for i, stage := range stages {
+		byID[stage.ID] = i
+	}
+
+	// Resolve the top-level ancestor of every nested stage
+	rootOf := func(stage Stage) string {
+		seen := map[string]bool{}
+		for stage.ParentID != "" && !seen[stage.ID] {
+			seen[stage.ID] = true
+			idx, ok := byID[stage.ParentID]
+			if !ok {
+				break
+			}
+			stage = stages[idx]
+		}
+		return stage.ID
+	}
+
+	branches := make(map[string][]Stage)
+	var roots []Stage
+	for _, stage := range stages {
+		if stage.ParentID == "" {
+			roots = append(roots, stage)
+			continue
+		}
+		if _, ok := byID[stage.ParentID]; !ok {
+			// Parent not part of the run; show the stage at top level
+			roots = append(roots, stage)
+			continue
+		}
+		root := rootOf(stage)
+		branches[root] = append(branches[root], stage)
+	}
+
+	columns := make([][]Stage, 0, len(roots))
+	for _, root := range roots {
+		columns = append(columns, []Stage{root})
+		if children := branches[root.ID]; len(children) > 0 {
+			columns = append(columns, children)
+		}
+	}
+	return columns
+}
+
+// CriticalPath returns the IDs of the stages that determine the run's total
+// duration: every sequential stage plus the last branch to finish in each
+// parallel block
+func CriticalPath(stages []Stage) map[string]bool {
+	critical := make(map[string]bool)
+	for _, column := range StageColumns(stages) {
+		last := column[0]
+		for _, stage := range column[1:] {
+			if stage.StartTimeMillis+stage.DurationMillis > last.StartTimeMillis+last.DurationMillis {
+				last = stage
+			}
+		}
+		critical[last.ID] = true
+	}
+	return critical
+}
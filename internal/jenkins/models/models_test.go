@@ -2,7 +2,9 @@ package models
 
 import (
 	"encoding/json"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestJobIsRunning(t *testing.T) {
@@ -334,3 +336,226 @@ func TestViewParsing(t *testing.T) {
 		t.Errorf("unexpected URL: %s", view.URL)
 	}
 }
+
+func TestStageColumns(t *testing.T) {
+	stages := []Stage{
+		{ID: "1", Name: "Build"},
+		{ID: "2", Name: "Tests"},
+		{ID: "3", Name: "Unit", ParentID: "2"},
+		{ID: "4", Name: "Integration", ParentID: "2"},
+		{ID: "5", Name: "DB", ParentID: "4"},
+		{ID: "6", Name: "Deploy"},
+	}
+
+	columns := StageColumns(stages)
+
+	var got []string
+	for _, column := range columns {
+		var names []string
+		for _, stage := range column {
+			names = append(names, stage.Name)
+		}
+		got = append(got, strings.Join(names, "|"))
+	}
+
+	expected := []string{"Build", "Tests", "Unit|Integration|DB", "Deploy"}
+	if strings.Join(got, ",") != strings.Join(expected, ",") {
+		t.Errorf("StageColumns() = %v, want %v", got, expected)
+	}
+}
+
+func TestCriticalPath(t *testing.T) {
+	stages := []Stage{
+		{ID: "1", Name: "Build", StartTimeMillis: 0, DurationMillis: 100},
+		{ID: "2", Name: "Tests", StartTimeMillis: 100, DurationMillis: 500},
+		{ID: "3", Name: "Unit", ParentID: "2", StartTimeMillis: 110, DurationMillis: 100},
+		{ID: "4", Name: "Integration", ParentID: "2", StartTimeMillis: 110, DurationMillis: 480},
+		{ID: "5", Name: "Deploy", StartTimeMillis: 600, DurationMillis: 50},
+	}
+
+	critical := CriticalPath(stages)
+
+	for _, id := range []string{"1", "2", "4", "5"} {
+		if !critical[id] {
+			t.Errorf("expected stage %s on the critical path", id)
+		}
+	}
+	if critical["3"] {
+		t.Error("expected the shorter parallel branch off the critical path")
+	}
+}
+
+func TestComputeBuildStats(t *testing.T) {
+	builds := []BuildRef{
+		{Number: 6, Building: true},
+		{Number: 5, Result: "SUCCESS", Duration: 50000, Stages: []Stage{{Name: "Build", DurationMillis: 20000}, {Name: "Test", DurationMillis: 30000}}},
+		{Number: 4, Result: "FAILURE", Duration: 40000, Stages: []Stage{{Name: "Build", DurationMillis: 40000}}},
+		{Number: 3, Result: "SUCCESS", Duration: 30000},
+		{Number: 2, Result: "SUCCESS", Duration: 20000},
+		{Number: 1, Result: "ABORTED", Duration: 10000},
+	}
+
+	stats := ComputeBuildStats(builds, 4, 2)
+
+	if len(stats.Builds) != 4 || stats.Builds[0].Number != 2 || stats.Builds[3].Number != 5 {
+		t.Fatalf("expected builds #2-#5 oldest first, got %+v", stats.Builds)
+	}
+	if stats.Successes != 3 || stats.SuccessRate != 0.75 {
+		t.Errorf("expected 3/4 successes, got %d (%v)", stats.Successes, stats.SuccessRate)
+	}
+	want := []float64{1, 1, 0.5, 0.5}
+	for i, rate := range want {
+		if i >= len(stats.RollingSuccess) || stats.RollingSuccess[i] != rate {
+			t.Errorf("expected rolling success %v, got %v", want, stats.RollingSuccess)
+			break
+		}
+	}
+	if stats.MeanDuration != 35*time.Second || stats.P95Duration != 50*time.Second ||
+		stats.MinDuration != 20*time.Second || stats.MaxDuration != 50*time.Second {
+		t.Errorf("unexpected durations: mean %v p95 %v min %v max %v",
+			stats.MeanDuration, stats.P95Duration, stats.MinDuration, stats.MaxDuration)
+	}
+
+	if len(stats.StageTrends) != 2 {
+		t.Fatalf("expected 2 stage trends, got %d", len(stats.StageTrends))
+	}
+	build := stats.StageTrends[0]
+	if build.Name != "Build" || build.Mean != 30*time.Second || build.Last != 20*time.Second {
+		t.Errorf("unexpected Build trend: %+v", build)
+	}
+	test := stats.StageTrends[1]
+	if test.Name != "Test" || len(test.Durations) != 2 || test.Durations[0] != 0 || test.Mean != 30*time.Second {
+		t.Errorf("unexpected Test trend: %+v", test)
+	}
+}
+
+func TestComputeBuildStatsEmpty(t *testing.T) {
+	stats := ComputeBuildStats([]BuildRef{{Number: 1, Building: true}}, 10, 5)
+	if len(stats.Builds) != 0 || stats.SuccessRate != 0 || stats.StageTrends != nil {
+		t.Errorf("expected empty stats, got %+v", stats)
+	}
+}
+
+func TestCompareBuilds(t *testing.T) {
+	params := func(kv ...string) []Action {
+		var p []Parameter
+		for i := 0; i < len(kv); i += 2 {
+			p = append(p, Parameter{Name: kv[i], Value: kv[i+1]})
+		}
+		return []Action{{Parameters: p}}
+	}
+	commit := func(id string) ChangeSet {
+		return ChangeSet{Items: []ChangeItem{{CommitID: id, Msg: "commit " + id}}}
+	}
+
+	base := &Build{Number: 10, Actions: params("ENV", "staging", "DEBUG", "false"),
+		Artifacts: []Artifact{{RelativePath: "a.jar"}, {RelativePath: "old.txt"}}}
+	target := &Build{Number: 13, Actions: params("ENV", "prod", "DEBUG", "false", "NEW", "x"),
+		ChangeSets: []ChangeSet{commit("c13")},
+		Artifacts:  []Artifact{{RelativePath: "a.jar"}, {RelativePath: "new.txt"}}}
+	between := []Build{
+		{Number: 12, ChangeSets: []ChangeSet{commit("c12")}},
+		{Number: 11, ChangeSets: []ChangeSet{commit("c11"), commit("c12")}},
+		{Number: 10, ChangeSets: []ChangeSet{commit("c10")}},
+	}
+	baseRun := &PipelineRun{Stages: []Stage{{Name: "Build", Status: "SUCCESS"}, {Name: "Legacy", Status: "SUCCESS"}}}
+	targetRun := &PipelineRun{Stages: []Stage{{Name: "Build", Status: "SUCCESS"}, {Name: "Test", Status: "FAILED"}}}
+
+	c := CompareBuilds(base, target, baseRun, targetRun, between)
+
+	if len(c.Parameters) != 2 || c.Parameters[0].Name != "ENV" || c.Parameters[1].Name != "NEW" || c.Parameters[1].InBase {
+		t.Errorf("unexpected parameter changes: %+v", c.Parameters)
+	}
+
+	var ids []string
+	for _, item := range c.Commits {
+		ids = append(ids, item.CommitID)
+	}
+	if strings.Join(ids, ",") != "c11,c12,c13" {
+		t.Errorf("expected commits c11,c12,c13, got %v", ids)
+	}
+
+	if len(c.Stages) != 3 || c.Stages[0].Changed() || !c.Stages[1].Changed() || c.Stages[2].Name != "Legacy" || c.Stages[2].TargetStatus != "" {
+		t.Errorf("unexpected stage changes: %+v", c.Stages)
+	}
+
+	if len(c.Artifacts) != 3 || !c.Artifacts[1].InTarget || c.Artifacts[1].InBase || c.Artifacts[2].InTarget {
+		t.Errorf("unexpected artifact changes: %+v", c.Artifacts)
+	}
+}
+
+func TestDetectFlakyTests(t *testing.T) {
+	report := func(outcomes map[string]string) *TestReport {
+		suite := TestSuite{Name: "app.Suite"}
+		for name, status := range outcomes {
+			suite.Cases = append(suite.Cases, TestCase{ClassName: "app.Suite", Name: name, Status: status})
+		}
+		return &TestReport{Suites: []TestSuite{suite}}
+	}
+
+	history := []BuildTestReport{
+		{Build: 4, Report: report(map[string]string{"flaky": "FAILED", "stable": "PASSED", "broken": "FAILED", "once": "PASSED"})},
+		{Build: 1, Report: report(map[string]string{"flaky": "PASSED", "stable": "PASSED", "broken": "FAILED", "once": "PASSED"})},
+		{Build: 3, Report: report(map[string]string{"flaky": "PASSED", "stable": "PASSED", "broken": "FAILED", "once": "REGRESSION"})},
+		{Build: 2, Report: report(map[string]string{"flaky": "FAILED", "stable": "PASSED", "broken": "FAILED", "once": "SKIPPED"})},
+		{Build: 5, Report: nil},
+	}
+
+	flaky := DetectFlakyTests(history)
+
+	if len(flaky) != 2 {
+		t.Fatalf("expected flaky and once, got %+v", flaky)
+	}
+	if flaky[0].Name != "flaky" || flaky[0].Flips != 3 || flaky[0].FlipRate != 1 || flaky[0].Failures != 2 {
+		t.Errorf("unexpected top flaky test: %+v", flaky[0])
+	}
+	if got := flaky[0].FailedBuilds(); len(got) != 2 || got[0] != 4 || got[1] != 2 {
+		t.Errorf("expected failed builds newest first, got %v", got)
+	}
+	// Skipped runs are ignored: PASSED, REGRESSION, PASSED
+	if flaky[1].Name != "once" || flaky[1].Runs != 3 || flaky[1].Flips != 2 {
+		t.Errorf("unexpected second flaky test: %+v", flaky[1])
+	}
+}
+
+func TestPendingInputParams(t *testing.T) {
+	var inputs []PendingInput
+	data := `[{"id": "Deploy", "message": "Deploy to prod?", "proceedText": "Ship it", "inputs": [
+		{"type": "BooleanParameterDefinition", "name": "DRY_RUN", "definition": {"defaultParameterValue": {"name": "DRY_RUN", "value": true}}},
+		{"type": "ChoiceParameterDefinition", "name": "REGION", "definition": {"choices": ["eu-west-1", "us-east-1"]}},
+		{"type": "PasswordParameterDefinition", "name": "TOKEN"},
+		{"type": "TextParameterDefinition", "name": "NOTES", "definition": {"defaultVal": "none"}},
+		{"type": "CredentialsParameterDefinition", "name": "CREDS"}
+	]}]`
+	if err := json.Unmarshal([]byte(data), &inputs); err != nil {
+		t.Fatal(err)
+	}
+
+	params := inputs[0].Inputs
+	expected := []struct{ kind, def string }{
+		{InputBoolean, "true"},
+		{InputChoice, "eu-west-1"},
+		{InputPassword, ""},
+		{InputText, "none"},
+		{InputString, ""},
+	}
+	for i, e := range expected {
+		if params[i].Kind() != e.kind || params[i].Default() != e.def {
+			t.Errorf("%s: expected %s defaulting to %q, got %s %q", params[i].Name, e.kind, e.def, params[i].Kind(), params[i].Default())
+		}
+	}
+	if choices := params[1].Choices(); len(choices) != 2 || choices[1] != "us-east-1" {
+		t.Errorf("unexpected choices %v", choices)
+	}
+}
+
+func TestReplayScriptsChanged(t *testing.T) {
+	original := ReplayScripts{Main: "main", Loaded: []LoadedScript{{Name: "Script1", Script: "a"}, {Name: "Script2", Script: "b"}}}
+	if n := original.Changed(original); n != 0 {
+		t.Errorf("expected no changes, got %d", n)
+	}
+	edited := ReplayScripts{Main: "main v2", Loaded: []LoadedScript{{Name: "Script1", Script: "a"}, {Name: "Script2", Script: "b v2"}}}
+	if n := edited.Changed(original); n != 2 {
+		t.Errorf("expected 2 changed scripts, got %d", n)
+	}
+}
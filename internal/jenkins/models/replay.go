@@ -0,0 +1,36 @@
+package models
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// PIPELINE REPLAY
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// ReplayScripts are the Pipeline scripts a build ran with, as offered for replay
+// Endpoint: /job/{jobName}/{build}/replay/ (HTML form, there is no JSON API)
+type ReplayScripts struct {
+	Main   string
+	Loaded []LoadedScript // Scripts pulled in with load, in page order
+}
+
+// LoadedScript is a script the Pipeline loaded at runtime
+type LoadedScript struct {
+	Name   string // Replay form field: the script class name with dots as underscores
+	Script string
+}
+
+// Changed returns how many scripts differ from the original ones
+func (s ReplayScripts) Changed(original ReplayScripts) int {
+	changed := 0
+	if s.Main != original.Main {
+		changed++
+	}
+	scripts := make(map[string]string, len(original.Loaded))
+	for _, l := range original.Loaded {
+		scripts[l.Name] = l.Script
+	}
+	for _, l := range s.Loaded {
+		if script, ok := scripts[l.Name]; !ok || script != l.Script {
+			changed++
+		}
+	}
+	return changed
+}
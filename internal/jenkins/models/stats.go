@@ -0,0 +1,155 @@
+package models
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// BUILD ANALYTICS
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// BuildStats summarises the recent history of a job
+type BuildStats struct {
+	Builds         []BuildRef      // Completed builds considered, oldest first
+	Durations      []time.Duration // Duration of each build, oldest first
+	Successes      int
+	SuccessRate    float64   // 0..1 over all considered builds
+	RollingSuccess []float64 // Success rate over a sliding window, oldest first
+	MeanDuration   time.Duration
+	P95Duration    time.Duration
+	MinDuration    time.Duration
+	MaxDuration    time.Duration
+	StageTrends    []StageTrend
+}
+
+// StageTrend is the duration history of one stage across builds
+type StageTrend struct {
+	Name      string
+	Durations []time.Duration // Oldest first; zero where the build lacks the stage
+	Mean      time.Duration
+	Last      time.Duration
+}
+
+// ComputeBuildStats computes statistics over the last n completed builds.
+// builds may be in any order; window is the size of the rolling success window.
+func ComputeBuildStats(builds []BuildRef, n, window int) BuildStats {
+	var completed []BuildRef
+	for _, b := range builds {
+		if !b.Building && b.Result != "" {
+			completed = append(completed, b)
+		}
+	}
+	sort.Slice(completed, func(i, j int) bool {
+		return completed[i].Number < completed[j].Number
+	})
+	if n > 0 && len(completed) > n {
+		completed = completed[len(completed)-n:]
+	}
+
+	stats := BuildStats{Builds: completed}
+	if len(completed) == 0 {
+		return stats
+	}
+
+	var total time.Duration
+	for i, b := range completed {
+		d := time.Duration(b.Duration) * time.Millisecond
+		stats.Durations = append(stats.Durations, d)
+		total += d
+		if b.Result == "SUCCESS" {
+			stats.Successes++
+		}
+
+		// Rolling success over the builds ending at i
+		from := 0
+		if window > 0 && i+1 > window {
+			from = i + 1 - window
+		}
+		ok := 0
+		for _, w := range completed[from : i+1] {
+			if w.Result == "SUCCESS" {
+				ok++
+			}
+		}
+		stats.RollingSuccess = append(stats.RollingSuccess, float64(ok)/float64(i+1-from))
+	}
+
+	stats.SuccessRate = float64(stats.Successes) / float64(len(completed))
+	stats.MeanDuration = total / time.Duration(len(completed))
+
+	sorted := append([]time.Duration(nil), stats.Durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	stats.MinDuration = sorted[0]
+	stats.MaxDuration = sorted[len(sorted)-1]
+	stats.P95Duration = percentile(sorted, 0.95)
+
+	stats.StageTrends = stageTrends(completed)
+	return stats
+}
+
+// stageTrends collects per-stage durations for builds that carry stages
+func stageTrends(builds []BuildRef) []StageTrend {
+	var withStages []BuildRef
+	for _, b := range builds {
+		if len(b.Stages) > 0 {
+			withStages = append(withStages, b)
+		}
+	}
+	if len(withStages) == 0 {
+		return nil
+	}
+
+	// Keep stage order of the most recent build, then any older-only stages
+	var names []string
+	seen := map[string]bool{}
+	for i := len(withStages) - 1; i >= 0; i-- {
+		for _, stage := range withStages[i].Stages {
+			if !seen[stage.Name] {
+				seen[stage.Name] = true
+				names = append(names, stage.Name)
+			}
+		}
+	}
+
+	trends := make([]StageTrend, 0, len(names))
+	for _, name := range names {
+		trend := StageTrend{Name: name}
+		var total time.Duration
+		count := 0
+		for _, b := range withStages {
+			var d time.Duration
+			for _, stage := range b.Stages {
+				if stage.Name == name {
+					d = time.Duration(stage.DurationMillis) * time.Millisecond
+					total += d
+					count++
+					break
+				}
+			}
+			trend.Durations = append(trend.Durations, d)
+		}
+		if count > 0 {
+			trend.Mean = total / time.Duration(count)
+		}
+		trend.Last = trend.Durations[len(trend.Durations)-1]
+		trends = append(trends, trend)
+	}
+	return trends
+}
+
+// percentile returns the nearest-rank percentile of sorted durations
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	if rank >= len(sorted) {
+		rank = len(sorted) - 1
+	}
+	return sorted[rank]
+}
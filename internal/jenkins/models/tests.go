@@ -0,0 +1,151 @@
+package models
+
+import (
+	"sort"
+	"strings"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// TEST RESULTS (JUnit testReport API)
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// Test case statuses reported by Jenkins
+const (
+	TestStatusPassed     = "PASSED"
+	TestStatusFixed      = "FIXED"
+	TestStatusFailed     = "FAILED"
+	TestStatusRegression = "REGRESSION"
+	TestStatusSkipped    = "SKIPPED"
+)
+
+// TestReport is the aggregated JUnit report of a build
+type TestReport struct {
+	Duration     float64       `json:"duration"` // Seconds
+	FailCount    int           `json:"failCount"`
+	PassCount    int           `json:"passCount"`
+	SkipCount    int           `json:"skipCount"`
+	Suites       []TestSuite   `json:"suites,omitempty"`
+	ChildReports []ChildReport `json:"childReports,omitempty"` // Matrix and Maven module builds
+}
+
+// ChildReport is the report of one child (module or configuration) build
+type ChildReport struct {
+	Result TestReport `json:"result"`
+}
+
+// TestSuite is a JUnit test suite
+type TestSuite struct {
+	Name     string     `json:"name"`
+	Duration float64    `json:"duration"`
+	Cases    []TestCase `json:"cases,omitempty"`
+}
+
+// TestCase is a single JUnit test case
+type TestCase struct {
+	ClassName       string  `json:"className"`
+	Name            string  `json:"name"`
+	Status          string  `json:"status"`
+	Duration        float64 `json:"duration"`
+	Age             int     `json:"age"` // Consecutive failing builds, 0 when passing
+	FailedSince     int     `json:"failedSince,omitempty"`
+	ErrorDetails    string  `json:"errorDetails,omitempty"`
+	ErrorStackTrace string  `json:"errorStackTrace,omitempty"`
+	Skipped         bool    `json:"skipped,omitempty"`
+	SkippedMessage  string  `json:"skippedMessage,omitempty"`
+	Stdout          string  `json:"stdout,omitempty"`
+	Stderr          string  `json:"stderr,omitempty"`
+}
+
+// IsFailure reports whether the test failed in this build
+func (t TestCase) IsFailure() bool {
+	return t.Status == TestStatusFailed || t.Status == TestStatusRegression
+}
+
+// IsNewFailure reports whether the test failed in this build but not in the previous one
+func (t TestCase) IsNewFailure() bool {
+	return t.IsFailure() && t.Age <= 1
+}
+
+// IsSkipped reports whether the test was skipped
+func (t TestCase) IsSkipped() bool {
+	return t.Skipped || t.Status == TestStatusSkipped
+}
+
+// FullName returns the class and test name
+func (t TestCase) FullName() string {
+	if t.ClassName == "" {
+		return t.Name
+	}
+	return t.ClassName + "." + t.Name
+}
+
+// Total returns the number of tests in the report
+func (r *TestReport) Total() int {
+	return r.FailCount + r.PassCount + r.SkipCount
+}
+
+// Flatten merges the suites of child reports into the top level. Aggregated
+// reports already carry the totals; they are summed only when missing.
+func (r *TestReport) Flatten() {
+	sumCounts := r.Total() == 0
+	for _, child := range r.ChildReports {
+		child.Result.Flatten()
+		if sumCounts {
+			r.Duration += child.Result.Duration
+			r.FailCount += child.Result.FailCount
+			r.PassCount += child.Result.PassCount
+			r.SkipCount += child.Result.SkipCount
+		}
+		r.Suites = append(r.Suites, child.Result.Suites...)
+	}
+	r.ChildReports = nil
+}
+
+// Failures returns the failing test cases, new failures first, then by age
+func (r *TestReport) Failures() []TestCase {
+	var failures []TestCase
+	for _, suite := range r.Suites {
+		for _, c := range suite.Cases {
+			if c.IsFailure() {
+				failures = append(failures, c)
+			}
+		}
+	}
+	sortTestCases(failures)
+	return failures
+}
+
+// sortTestCases orders failures by age (newest first), then by name
+func sortTestCases(cases []TestCase) {
+	sort.SliceStable(cases, func(i, j int) bool {
+		if cases[i].Age != cases[j].Age {
+			return cases[i].Age < cases[j].Age
+		}
+		return cases[i].FullName() < cases[j].FullName()
+	})
+}
+
+// Counts returns the failed, passed and skipped case counts of a suite
+func (s TestSuite) Counts() (failed, passed, skipped int) {
+	for _, c := range s.Cases {
+		switch {
+		case c.IsFailure():
+			failed++
+		case c.IsSkipped():
+			skipped++
+		default:
+			passed++
+		}
+	}
+	return failed, passed, skipped
+}
+
+// Matches reports whether the case name, class or error contains filter
+func (t TestCase) Matches(filter string) bool {
+	if filter == "" {
+		return true
+	}
+	filter = strings.ToLower(filter)
+	return strings.Contains(strings.ToLower(t.FullName()), filter) ||
+		strings.Contains(strings.ToLower(t.ErrorDetails), filter)
+}
@@ -0,0 +1,113 @@
+package jenkins
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/elogrono/jenkins-tui/internal/jenkins/models"
+)
+
+// ErrReplayUnavailable is returned for builds that cannot be replayed, e.g.
+// builds that are not Pipelines or when the user lacks the Run/Replay permission
+var ErrReplayUnavailable = errors.New("build cannot be replayed")
+
+// replayPageLimit bounds the replay form page, which embeds every script
+const replayPageLimit = 10 * 1024 * 1024
+
+// textareaPattern matches the script editors of the replay form
+var textareaPattern = regexp.MustCompile(`(?is)<textarea[^>]*\bname="([^"]+)"[^>]*>(.*?)</textarea>`)
+
+// GetReplayScripts fetches the main script and the loaded scripts a Pipeline
+// build ran with. Jenkins only offers them through the replay form page.
+func (c *Client) GetReplayScripts(ctx context.Context, jobName string, buildNumber int) (*models.ReplayScripts, error) {
+	path := "/job/" + encodeJobPath(jobName) + "/" + itoa(buildNumber) + "/replay/"
+	resp, err := c.doRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrReplayUnavailable
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return nil, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, string(body))
+	}
+
+	page, err := io.ReadAll(io.LimitReader(resp.Body, replayPageLimit))
+	if err != nil {
+		return nil, fmt.Errorf("error reading response: %w", err)
+	}
+	return parseReplayForm(string(page))
+}
+
+// parseReplayForm extracts the scripts from the textareas of the replay form
+func parseReplayForm(page string) (*models.ReplayScripts, error) {
+	scripts := &models.ReplayScripts{}
+	found := false
+	for _, match := range textareaPattern.FindAllStringSubmatch(page, -1) {
+		name := html.UnescapeString(match[1])
+		// Browsers drop a newline right after the opening tag; so do we
+		script := strings.TrimPrefix(html.UnescapeString(match[2]), "\n")
+		if name == "mainScript" {
+			scripts.Main = script
+			found = true
+			continue
+		}
+		scripts.Loaded = append(scripts.Loaded, models.LoadedScript{Name: name, Script: script})
+	}
+	if !found {
+		return nil, ErrReplayUnavailable
+	}
+	return scripts, nil
+}
+
+// Replay runs a Pipeline build again with the given scripts. It returns the
+// number the new build is expected to get, read from the job just before
+// submitting; a concurrent trigger of the same job may take it instead.
+func (c *Client) Replay(ctx context.Context, jobName string, buildNumber int, scripts models.ReplayScripts) (int, error) {
+	var job struct {
+		NextBuildNumber int `json:"nextBuildNumber"`
+	}
+	if err := c.getJSON(ctx, "/job/"+encodeJobPath(jobName)+"/api/json?"+buildTreeParam("nextBuildNumber"), &job); err != nil {
+		return 0, err
+	}
+
+	submitted := map[string]string{"mainScript": scripts.Main}
+	for _, l := range scripts.Loaded {
+		submitted[l.Name] = l.Script
+	}
+	payload, err := json.Marshal(submitted)
+	if err != nil {
+		return 0, fmt.Errorf("error encoding replay scripts: %w", err)
+	}
+
+	form := url.Values{"json": {string(payload)}}
+	path := "/job/" + encodeJobPath(jobName) + "/" + itoa(buildNumber) + "/replay/run"
+	resp, err := c.doRequest(ctx, http.MethodPost, path, strings.NewReader(form.Encode()))
+	if err != nil {
+		if errors.Is(err, ErrForbidden) {
+			c.denyPermission(PermReplay, jobName, buildNumber)
+		}
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	// Jenkins answers with a redirect to the job page
+	if resp.StatusCode == http.StatusNotFound {
+		return 0, ErrReplayUnavailable
+	}
+	if resp.StatusCode >= 400 {
+		return 0, fmt.Errorf("unexpected status %d replaying build", resp.StatusCode)
+	}
+	return job.NextBuildNumber, nil
+}
@@ -0,0 +1,137 @@
+package jenkins
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/elogrono/jenkins-tui/internal/config"
+)
+
+// newTransport builds the HTTP transport for a profile, applying proxy,
+// custom CA bundle and client certificate settings
+func newTransport(p *config.Profile) (*http.Transport, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: p.InsecureSkipTLSVerify,
+	}
+
+	if p.CACertFile != "" {
+		pool, err := loadCertPool(p.CACertFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if p.ClientCertFile != "" || p.ClientKeyFile != "" {
+		if p.ClientCertFile == "" || p.ClientKeyFile == "" {
+			return nil, fmt.Errorf("client_cert_file and client_key_file must be set together")
+		}
+		cert, err := tls.LoadX509KeyPair(p.ClientCertFile, p.ClientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("error loading client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	proxy, err := proxyFunc(p.ProxyURL, p.NoProxy)
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.Transport{
+		Proxy:               proxy,
+		TLSClientConfig:     tlsConfig,
+		MaxIdleConns:        10,
+		IdleConnTimeout:     30 * time.Second,
+		DisableCompression:  false,
+		MaxIdleConnsPerHost: 5,
+	}, nil
+}
+
+// loadCertPool returns the system pool extended with the certificates in the PEM bundle
+func loadCertPool(path string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading CA bundle: %w", err)
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no PEM certificates found in CA bundle %s", path)
+	}
+	return pool, nil
+}
+
+// proxyFunc returns the proxy selector for the transport. Without a profile
+// proxy the standard HTTP(S)_PROXY environment variables are honoured.
+func proxyFunc(proxyURL, noProxy string) (func(*http.Request) (*url.URL, error), error) {
+	if proxyURL == "" {
+		return http.ProxyFromEnvironment, nil
+	}
+
+	u, err := url.Parse(proxyURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy_url: %w", err)
+	}
+
+	rules := parseNoProxy(noProxy)
+	return func(req *http.Request) (*url.URL, error) {
+		if bypassProxy(req.URL.Hostname(), rules) {
+			return nil, nil
+		}
+		return u, nil
+	}, nil
+}
+
+// parseNoProxy splits a comma separated no_proxy list into lower-cased entries
+func parseNoProxy(noProxy string) []string {
+	var rules []string
+	for _, entry := range strings.Split(noProxy, ",") {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		if entry != "" {
+			rules = append(rules, entry)
+		}
+	}
+	return rules
+}
+
+// bypassProxy reports whether the host matches one of the no_proxy rules.
+// Supported forms: "*", exact hosts, domain suffixes ("example.com" or
+// ".example.com"), IP addresses and CIDR ranges.
+func bypassProxy(host string, rules []string) bool {
+	host = strings.ToLower(host)
+	ip := net.ParseIP(host)
+
+	for _, rule := range rules {
+		if rule == "*" {
+			return true
+		}
+		if _, cidr, err := net.ParseCIDR(rule); err == nil {
+			if ip != nil && cidr.Contains(ip) {
+				return true
+			}
+			continue
+		}
+		if ruleIP := net.ParseIP(rule); ruleIP != nil {
+			if ip != nil && ruleIP.Equal(ip) {
+				return true
+			}
+			continue
+		}
+		domain := strings.TrimPrefix(rule, ".")
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,76 @@
+package jenkins
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/elogrono/jenkins-tui/internal/config"
+)
+
+func TestBypassProxy(t *testing.T) {
+	rules := parseNoProxy(" localhost, .internal.example.com,10.0.0.0/8 ,192.168.1.5")
+
+	tests := []struct {
+		host     string
+		expected bool
+	}{
+		{"localhost", true},
+		{"internal.example.com", true},
+		{"ci.internal.example.com", true},
+		{"example.com", false},
+		{"10.1.2.3", true},
+		{"11.1.2.3", false},
+		{"192.168.1.5", true},
+		{"jenkins.example.com", false},
+	}
+
+	for _, tt := range tests {
+		if got := bypassProxy(tt.host, rules); got != tt.expected {
+			t.Errorf("bypassProxy(%q) = %v, want %v", tt.host, got, tt.expected)
+		}
+	}
+
+	if !bypassProxy("anything", parseNoProxy("*")) {
+		t.Error("expected wildcard rule to bypass every host")
+	}
+}
+
+func TestProxyFunc(t *testing.T) {
+	proxy, err := proxyFunc("http://proxy.example.com:3128", "localhost")
+	if err != nil {
+		t.Fatalf("proxyFunc failed: %v", err)
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, "https://jenkins.example.com/api/json", nil)
+	u, err := proxy(req)
+	if err != nil || u == nil || u.Host != "proxy.example.com:3128" {
+		t.Errorf("expected request to use proxy, got %v (err %v)", u, err)
+	}
+
+	req, _ = http.NewRequest(http.MethodGet, "http://localhost:8080/api/json", nil)
+	u, _ = proxy(req)
+	if u != nil {
+		t.Errorf("expected localhost to bypass proxy, got %v", u)
+	}
+}
+
+func TestNewTransportInvalidCABundle(t *testing.T) {
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caFile, []byte("not a certificate"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := newTransport(&config.Profile{CACertFile: caFile})
+	if err == nil {
+		t.Error("expected error for CA bundle without certificates")
+	}
+}
+
+func TestNewTransportClientCertRequiresKey(t *testing.T) {
+	_, err := newTransport(&config.Profile{ClientCertFile: "client.crt"})
+	if err == nil {
+		t.Error("expected error when client key is missing")
+	}
+}
@@ -0,0 +1,168 @@
+package components
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/elogrono/jenkins-tui/internal/ui/theme"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// SPARKLINE COMPONENT
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// sparkLevels are the block characters used for one-line charts, lowest first
+var sparkLevels = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline renders a series as a single line of block characters
+type Sparkline struct {
+	Values []float64
+	Width  int // 0 uses one cell per value
+	Min    float64
+	Max    float64 // Min == Max scales to the data
+	Color  lipgloss.Color
+}
+
+// NewSparkline creates a new sparkline
+func NewSparkline(values []float64) *Sparkline {
+	return &Sparkline{
+		Values: values,
+		Color:  theme.Secondary,
+	}
+}
+
+// SetWidth limits the sparkline to the most recent width values
+func (s *Sparkline) SetWidth(width int) *Sparkline {
+	s.Width = width
+	return s
+}
+
+// SetRange fixes the scale instead of fitting it to the data
+func (s *Sparkline) SetRange(min, max float64) *Sparkline {
+	s.Min = min
+	s.Max = max
+	return s
+}
+
+// SetColor sets the sparkline color
+func (s *Sparkline) SetColor(color lipgloss.Color) *Sparkline {
+	s.Color = color
+	return s
+}
+
+// Render renders the sparkline
+func (s *Sparkline) Render() string {
+	values := s.Values
+	if s.Width > 0 && len(values) > s.Width {
+		values = values[len(values)-s.Width:]
+	}
+	if len(values) == 0 {
+		return theme.MutedStyle.Render("no data")
+	}
+
+	lo, hi := s.Min, s.Max
+	if lo == hi {
+		lo, hi = values[0], values[0]
+		for _, v := range values {
+			lo = min(lo, v)
+			hi = max(hi, v)
+		}
+	}
+
+	var b strings.Builder
+	for _, v := range values {
+		b.WriteRune(sparkLevels[scaleLevel(v, lo, hi, len(sparkLevels))])
+	}
+	return lipgloss.NewStyle().Foreground(s.Color).Render(b.String())
+}
+
+// scaleLevel maps v in [lo, hi] to 0..levels-1
+func scaleLevel(v, lo, hi float64, levels int) int {
+	if hi <= lo {
+		return levels / 2
+	}
+	level := int((v - lo) / (hi - lo) * float64(levels-1))
+	return max(0, min(level, levels-1))
+}
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// BAR CHART COMPONENT
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// ChartBar is one bar of a bar chart
+type ChartBar struct {
+	Value float64
+	Color lipgloss.Color
+}
+
+// BarChart renders vertical bars, one column per value, scaled to Height rows
+type BarChart struct {
+	Bars   []ChartBar
+	Height int
+	Width  int // 0 renders all bars
+}
+
+// NewBarChart creates a new bar chart
+func NewBarChart(height int) *BarChart {
+	return &BarChart{
+		Height: max(height, 1),
+	}
+}
+
+// AddBar adds a bar
+func (c *BarChart) AddBar(value float64, color lipgloss.Color) *BarChart {
+	c.Bars = append(c.Bars, ChartBar{Value: value, Color: color})
+	return c
+}
+
+// SetWidth limits the chart to the most recent width bars
+func (c *BarChart) SetWidth(width int) *BarChart {
+	c.Width = width
+	return c
+}
+
+// Render renders the chart; each row uses eighth-block characters for precision
+func (c *BarChart) Render() string {
+	bars := c.Bars
+	if c.Width > 0 && len(bars) > c.Width {
+		bars = bars[len(bars)-c.Width:]
+	}
+	if len(bars) == 0 {
+		return theme.MutedStyle.Render("no data")
+	}
+
+	var peak float64
+	for _, bar := range bars {
+		peak = max(peak, bar.Value)
+	}
+
+	// Height of each bar in eighths of a row
+	eighths := make([]int, len(bars))
+	for i, bar := range bars {
+		if peak > 0 {
+			eighths[i] = int(bar.Value / peak * float64(c.Height*8))
+		}
+		if bar.Value > 0 && eighths[i] == 0 {
+			eighths[i] = 1
+		}
+	}
+
+	rows := make([]string, c.Height)
+	for r := 0; r < c.Height; r++ {
+		floor := (c.Height - 1 - r) * 8
+		var b strings.Builder
+		for i, bar := range bars {
+			fill := eighths[i] - floor
+			cell := " "
+			switch {
+			case fill >= 8:
+				cell = "█"
+			case fill > 0:
+				cell = string(sparkLevels[fill-1])
+			}
+			b.WriteString(lipgloss.NewStyle().Foreground(bar.Color).Render(cell))
+		}
+		rows[r] = b.String()
+	}
+	return strings.Join(rows, "\n")
+}
@@ -0,0 +1,56 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestSparklineRender(t *testing.T) {
+	out := NewSparkline([]float64{0, 5, 10}).Render()
+	for _, s := range []string{"▁", "▄", "█"} {
+		if !strings.Contains(out, s) {
+			t.Errorf("expected %q in sparkline %q", s, out)
+		}
+	}
+
+	// Width keeps only the most recent values
+	out = NewSparkline([]float64{10, 0, 10}).SetWidth(2).Render()
+	if lipgloss.Width(out) != 2 || !strings.Contains(out, "▁█") {
+		t.Errorf("expected the last two values, got %q", out)
+	}
+
+	// A fixed range does not stretch a flat series
+	out = NewSparkline([]float64{1, 1}).SetRange(0, 1).Render()
+	if !strings.Contains(out, "██") {
+		t.Errorf("expected full bars at the top of the range, got %q", out)
+	}
+
+	if out := NewSparkline(nil).Render(); !strings.Contains(out, "no data") {
+		t.Errorf("expected 'no data', got %q", out)
+	}
+}
+
+func TestBarChartRender(t *testing.T) {
+	chart := NewBarChart(2).
+		AddBar(10, "#ffffff").
+		AddBar(5, "#ffffff").
+		AddBar(0, "#ffffff")
+
+	lines := strings.Split(chart.Render(), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(lines))
+	}
+	if lipgloss.Width(lines[0]) != 3 || lipgloss.Width(lines[1]) != 3 {
+		t.Errorf("expected one column per bar, got %q", lines)
+	}
+	// The tallest bar fills both rows, the half bar only the bottom one
+	if !strings.Contains(lines[0], "█") || strings.Count(lines[1], "█") != 2 {
+		t.Errorf("unexpected bars:\n%s", strings.Join(lines, "\n"))
+	}
+
+	if out := NewBarChart(3).Render(); !strings.Contains(out, "no data") {
+		t.Errorf("expected 'no data', got %q", out)
+	}
+}
@@ -142,6 +142,7 @@ func (k *KPICard) Render() string {
 type Shortkey struct {
 	Key         string
 	Description string
+	Disabled    bool
 }
 
 // ShortkeyBar represents a bar of shortcuts
@@ -165,6 +166,20 @@ func (s *ShortkeyBar) Add(key, description string) *ShortkeyBar {
 	return s
 }
 
+// AddDisabled adds a greyed out shortkey for an action that is not available
+func (s *ShortkeyBar) AddDisabled(key, description string) *ShortkeyBar {
+	s.Keys = append(s.Keys, Shortkey{Key: key, Description: description, Disabled: true})
+	return s
+}
+
+// AddIf adds the shortkey enabled or disabled depending on the condition
+func (s *ShortkeyBar) AddIf(enabled bool, key, description string) *ShortkeyBar {
+	if enabled {
+		return s.Add(key, description)
+	}
+	return s.AddDisabled(key, description)
+}
+
 // Render renders the shortkey bar
 func (s *ShortkeyBar) Render() string {
 	keyStyle := lipgloss.NewStyle().
@@ -177,8 +192,16 @@ func (s *ShortkeyBar) Render() string {
 	sepStyle := lipgloss.NewStyle().
 		Foreground(theme.Border)
 
+	disabledStyle := lipgloss.NewStyle().
+		Foreground(theme.Disabled).
+		Strikethrough(true)
+
 	var items []string
 	for _, k := range s.Keys {
+		if k.Disabled {
+			items = append(items, disabledStyle.Render(k.Key+" "+k.Description))
+			continue
+		}
 		items = append(items, keyStyle.Render(k.Key)+" "+descStyle.Render(k.Description))
 	}
 
@@ -300,6 +323,7 @@ const (
 
 // Stage represents a pipeline stage for display
 type Stage struct {
+	ID       string
 	Name     string
 	Status   StageStatus
 	Duration time.Duration
@@ -338,35 +362,7 @@ func (s *StagePipeline) Render() string {
 
 	for i, stage := range s.Stages {
 		// Stage icon and color based on status
-		var icon string
-		var color lipgloss.Color
-
-		switch stage.Status {
-		case StageStatusSuccess:
-			icon = theme.IconSuccess
-			color = theme.BuildSuccess
-		case StageStatusFailure:
-			icon = theme.IconFailure
-			color = theme.BuildFailure
-		case StageStatusRunning:
-			icon = theme.IconRunning
-			color = theme.BuildRunning
-		case StageStatusPending:
-			icon = theme.IconPending
-			color = theme.Muted
-		case StageStatusSkipped:
-			icon = theme.IconAborted
-			color = theme.BuildAborted
-		case StageStatusUnstable:
-			icon = theme.IconWarning
-			color = theme.BuildUnstable
-		case StageStatusAborted:
-			icon = theme.IconAborted
-			color = theme.BuildAborted
-		default:
-			icon = theme.IconUnknown
-			color = theme.Muted
-		}
+		icon, color := StageIcon(stage.Status)
 
 		iconStyle := lipgloss.NewStyle().Foreground(color).Bold(true)
 		nameStyle := lipgloss.NewStyle().Foreground(theme.Foreground)
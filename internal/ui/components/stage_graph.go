@@ -0,0 +1,220 @@
+package components
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/elogrono/jenkins-tui/internal/ui/theme"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// STAGE GRAPH COMPONENT
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// StageGraph renders a pipeline as columns of stages connected left to right.
+// A column with several stages is a parallel block: the previous column fans
+// out into it and it fans back in to the next one.
+type StageGraph struct {
+	Columns  [][]Stage
+	Width    int
+	Selected string // ID of the highlighted stage
+}
+
+// NewStageGraph creates a new stage graph
+func NewStageGraph(width int) *StageGraph {
+	return &StageGraph{
+		Width: width,
+	}
+}
+
+// AddColumn adds a column of stages; more than one stage means parallel branches
+func (g *StageGraph) AddColumn(stages ...Stage) *StageGraph {
+	if len(stages) > 0 {
+		g.Columns = append(g.Columns, stages)
+	}
+	return g
+}
+
+// SetSelected highlights the stage with the given ID
+func (g *StageGraph) SetSelected(id string) *StageGraph {
+	g.Selected = id
+	return g
+}
+
+// Render renders the graph, wrapping onto further rows when it is wider than Width
+func (g *StageGraph) Render() string {
+	if len(g.Columns) == 0 {
+		return theme.MutedStyle.Render("No stages")
+	}
+
+	// Pre-render every column so widths are known
+	labels := make([][]string, len(g.Columns))
+	widths := make([]int, len(g.Columns))
+	for i, column := range g.Columns {
+		labels[i] = make([]string, len(column))
+		for j, stage := range column {
+			labels[i][j] = g.renderNode(stage)
+			widths[i] = max(widths[i], lipgloss.Width(labels[i][j]))
+		}
+	}
+
+	// Split columns into blocks that fit the available width
+	var blocks []string
+	start, used := 0, 0
+	for i := range g.Columns {
+		need := widths[i] + stageConnectorWidth
+		if g.Width > 0 && i > start && used+need > g.Width {
+			blocks = append(blocks, g.renderBlock(labels, widths, start, i))
+			start, used = i, 0
+		}
+		used += need
+	}
+	blocks = append(blocks, g.renderBlock(labels, widths, start, len(g.Columns)))
+
+	continuation := theme.MutedStyle.Render("↳ ")
+	for i := 1; i < len(blocks); i++ {
+		blocks[i] = indentLines(blocks[i], continuation, "  ")
+	}
+	return strings.Join(blocks, "\n")
+}
+
+// stageConnectorWidth is the width of the connector drawn between two columns
+const stageConnectorWidth = 6
+
+// renderBlock renders columns [from, to) side by side with connectors
+func (g *StageGraph) renderBlock(labels [][]string, widths []int, from, to int) string {
+	rows := 1
+	for i := from; i < to; i++ {
+		rows = max(rows, len(labels[i]))
+	}
+
+	lineStyle := lipgloss.NewStyle().Foreground(theme.Border)
+	lines := make([]strings.Builder, rows)
+
+	for i := from; i < to; i++ {
+		last := i == to-1
+		for r := 0; r < rows; r++ {
+			// Node, padded with a line when it connects onwards
+			if r < len(labels[i]) {
+				lines[r].WriteString(labels[i][r])
+				pad := widths[i] - lipgloss.Width(labels[i][r])
+				if !last {
+					lines[r].WriteString(lineStyle.Render(" " + strings.Repeat("─", pad)))
+				} else {
+					lines[r].WriteString(strings.Repeat(" ", pad+1))
+				}
+			} else {
+				lines[r].WriteString(strings.Repeat(" ", widths[i]+1))
+			}
+
+			if !last {
+				lines[r].WriteString(lineStyle.Render(stageConnector(r, len(labels[i]), len(labels[i+1]))))
+			}
+		}
+	}
+
+	out := make([]string, rows)
+	for r := range lines {
+		out[r] = strings.TrimRight(lines[r].String(), " ")
+	}
+	return strings.Join(out, "\n")
+}
+
+// stageConnector draws row r of the junction between a column with `in`
+// stages and a column with `out` stages
+func stageConnector(r, in, out int) string {
+	n := max(in, out)
+	if r >= n {
+		return strings.Repeat(" ", stageConnectorWidth-1)
+	}
+
+	left, right := r < in, r < out
+	up, down := r > 0, r < n-1
+
+	var junction string
+	switch {
+	case left && right && up && down:
+		junction = "┼"
+	case left && right && down:
+		junction = "┬"
+	case left && right && up:
+		junction = "┴"
+	case left && up && down:
+		junction = "┤"
+	case right && up && down:
+		junction = "├"
+	case left && up:
+		junction = "┘"
+	case right && up:
+		junction = "└"
+	case left && down:
+		junction = "┐"
+	case right && down:
+		junction = "┌"
+	case up && down:
+		junction = "│"
+	default:
+		junction = "─"
+	}
+
+	leftPart, rightPart := " ", "   "
+	if left {
+		leftPart = "─"
+	}
+	if right {
+		rightPart = "─▶ "
+	}
+	return leftPart + junction + rightPart
+}
+
+// renderNode renders a single stage as "icon name duration"
+func (g *StageGraph) renderNode(stage Stage) string {
+	icon, color := StageIcon(stage.Status)
+
+	nameStyle := lipgloss.NewStyle().Foreground(theme.Foreground)
+	if g.Selected != "" && stage.ID == g.Selected {
+		nameStyle = nameStyle.Foreground(theme.Primary).Bold(true).Underline(true)
+	}
+
+	node := lipgloss.NewStyle().Foreground(color).Bold(true).Render(icon) + " " +
+		nameStyle.Render(truncate(stage.Name, 24))
+	if stage.Duration > 0 || stage.Status == StageStatusSuccess {
+		node += " " + lipgloss.NewStyle().Foreground(theme.ForegroundDim).Render(formatDuration(stage.Duration))
+	}
+	return node
+}
+
+// StageIcon returns the icon and color for a stage status
+func StageIcon(status StageStatus) (string, lipgloss.Color) {
+	switch status {
+	case StageStatusSuccess:
+		return theme.IconSuccess, theme.BuildSuccess
+	case StageStatusFailure:
+		return theme.IconFailure, theme.BuildFailure
+	case StageStatusRunning:
+		return theme.IconRunning, theme.BuildRunning
+	case StageStatusPending:
+		return theme.IconPending, theme.Muted
+	case StageStatusSkipped:
+		return theme.IconAborted, theme.BuildAborted
+	case StageStatusUnstable:
+		return theme.IconWarning, theme.BuildUnstable
+	case StageStatusAborted:
+		return theme.IconAborted, theme.BuildAborted
+	default:
+		return theme.IconUnknown, theme.Muted
+	}
+}
+
+// indentLines prefixes the first line with first and the others with rest
+func indentLines(s, first, rest string) string {
+	lines := strings.Split(s, "\n")
+	for i := range lines {
+		if i == 0 {
+			lines[i] = first + lines[i]
+		} else {
+			lines[i] = rest + lines[i]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,79 @@
+package components
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStageGraphRenderEmpty(t *testing.T) {
+	if out := NewStageGraph(80).Render(); !strings.Contains(out, "No stages") {
+		t.Errorf("expected 'No stages', got %q", out)
+	}
+}
+
+func TestStageGraphRenderParallel(t *testing.T) {
+	g := NewStageGraph(200).
+		AddColumn(Stage{ID: "1", Name: "Build", Status: StageStatusSuccess, Duration: 12 * time.Second}).
+		AddColumn(Stage{ID: "2", Name: "Tests", Status: StageStatusFailure, Duration: time.Minute}).
+		AddColumn(
+			Stage{ID: "3", Name: "Unit", Status: StageStatusSuccess, Duration: 30 * time.Second},
+			Stage{ID: "4", Name: "Integration", Status: StageStatusFailure, Duration: time.Minute},
+			Stage{ID: "5", Name: "Lint", Status: StageStatusSuccess, Duration: 4 * time.Second},
+		).
+		AddColumn(Stage{ID: "6", Name: "Deploy", Status: StageStatusSkipped})
+
+	out := g.Render()
+	lines := strings.Split(out, "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 rows for a 3-way parallel block, got %d:\n%s", len(lines), out)
+	}
+
+	// Fan-out from Tests and fan-in to Deploy
+	for _, junction := range []string{"┬", "├", "└", "┤", "┘"} {
+		if !strings.Contains(out, junction) {
+			t.Errorf("expected junction %q in graph:\n%s", junction, out)
+		}
+	}
+	for _, name := range []string{"Build", "Tests", "Unit", "Integration", "Lint", "Deploy", "1m 0s"} {
+		if !strings.Contains(out, name) {
+			t.Errorf("expected %q in graph", name)
+		}
+	}
+	if !strings.Contains(lines[0], "Deploy") || strings.Contains(lines[1], "Deploy") {
+		t.Errorf("expected Deploy on the first row only:\n%s", out)
+	}
+}
+
+func TestStageGraphWraps(t *testing.T) {
+	g := NewStageGraph(30)
+	for _, name := range []string{"Checkout", "Compile", "Package", "Publish"} {
+		g.AddColumn(Stage{ID: name, Name: name, Status: StageStatusSuccess, Duration: time.Second})
+	}
+
+	out := g.Render()
+	if !strings.Contains(out, "↳") {
+		t.Errorf("expected graph to wrap onto a continuation row:\n%s", out)
+	}
+}
+
+func TestStageConnector(t *testing.T) {
+	tests := []struct {
+		r, in, out int
+		expected   string
+	}{
+		{0, 1, 1, "───▶ "},
+		{0, 1, 2, "─┬─▶ "},
+		{1, 1, 2, " └─▶ "},
+		{0, 2, 1, "─┬─▶ "},
+		{1, 2, 1, "─┘   "},
+		{1, 3, 3, "─┼─▶ "},
+		{2, 1, 2, "     "},
+	}
+
+	for _, tt := range tests {
+		if got := stageConnector(tt.r, tt.in, tt.out); got != tt.expected {
+			t.Errorf("stageConnector(%d, %d, %d) = %q, want %q", tt.r, tt.in, tt.out, got, tt.expected)
+		}
+	}
+}
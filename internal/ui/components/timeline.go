@@ -0,0 +1,160 @@
+package components
+
+import (
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/elogrono/jenkins-tui/internal/ui/theme"
+)
+
+// ═══════════════════════════════════════════════════════════════════════════════
+// TIMELINE COMPONENT
+// ═══════════════════════════════════════════════════════════════════════════════
+
+// TimelineBar is one stage on the timeline
+type TimelineBar struct {
+	Name     string
+	Status   StageStatus
+	Start    time.Duration // Offset from the start of the run
+	Duration time.Duration
+	Pause    time.Duration // Part of Duration spent paused (input, waiting)
+	Critical bool          // Stage is on the critical path
+	Indent   int           // Nesting level, for parallel branches
+}
+
+// Timeline renders stages as horizontal bars on a shared time axis
+type Timeline struct {
+	Bars       []TimelineBar
+	Width      int
+	LabelWidth int
+	Offset     int // First bar to render
+	MaxRows    int // 0 renders all bars
+}
+
+// NewTimeline creates a new timeline
+func NewTimeline(width int) *Timeline {
+	return &Timeline{
+		Width:      width,
+		LabelWidth: 24,
+	}
+}
+
+// AddBar adds a stage bar
+func (t *Timeline) AddBar(bar TimelineBar) *Timeline {
+	t.Bars = append(t.Bars, bar)
+	return t
+}
+
+// SetWindow limits rendering to maxRows bars starting at offset
+func (t *Timeline) SetWindow(offset, maxRows int) *Timeline {
+	t.Offset = offset
+	t.MaxRows = maxRows
+	return t
+}
+
+// Span returns the total time covered by the bars
+func (t *Timeline) Span() time.Duration {
+	var span time.Duration
+	for _, bar := range t.Bars {
+		span = max(span, bar.Start+bar.Duration)
+	}
+	return span
+}
+
+// Render renders the bars followed by the time axis and a legend
+func (t *Timeline) Render() string {
+	if len(t.Bars) == 0 {
+		return theme.MutedStyle.Render("No stages")
+	}
+
+	// label │ bar area │ duration
+	durWidth := 9
+	area := max(t.Width-t.LabelWidth-durWidth-3, 10)
+	span := t.Span()
+	if span <= 0 {
+		span = time.Millisecond
+	}
+	scale := func(d time.Duration) int {
+		return int(int64(d) * int64(area) / int64(span))
+	}
+
+	axisStyle := lipgloss.NewStyle().Foreground(theme.Border)
+
+	end := len(t.Bars)
+	if t.MaxRows > 0 {
+		end = min(t.Offset+t.MaxRows, end)
+	}
+
+	var lines []string
+	for _, bar := range t.Bars[min(t.Offset, end):end] {
+		label := strings.Repeat("  ", bar.Indent) + bar.Name
+		label = truncate(label, t.LabelWidth)
+		labelStyle := lipgloss.NewStyle().Foreground(theme.Foreground).Width(t.LabelWidth)
+		if bar.Critical {
+			labelStyle = labelStyle.Foreground(theme.Highlight).Bold(true)
+		}
+
+		startCol := min(scale(bar.Start), area-1)
+		width := max(scale(bar.Duration), 1)
+		width = min(width, area-startCol)
+		pauseWidth := min(scale(bar.Pause), width)
+		if bar.Pause > 0 && pauseWidth == 0 && width > 1 {
+			pauseWidth = 1
+		}
+		runWidth := width - pauseWidth
+
+		_, color := StageIcon(bar.Status)
+		runChar := "█"
+		if bar.Critical {
+			runChar = "▇"
+		}
+		runStyle := lipgloss.NewStyle().Foreground(color)
+		if bar.Critical {
+			runStyle = runStyle.Background(theme.Surface).Underline(true)
+		}
+		pauseStyle := lipgloss.NewStyle().Foreground(theme.Pending)
+
+		row := strings.Repeat(" ", startCol) +
+			runStyle.Render(strings.Repeat(runChar, runWidth)) +
+			pauseStyle.Render(strings.Repeat("░", pauseWidth)) +
+			strings.Repeat(" ", area-startCol-width)
+
+		lines = append(lines, labelStyle.Render(label)+" "+
+			axisStyle.Render("│")+row+axisStyle.Render("│")+" "+
+			lipgloss.NewStyle().Foreground(theme.ForegroundDim).Render(formatDuration(bar.Duration)))
+	}
+
+	lines = append(lines, strings.Repeat(" ", t.LabelWidth+1)+axisStyle.Render("└"+strings.Repeat("─", area)+"┘"))
+	lines = append(lines, strings.Repeat(" ", t.LabelWidth+2)+t.renderAxisLabels(area, span))
+
+	legend := lipgloss.NewStyle().Foreground(theme.BuildSuccess).Render("█") + theme.MutedStyle.Render(" running  ") +
+		lipgloss.NewStyle().Foreground(theme.Pending).Render("░") + theme.MutedStyle.Render(" paused  ") +
+		lipgloss.NewStyle().Foreground(theme.Highlight).Bold(true).Render("name") + theme.MutedStyle.Render(" critical path")
+	lines = append(lines, "", legend)
+
+	return strings.Join(lines, "\n")
+}
+
+// renderAxisLabels places time labels at the quarters of the axis
+func (t *Timeline) renderAxisLabels(area int, span time.Duration) string {
+	axis := []rune(strings.Repeat(" ", area+8))
+	for q := 0; q <= 4; q++ {
+		label := "0"
+		if q > 0 {
+			label = formatDuration(span * time.Duration(q) / 4)
+		}
+		pos := area * q / 4
+		if q == 4 {
+			pos = max(area-len(label)+1, 0)
+		} else if q > 0 {
+			pos -= len(label) / 2
+		}
+		for i, r := range label {
+			if pos+i >= 0 && pos+i < len(axis) {
+				axis[pos+i] = r
+			}
+		}
+	}
+	return theme.MutedStyle.Render(strings.TrimRight(string(axis), " "))
+}
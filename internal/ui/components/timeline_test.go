@@ -0,0 +1,52 @@
+package components
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTimelineRenderEmpty(t *testing.T) {
+	if out := NewTimeline(80).Render(); !strings.Contains(out, "No stages") {
+		t.Errorf("expected 'No stages', got %q", out)
+	}
+}
+
+func TestTimelineRender(t *testing.T) {
+	tl := NewTimeline(100).
+		AddBar(TimelineBar{Name: "Build", Status: StageStatusSuccess, Duration: 20 * time.Second, Critical: true}).
+		AddBar(TimelineBar{Name: "Approve", Status: StageStatusSuccess, Start: 20 * time.Second, Duration: 60 * time.Second, Pause: 50 * time.Second, Critical: true}).
+		AddBar(TimelineBar{Name: "Deploy", Status: StageStatusFailure, Start: 80 * time.Second, Duration: 20 * time.Second, Critical: true})
+
+	if tl.Span() != 100*time.Second {
+		t.Errorf("expected span 100s, got %v", tl.Span())
+	}
+
+	out := tl.Render()
+	lines := strings.Split(out, "\n")
+
+	for _, s := range []string{"Build", "Approve", "Deploy", "░", "1m 40s", "critical path"} {
+		if !strings.Contains(out, s) {
+			t.Errorf("expected %q in timeline:\n%s", s, out)
+		}
+	}
+
+	// Later stages start further right on the shared axis
+	buildCol := strings.Index(lines[0], "▇")
+	deployCol := strings.Index(lines[2], "▇")
+	if buildCol < 0 || deployCol <= buildCol {
+		t.Errorf("expected Deploy bar to start after Build bar:\n%s", out)
+	}
+}
+
+func TestTimelineWindow(t *testing.T) {
+	tl := NewTimeline(80)
+	for _, name := range []string{"one", "two", "three", "four"} {
+		tl.AddBar(TimelineBar{Name: name, Status: StageStatusSuccess, Duration: time.Second})
+	}
+
+	out := tl.SetWindow(1, 2).Render()
+	if strings.Contains(out, "one") || !strings.Contains(out, "two") || !strings.Contains(out, "three") || strings.Contains(out, "four") {
+		t.Errorf("expected only bars two and three:\n%s", out)
+	}
+}
@@ -59,6 +59,7 @@ const (
 	IconRunning  = "●"
 	IconPending  = "○"
 	IconAborted  = "⊘"
+	IconPaused   = "⏸"
 	IconNotBuilt = "○"
 	IconDisabled = "⊝"
 	IconUnknown  = "?"
@@ -91,6 +92,9 @@ const (
 	IconLink      = "🔗"
 	IconStar      = "★"
 	IconStarEmpty = "☆"
+	IconLock      = "🔒"
+	IconUnlock    = "🔓"
+	IconMarked    = "◆"
 
 	// Progress
 	IconSpinner1 = "⠋"
@@ -442,22 +446,26 @@ func BuildStatusIcon(color string) string {
 
 // BuildResultStyle returns the style based on result string
 func BuildResultStyle(result string) lipgloss.Style {
-	base := lipgloss.NewStyle().Bold(true)
+	return lipgloss.NewStyle().Bold(true).Foreground(BuildResultColor(result))
+}
+
+// BuildResultColor returns the color for a build result string
+func BuildResultColor(result string) lipgloss.Color {
 	switch result {
 	case "SUCCESS":
-		return base.Foreground(BuildSuccess)
+		return BuildSuccess
 	case "FAILURE":
-		return base.Foreground(BuildFailure)
+		return BuildFailure
 	case "UNSTABLE":
-		return base.Foreground(BuildUnstable)
+		return BuildUnstable
 	case "ABORTED":
-		return base.Foreground(BuildAborted)
+		return BuildAborted
 	case "RUNNING", "BUILDING":
-		return base.Foreground(BuildRunning)
+		return BuildRunning
 	case "NOT_BUILT":
-		return base.Foreground(BuildNotBuilt)
+		return BuildNotBuilt
 	default:
-		return base.Foreground(Muted)
+		return Muted
 	}
 }
 